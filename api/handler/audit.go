@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"test/api/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAuditLog godoc
+// @Router       /audit [GET]
+// @Summary      Get audit log
+// @Description  get changes made through the api, newest first, before and after keep only the changed fields
+// @Tags         audit
+// @Accept       json
+// @Produce      json
+// @Param        page query string false "page"
+// @Param        limit query string false "limit"
+// @Param        cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param        count query bool false "count all rows, true by default"
+// @Param        entity query string false "entity, like products or branches"
+// @Param        entity_id query string false "entity_id"
+// @Param        actor_id query string false "actor_id"
+// @Param        from query string false "changes made on this date or later, YYYY-MM-DD"
+// @Param        to query string false "changes made on this date or earlier, YYYY-MM-DD"
+// @Success      200  {object}  models.AuditLogsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetAuditLog(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	auditLogs, err := h.services.Audit().GetList(ctx, models.GetListRequest{
+		Page:        page,
+		Limit:       limit,
+		Cursor:      c.Query("cursor"),
+		SkipCount:   skipCount,
+		Entity:      c.Query("entity"),
+		EntityID:    c.Query("entity_id"),
+		ActorID:     c.Query("actor_id"),
+		CreatedFrom: c.Query("from"),
+		CreatedTo:   c.Query("to"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while getting audit log", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, auditLogs)
+}
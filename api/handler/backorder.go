@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"test/api/models"
+	"test/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBackorder godoc
+// @Router       /backorder/{id} [GET]
+// @Summary      Get a backorder
+// @Description  get a backorder placed at checkout for a product that was out of stock
+// @Tags         backorder
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "backorder_id"
+// @Success      200  {object}  models.Backorder
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetBackorder(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	backorder, err := h.services.Backorder().Get(ctx, models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		handleBackorderError(c, "error is while getting backorder", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, backorder)
+}
+
+// GetBackorderList godoc
+// @Router       /backorders [GET]
+// @Summary      Get backorders
+// @Description  get backorders of a customer or a product, newest first
+// @Tags         backorder
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param 		 count query bool false "count all rows, true by default"
+// @Param 		 customer_id query string false "customer_id"
+// @Param 		 product_id query string false "product_id"
+// @Param 		 status query string false "open, fulfilled or cancelled"
+// @Success      200  {object}  models.BackordersResponse
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetBackorderList(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	backorders, err := h.services.Backorder().GetList(ctx, models.GetListRequest{
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		UserID:    c.Query("customer_id"),
+		ProductID: c.Query("product_id"),
+		Status:    c.Query("status"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while getting backorders", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, backorders)
+}
+
+// CancelBackorder godoc
+// @Router       /backorder/{id}/cancel [POST]
+// @Summary      Cancel a backorder
+// @Description  cancel an open backorder, fulfilled backorders are released instead
+// @Tags         backorder
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "backorder_id"
+// @Success      200  {object}  models.Backorder
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CancelBackorder(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	backorder, err := h.services.Backorder().Cancel(ctx, models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		handleBackorderError(c, "error is while cancelling backorder", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, backorder)
+}
+
+// CollectBackorder godoc
+// @Router       /backorder/{id}/collect [POST]
+// @Summary      Collect a backorder
+// @Description  sell a fulfilled backorder to its customer at the price quoted when it was ordered, in a basket of its own
+// @Tags         backorder
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "backorder_id"
+// @Success      200  {object}  models.Backorder
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CollectBackorder(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	backorder, err := h.services.Backorder().Collect(ctx, models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		handleBackorderError(c, "error is while collecting backorder", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, backorder)
+}
+
+// ReleaseBackorder godoc
+// @Router       /backorder/{id}/release [POST]
+// @Summary      Release a backorder
+// @Description  give up a fulfilled backorder, its quantity goes back to stock and its lots
+// @Tags         backorder
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "backorder_id"
+// @Success      200  {object}  models.Backorder
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ReleaseBackorder(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	backorder, err := h.services.Backorder().Release(ctx, models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		handleBackorderError(c, "error is while releasing backorder", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, backorder)
+}
+
+func handleBackorderError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		handleResponse(c, "backorder not found", http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrBackorderClosed), errors.Is(err, service.ErrBackorderNotHeld):
+		handleResponse(c, msg, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrNotEnoughCash):
+		handleResponse(c, msg, http.StatusBadRequest, err.Error())
+	default:
+		handleResponse(c, msg, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -2,12 +2,15 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"test/api/models"
+	"test/service"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // CreateBasket godoc
@@ -29,7 +32,7 @@ func (h Handler) CreateBasket(c *gin.Context) {
 		handleResponse(c, "error is while decoding", http.StatusBadRequest, err.Error())
 		return
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	res, err := h.services.Basket().Create(ctx, createBasket)
 	if err != nil {
@@ -48,6 +51,7 @@ func (h Handler) CreateBasket(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "basket_id"
 // @Success      201  {object}  models.Basket
+// @Header       201  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
@@ -56,12 +60,13 @@ func (h Handler) GetBasket(c *gin.Context) {
 
 	uid := c.Param("id")
 
-	basket, err := h.services.Basket().Get(context.Background(), uid)
+	basket, err := h.services.Basket().Get(requestContext(c), uid)
 	if err != nil {
 		handleResponse(c, "error is while getting by id", http.StatusInternalServerError, err)
 		return
 	}
 
+	setETag(c, basket.Version)
 	handleResponse(c, "", http.StatusOK, basket)
 }
 
@@ -74,6 +79,8 @@ func (h Handler) GetBasket(c *gin.Context) {
 // @Produce      json
 // @Param        page query string false "page"
 // @Param        limit query string false "limit"
+// @Param        cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param        count query bool false "count all rows, true by default"
 // @Param        search query string false "search"
 // @Success      201  {object}  models.BasketResponse
 // @Failure      400  {object}  models.Response
@@ -101,12 +108,20 @@ func (h Handler) GetBasketList(c *gin.Context) {
 	}
 
 	search = c.Query("search")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	baskets, err := h.services.Basket().GetList(ctx, models.GetListRequest{
-		Page:   page,
-		Limit:  limit,
-		Search: search,
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		Search:    search,
 	})
 	if err != nil {
 		handleResponse(c, "error is while getting list", http.StatusInternalServerError, err)
@@ -125,9 +140,13 @@ func (h Handler) GetBasketList(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "basket_id"
 // @Param        basket body models.UpdateBasket false "basket"
+// @Param        If-Match header string true "version of the row being updated"
 // @Success      201  {object}  models.Basket
+// @Header       201  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateBasket(c *gin.Context) {
 	updatedBasket := models.UpdateBasket{}
@@ -139,14 +158,91 @@ func (h Handler) UpdateBasket(c *gin.Context) {
 	}
 
 	updatedBasket.ID = uid
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading version", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedBasket.Version = version
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	basket, err := h.services.Basket().Update(ctx, updatedBasket)
 	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "basket was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
 		handleResponse(c, "error is while updating basket", http.StatusInternalServerError, err)
 		return
 	}
 
+	setETag(c, basket.Version)
+	handleResponse(c, "", http.StatusOK, basket)
+}
+
+// PatchBasket godoc
+// @Router       /basket/{id} [PATCH]
+// @Summary      Patch a basket
+// @Description  change only the fields of the basket present in the body, a JSON merge patch
+// @Tags         basket
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "basket_id"
+// @Param        basket body models.UpdateBasket false "fields of the basket to change"
+// @Param        If-Match header string false "version of the row being patched, the version field of the body is used without it"
+// @Success      200  {object}  models.Basket
+// @Header       200  {string}  ETag  "version of the row"
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) PatchBasket(c *gin.Context) {
+	patch, err := readPatch(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	basket, err := h.services.Basket().Patch(ctx, patch)
+	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "basket was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidPatch) {
+			handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "basket not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while patching basket", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	setETag(c, basket.Version)
 	handleResponse(c, "", http.StatusOK, basket)
 }
 
@@ -164,7 +260,7 @@ func (h Handler) UpdateBasket(c *gin.Context) {
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteBasket(c *gin.Context) {
 	uid := c.Param("id")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	if err := h.services.Basket().Delete(ctx, models.PrimaryKey{ID: uid}); err != nil {
 		handleResponse(c, "error is while deleting basket", http.StatusInternalServerError, err)
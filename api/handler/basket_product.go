@@ -2,13 +2,16 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"test/api/models"
+	"test/service"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 // CreateBasketProduct godoc
@@ -30,7 +33,7 @@ func (h Handler) CreateBasketProduct(c *gin.Context) {
 		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
 		return
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	createdBasketProduct, err := h.services.BasketProduct().Create(ctx, basketProduct)
 	if err != nil {
@@ -50,12 +53,13 @@ func (h Handler) CreateBasketProduct(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "basketProduct_id"
 // @Success      201  {object}  models.BasketProduct
+// @Header       201  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) GetBasketProduct(c *gin.Context) {
 	uid := c.Param("id")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.BasketProduct().Get(ctx, models.PrimaryKey{ID: uid})
 	if err != nil {
@@ -63,6 +67,7 @@ func (h Handler) GetBasketProduct(c *gin.Context) {
 		return
 	}
 
+	setETag(c, resp.Version)
 	handleResponse(c, "", http.StatusOK, resp)
 }
 
@@ -75,6 +80,8 @@ func (h Handler) GetBasketProduct(c *gin.Context) {
 // @Produce      json
 // @Param        page query string false "page"
 // @Param        limit query string false "limit"
+// @Param        cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param        count query bool false "count all rows, true by default"
 // @Param        search query string false "search"
 // @Param 	  	 basket_id query string false "basket_id"
 // @Success      201  {object}  models.BasketProductResponse
@@ -115,13 +122,21 @@ func (h Handler) GetBasketProductList(c *gin.Context) {
 
 		basketID = bUID.String()
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.BasketProduct().GetList(ctx, models.GetListRequest{
-		Page:     page,
-		Limit:    limit,
-		Search:   search,
-		BasketID: basketID,
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		Search:    search,
+		BasketID:  basketID,
 	})
 
 	handleResponse(c, "", http.StatusOK, resp)
@@ -136,9 +151,13 @@ func (h Handler) GetBasketProductList(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "basketProduct_id"
 // @Param        basketProduct body models.UpdateBasketProduct false "basketProduct"
+// @Param        If-Match header string true "version of the row being updated"
 // @Success      201  {object}  models.BasketProduct
+// @Header       201  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateBasketProduct(c *gin.Context) {
 	basketProduct := models.UpdateBasketProduct{}
@@ -150,17 +169,94 @@ func (h Handler) UpdateBasketProduct(c *gin.Context) {
 	}
 
 	basketProduct.ID = uid
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading version", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	basketProduct.Version = version
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.BasketProduct().Update(ctx, basketProduct)
 	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "basket product was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
 		handleResponse(c, "error is while updating basket", http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	setETag(c, resp.Version)
 	handleResponse(c, "", http.StatusOK, resp)
 }
 
+// PatchBasketProduct godoc
+// @Router       /basketProduct/{id} [PATCH]
+// @Summary      Patch a basket product
+// @Description  change only the fields of the basket product present in the body, a JSON merge patch
+// @Tags         basketProduct
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "basketProduct_id"
+// @Param        basketProduct body models.UpdateBasketProduct false "fields of the basket product to change"
+// @Param        If-Match header string false "version of the row being patched, the version field of the body is used without it"
+// @Success      200  {object}  models.BasketProduct
+// @Header       200  {string}  ETag  "version of the row"
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) PatchBasketProduct(c *gin.Context) {
+	patch, err := readPatch(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	basketProduct, err := h.services.BasketProduct().Patch(ctx, patch)
+	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "basket product was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidPatch) {
+			handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "basket product not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while patching basket product", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	setETag(c, basketProduct.Version)
+	handleResponse(c, "", http.StatusOK, basketProduct)
+}
+
 // DeleteBasketProduct godoc
 // @Router       /basketProduct/{id} [Delete]
 // @Summary      Delete basketProduct
@@ -175,7 +271,7 @@ func (h Handler) UpdateBasketProduct(c *gin.Context) {
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteBasketProduct(c *gin.Context) {
 	uid := c.Param("id")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	if err := h.services.BasketProduct().Delete(ctx, models.PrimaryKey{ID: uid}); err != nil {
 		handleResponse(c, "error is while deleting", http.StatusInternalServerError, err.Error())
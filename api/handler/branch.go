@@ -1,12 +1,14 @@
 package handler
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"test/api/models"
+	"test/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // CreateBranch godoc
@@ -29,7 +31,7 @@ func (h Handler) CreateBranch(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.services.Branch().Create(context.Background(), branch)
+	resp, err := h.services.Branch().Create(requestContext(c), branch)
 	if err != nil {
 		handleResponse(c, "error is while creating branch", http.StatusInternalServerError, err.Error())
 		return
@@ -47,18 +49,20 @@ func (h Handler) CreateBranch(c *gin.Context) {
 // @Produce      json
 // @Param 		 id path string true "branch_id"
 // @Success      200  {object}  models.Branch
+// @Header       200  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) GetBranch(c *gin.Context) {
 	uid := c.Param("id")
 
-	branch, err := h.services.Branch().Get(context.Background(), uid)
+	branch, err := h.services.Branch().Get(requestContext(c), uid)
 	if err != nil {
 		handleResponse(c, "error is while getting by id", http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	setETag(c, branch.Version)
 	handleResponse(c, "", http.StatusOK, branch)
 }
 
@@ -71,6 +75,8 @@ func (h Handler) GetBranch(c *gin.Context) {
 // @Produce      json
 // @Param 		 page query string false "page"
 // @Param 		 limit query string false "limit"
+// @Param 		 cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param 		 count query bool false "count all rows, true by default"
 // @Param 		 search query string false "search"
 // @Success      200  {object}  models.BranchResponse
 // @Failure      400  {object}  models.Response
@@ -99,10 +105,18 @@ func (h Handler) GetBranchList(c *gin.Context) {
 
 	search = c.Query("search")
 
-	branches, err := h.services.Branch().GetList(context.Background(), models.GetListRequest{
-		Page:   page,
-		Limit:  limit,
-		Search: search,
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	branches, err := h.services.Branch().GetList(requestContext(c), models.GetListRequest{
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		Search:    search,
 	})
 
 	if err != nil {
@@ -121,9 +135,13 @@ func (h Handler) GetBranchList(c *gin.Context) {
 // @Produce      json
 // @Param 		 id path string true "branch_id"
 // @Param 		 branch body models.UpdateBranch false "branch"
+// @Param        If-Match header string true "version of the row being updated"
 // @Success      200  {object}  models.Branch
+// @Header       200  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateBranch(c *gin.Context) {
 	uid := c.Param("id")
@@ -136,19 +154,92 @@ func (h Handler) UpdateBranch(c *gin.Context) {
 
 	branch.ID = uid
 
-	updatedBranch, err := h.services.Branch().Update(context.Background(), branch)
+	version, err := ifMatchVersion(c)
 	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading version", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	branch.Version = version
+
+	updatedBranch, err := h.services.Branch().Update(requestContext(c), branch)
+	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "branch was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
 		handleResponse(c, "error is while updating branch", http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	setETag(c, updatedBranch.Version)
 	handleResponse(c, "", http.StatusOK, updatedBranch)
 }
 
+// PatchBranch godoc
+// @Router       /branch/{id} [PATCH]
+// @Summary      Patch a branch
+// @Description  change only the fields of the branch present in the body, a JSON merge patch
+// @Tags         branch
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "branch_id"
+// @Param        branch body models.UpdateBranch false "fields of the branch to change"
+// @Param        If-Match header string false "version of the row being patched, the version field of the body is used without it"
+// @Success      200  {object}  models.Branch
+// @Header       200  {string}  ETag  "version of the row"
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) PatchBranch(c *gin.Context) {
+	patch, err := readPatch(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	branch, err := h.services.Branch().Patch(requestContext(c), patch)
+	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "branch was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidPatch) {
+			handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "branch not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while patching branch", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	setETag(c, branch.Version)
+	handleResponse(c, "", http.StatusOK, branch)
+}
+
 // DeleteBranch godoc
 // @Router       /branch/{id} [DELETE]
 // @Summary      Delete branch
-// @Description  delete branch
+// @Description  delete branch, refused with its users and products listed unless branches cascade on delete
 // @Tags         branch
 // @Accept       json
 // @Produce      json
@@ -156,11 +247,17 @@ func (h Handler) UpdateBranch(c *gin.Context) {
 // @Success      200  {object}  models.Response
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteBranch(c *gin.Context) {
 	uid := c.Param("id")
 
-	if err := h.services.Branch().Delete(context.Background(), models.PrimaryKey{ID: uid}); err != nil {
+	if err := h.services.Branch().Delete(requestContext(c), models.PrimaryKey{ID: uid}); err != nil {
+		if dependentsErr := (service.DependentsError{}); errors.As(err, &dependentsErr) {
+			handleResponse(c, "branch is in use", http.StatusConflict, dependentsErr)
+			return
+		}
+
 		handleResponse(c, "error is while delting branch", http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"test/api/models"
+	"test/pkg/money"
+	"test/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// SetBranchProductPrices godoc
+// @Router       /branch/{id}/prices [PUT]
+// @Summary      Set branch product prices
+// @Description  create or update price overrides of products in branch
+// @Tags         branch
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "branch_id"
+// @Param 		 prices body models.SetBranchProductPrices true "prices"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) SetBranchProductPrices(c *gin.Context) {
+	request := models.SetBranchProductPrices{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.BranchID = c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	if err := h.services.BranchProductPrice().SetMultiple(ctx, request); err != nil {
+		handleBranchProductPriceError(c, "error is while setting branch product prices", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "branch product prices saved")
+}
+
+// GetBranchProductPrices godoc
+// @Router       /branch/{id}/prices [GET]
+// @Summary      Get branch product prices
+// @Description  get price overrides of products in branch
+// @Tags         branch
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "branch_id"
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param 		 count query bool false "count all rows, true by default"
+// @Success      200  {object}  models.BranchProductPricesResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetBranchProductPrices(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	prices, err := h.services.BranchProductPrice().GetList(ctx, models.GetListRequest{
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		BranchID:  c.Param("id"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while getting branch product prices", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, prices)
+}
+
+// DeleteBranchProductPrices godoc
+// @Router       /branch/{id}/prices [DELETE]
+// @Summary      Delete branch product prices
+// @Description  delete price overrides of products in branch, products fall back to base price
+// @Tags         branch
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "branch_id"
+// @Param 		 product_ids body models.DeleteBranchProductPrices true "product_ids"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteBranchProductPrices(c *gin.Context) {
+	request := models.DeleteBranchProductPrices{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.BranchID = c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	if err := h.services.BranchProductPrice().DeleteMultiple(ctx, request); err != nil {
+		handleResponse(c, "error is while deleting branch product prices", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "branch product prices deleted")
+}
+
+// handleBranchProductPriceError answers invalid prices with a bad request and a missing branch or product with not found.
+func handleBranchProductPriceError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidBranchProductPrice), errors.Is(err, money.ErrCurrencyMismatch):
+		handleResponse(c, msg, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		handleResponse(c, "branch or product not found", http.StatusNotFound, err.Error())
+	default:
+		handleResponse(c, msg, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"test/api/models"
+	"test/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// BulkUpdateProductPrices godoc
+// @Router       /products/prices [POST]
+// @Summary      Update prices in bulk
+// @Description  set, increase or decrease the price of the filtered products by amount in minor units or by percent,
+// @Description  in one transaction, every changed price is kept in the price history
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        update body models.BulkPriceUpdate true "update"
+// @Success      200  {object}  models.BulkResult
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) BulkUpdateProductPrices(c *gin.Context) {
+	update := models.BulkPriceUpdate{}
+
+	if err := c.ShouldBindJSON(&update); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*30)
+	defer cancel()
+
+	result, err := h.services.Product().BulkUpdatePrices(ctx, update)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBulk) || errors.Is(err, service.ErrPriceNotPositive) {
+			handleResponse(c, "error is while updating prices", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while updating prices", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, result)
+}
+
+// AdjustProductStock godoc
+// @Router       /products/stock [POST]
+// @Summary      Adjust stock in bulk
+// @Description  add to or remove from the stock of products with a reason, in one transaction,
+// @Description  every change is kept in the stock movements of the product
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        adjustment body models.BulkStockAdjustment true "adjustment"
+// @Success      200  {object}  models.BulkResult
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) AdjustProductStock(c *gin.Context) {
+	adjustment := models.BulkStockAdjustment{}
+
+	if err := c.ShouldBindJSON(&adjustment); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*30)
+	defer cancel()
+
+	result, err := h.services.Product().AdjustStock(ctx, adjustment)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBulk) || errors.Is(err, service.ErrNegativeStock) {
+			handleResponse(c, "error is while adjusting stock", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "product not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while adjusting stock", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, result)
+}
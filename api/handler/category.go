@@ -2,12 +2,15 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"test/api/models"
+	"test/service"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // CreateCategory godoc
@@ -29,10 +32,15 @@ func (h Handler) CreateCategory(c *gin.Context) {
 		handleResponse(c, "error is while reading body from client", http.StatusBadRequest, err.Error())
 		return
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.Category().Create(ctx, category)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidTaxRate) {
+			handleResponse(c, "error is while creating category", http.StatusBadRequest, err.Error())
+			return
+		}
+
 		handleResponse(c, "error is while creating category", http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -49,12 +57,13 @@ func (h Handler) CreateCategory(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "category_id"
 // @Success      201  {object}  models.Category
+// @Header       201  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) GetCategory(c *gin.Context) {
 	uid := c.Param("id")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	category, err := h.services.Category().Get(ctx, models.PrimaryKey{ID: uid})
 	if err != nil {
@@ -62,6 +71,7 @@ func (h Handler) GetCategory(c *gin.Context) {
 		return
 	}
 
+	setETag(c, category.Version)
 	handleResponse(c, "", http.StatusOK, category)
 }
 
@@ -74,6 +84,8 @@ func (h Handler) GetCategory(c *gin.Context) {
 // @Produce      json
 // @Param        page query string false "page"
 // @Param        limit query string false "limit"
+// @Param        cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param        count query bool false "count all rows, true by default"
 // @Param        search query string false "search"
 // @Success      201  {object}  models.CategoryResponse
 // @Failure      400  {object}  models.Response
@@ -101,12 +113,20 @@ func (h Handler) GetCategoryList(c *gin.Context) {
 	}
 
 	search = c.Query("search")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	categories, err := h.services.Category().GetList(ctx, models.GetListRequest{
-		Page:   page,
-		Limit:  limit,
-		Search: search,
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		Search:    search,
 	})
 
 	if err != nil {
@@ -117,6 +137,29 @@ func (h Handler) GetCategoryList(c *gin.Context) {
 	handleResponse(c, "", http.StatusOK, categories)
 }
 
+// GetCategoryTree godoc
+// @Router       /categories/tree [GET]
+// @Summary      Get category tree
+// @Description  get all categories as a tree, subcategories are nested in children
+// @Tags         category
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.CategoryTree
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetCategoryTree(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	tree, err := h.services.Category().GetTree(ctx)
+	if err != nil {
+		handleResponse(c, "error is while getting category tree", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, tree)
+}
+
 // UpdateCategory godoc
 // @Router       /category/{id} [PUT]
 // @Summary      Update category
@@ -126,9 +169,13 @@ func (h Handler) GetCategoryList(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "category_id"
 // @Param        category body models.UpdateCategory false "category"
+// @Param        If-Match header string true "version of the row being updated"
 // @Success      201  {object}  models.Response
+// @Header       201  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateCategory(c *gin.Context) {
 	category := models.UpdateCategory{}
@@ -140,21 +187,112 @@ func (h Handler) UpdateCategory(c *gin.Context) {
 	}
 
 	category.ID = uid
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading version", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	category.Version = version
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	updatedCategory, err := h.services.Category().Update(ctx, category)
 	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "category was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
+		if errors.Is(err, service.ErrCategoryCycle) {
+			handleResponse(c, "error is while moving category", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidTaxRate) {
+			handleResponse(c, "error is while updating category", http.StatusBadRequest, err.Error())
+			return
+		}
 		handleResponse(c, "error is while getting by id", http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	setETag(c, updatedCategory.Version)
 	handleResponse(c, "", http.StatusOK, updatedCategory)
 }
 
+// PatchCategory godoc
+// @Router       /category/{id} [PATCH]
+// @Summary      Patch a category
+// @Description  change only the fields of the category present in the body, a JSON merge patch
+// @Tags         category
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "category_id"
+// @Param        category body models.UpdateCategory false "fields of the category to change"
+// @Param        If-Match header string false "version of the row being patched, the version field of the body is used without it"
+// @Success      200  {object}  models.Category
+// @Header       200  {string}  ETag  "version of the row"
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) PatchCategory(c *gin.Context) {
+	patch, err := readPatch(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	category, err := h.services.Category().Patch(ctx, patch)
+	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "category was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidPatch) || errors.Is(err, service.ErrInvalidTaxRate) {
+			handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, service.ErrCategoryCycle) {
+			handleResponse(c, "error is while moving category", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "category not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while patching category", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	setETag(c, category.Version)
+	handleResponse(c, "", http.StatusOK, category)
+}
+
 // DeleteCategory godoc
 // @Router       /category/{id} [DELETE]
 // @Summary      Delete category
-// @Description  delete category
+// @Description  delete category, refused with its subcategories and products listed unless categories cascade on delete
 // @Tags         category
 // @Accept       json
 // @Produce      json
@@ -162,12 +300,17 @@ func (h Handler) UpdateCategory(c *gin.Context) {
 // @Success      201  {object}  models.Response
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteCategory(c *gin.Context) {
 	uid := c.Param("id")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	if err := h.services.Category().Delete(ctx, models.PrimaryKey{ID: uid}); err != nil {
+		if dependentsErr := (service.DependentsError{}); errors.As(err, &dependentsErr) {
+			handleResponse(c, "category is in use", http.StatusConflict, dependentsErr)
+			return
+		}
 		handleResponse(c, "error is while delete", http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"test/api/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SetCurrencyRate godoc
+// @Router       /currency-rate [POST]
+// @Summary      Set currency rate
+// @Description  set the price of one unit of currency in the base currency on a date, an existing rate on that date is replaced
+// @Tags         currency
+// @Accept       json
+// @Produce      json
+// @Param 		 rate body models.CreateCurrencyRate true "rate"
+// @Success      200  {object}  models.CurrencyRate
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) SetCurrencyRate(c *gin.Context) {
+	rate := models.CreateCurrencyRate{}
+
+	if err := c.ShouldBindJSON(&rate); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	resp, err := h.services.CurrencyRate().Set(ctx, rate)
+	if err != nil {
+		handleResponse(c, "error is while setting currency rate", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, resp)
+}
+
+// GetCurrencyRateList godoc
+// @Router       /currency-rates [GET]
+// @Summary      Get currency rate list
+// @Description  get currency rates, newest first
+// @Tags         currency
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param 		 count query bool false "count all rows, true by default"
+// @Param 		 currency query string false "currency"
+// @Success      200  {object}  models.CurrencyRatesResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetCurrencyRateList(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	rates, err := h.services.CurrencyRate().GetList(ctx, models.GetListRequest{
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		Currency:  c.Query("currency"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while getting currency rates", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, rates)
+}
+
+// DeleteCurrencyRate godoc
+// @Router       /currency-rate/{id} [DELETE]
+// @Summary      Delete currency rate
+// @Description  delete currency rate
+// @Tags         currency
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "currency_rate_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteCurrencyRate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		handleResponse(c, "invalid uuid type", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	if err = h.services.CurrencyRate().Delete(ctx, models.PrimaryKey{ID: id.String()}); err != nil {
+		handleResponse(c, "error is while deleting currency rate", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "currency rate deleted!")
+}
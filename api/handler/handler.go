@@ -1,12 +1,23 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"test/api/models"
+	"test/pkg/audit"
 	"test/pkg/logger"
 	"test/service"
 )
 
+// RequestIDHeader carries the id of a request, see requestIDMiddleware of the router.
+const RequestIDHeader = "X-Request-ID"
+
 type Handler struct {
 	services service.IServiceManager
 	log      logger.ILogger
@@ -59,4 +70,77 @@ func handleResponseNew(c *gin.Context, log logger.ILogger, msg string, statusCod
 	resp.Data = data
 
 	c.JSON(resp.StatusCode, resp)
-}
\ No newline at end of file
+}
+
+// actorID returns the id of the user making the request, sent by clients in the X-User-ID header.
+func actorID(c *gin.Context) string {
+	return c.GetHeader("X-User-ID")
+}
+
+// requestContext carries who made the request and from where, services write it to the audit log.
+func requestContext(c *gin.Context) context.Context {
+	return audit.WithMeta(context.Background(), audit.Meta{
+		ActorID:   actorID(c),
+		RequestID: c.GetHeader(RequestIDHeader),
+		IP:        c.ClientIP(),
+	})
+}
+
+var errVersionRequired = errors.New("version of the row is required, send it in If-Match")
+
+// ifMatchVersion returns the version of the row an update was made on, taken from the If-Match header.
+func ifMatchVersion(c *gin.Context) (int, error) {
+	ifMatch := strings.Trim(strings.TrimPrefix(c.GetHeader("If-Match"), "W/"), `"`)
+	if ifMatch == "" {
+		return 0, errVersionRequired
+	}
+
+	return strconv.Atoi(ifMatch)
+}
+
+// readPatch reads the JSON merge patch of the row with the id of the path, the version it was made on
+// is taken from If-Match or else from the version field of the patch.
+func readPatch(c *gin.Context) (models.Patch, error) {
+	uid, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return models.Patch{}, err
+	}
+
+	document, err := c.GetRawData()
+	if err != nil {
+		return models.Patch{}, err
+	}
+
+	body := struct {
+		Version int `json:"version"`
+	}{}
+	if err = json.Unmarshal(document, &body); err != nil {
+		return models.Patch{}, err
+	}
+
+	version, err := ifMatchVersion(c)
+	if errors.Is(err, errVersionRequired) && body.Version > 0 {
+		version, err = body.Version, nil
+	}
+	if err != nil {
+		return models.Patch{}, err
+	}
+
+	return models.Patch{
+		ID:       uid.String(),
+		Version:  version,
+		Document: document,
+	}, nil
+}
+
+// setETag sends the version of the row as its entity tag, clients send it back in If-Match.
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// listSkipCount reads the count query parameter of list endpoints, lists are counted unless count=false.
+func listSkipCount(c *gin.Context) (bool, error) {
+	count, err := strconv.ParseBool(c.DefaultQuery("count", "true"))
+
+	return !count, err
+}
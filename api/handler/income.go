@@ -2,12 +2,15 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"test/api/models"
+	"test/service"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // CreateIncome godoc
@@ -22,7 +25,7 @@ import (
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) CreateIncome(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.Income().Create(ctx)
 	if err != nil {
@@ -47,7 +50,7 @@ func (h Handler) CreateIncome(c *gin.Context) {
 // @Failure      500  {object}  models.Response
 func (h Handler) GetIncome(c *gin.Context) {
 	uid := c.Param("id")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.Income().Get(ctx, models.PrimaryKey{ID: uid})
 	if err != nil {
@@ -67,6 +70,8 @@ func (h Handler) GetIncome(c *gin.Context) {
 // @Produce      json
 // @Param        page query string false "page"
 // @Param        limit query string false "limit"
+// @Param        cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param        count query bool false "count all rows, true by default"
 // @Param        search query string false "search"
 // @Success      201  {object}  models.IncomesResponse
 // @Failure      400  {object}  models.Response
@@ -94,12 +99,20 @@ func (h Handler) GetIncomeList(c *gin.Context) {
 	}
 
 	search = c.Query("search")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.Income().GetList(ctx, models.GetListRequest{
-		Page:   page,
-		Limit:  limit,
-		Search: search,
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		Search:    search,
 	})
 	if err != nil {
 		handleResponse(c, "error is while getting incomes list", http.StatusInternalServerError, err.Error())
@@ -122,7 +135,7 @@ func (h Handler) GetIncomeList(c *gin.Context) {
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteIncome(c *gin.Context) {
 	uid := c.Param("id")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	if err := h.services.Income().Delete(ctx, models.PrimaryKey{ID: uid}); err != nil {
 		handleResponse(c, "error is while deleting basket", http.StatusInternalServerError, err)
@@ -131,3 +144,39 @@ func (h Handler) DeleteIncome(c *gin.Context) {
 
 	handleResponse(c, "", http.StatusOK, nil)
 }
+
+// PostIncome godoc
+// @Router       /income/{id}/post [POST]
+// @Summary      Post income to stock
+// @Description  receive the goods of the income: every line becomes a lot of its product with its lot number,
+// @Description  expiry date and cost, and its quantity is added to stock. An income is posted once.
+// @Tags         income
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "income_id"
+// @Success      200  {object}  models.Income
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) PostIncome(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*30)
+	defer cancel()
+
+	income, err := h.services.Income().Post(ctx, models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			handleResponse(c, "income not found", http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrIncomePosted):
+			handleResponse(c, "error is while posting income", http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrEmptyIncome):
+			handleResponse(c, "error is while posting income", http.StatusBadRequest, err.Error())
+		default:
+			handleResponse(c, "error is while posting income", http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, income)
+}
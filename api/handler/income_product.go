@@ -2,13 +2,16 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"test/api/models"
+	"test/service"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // CreateIncomeProducts godoc
@@ -22,6 +25,7 @@ import (
 // @Success      201  {object}  string
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) CreateIncomeProducts(c *gin.Context) {
 	var incomeProducts = models.CreateIncomeProducts{}
@@ -30,11 +34,11 @@ func (h Handler) CreateIncomeProducts(c *gin.Context) {
 		handleResponse(c, "error while binding json", http.StatusBadRequest, err.Error())
 		return
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	err := h.services.IncomeProduct().CreateMultiple(ctx, incomeProducts)
 	if err != nil {
-		handleResponse(c, "error while creating incomeProducts", http.StatusInternalServerError, err.Error())
+		handleIncomeProductsError(c, "error while creating incomeProducts", err)
 		return
 	}
 
@@ -50,6 +54,8 @@ func (h Handler) CreateIncomeProducts(c *gin.Context) {
 // @Produce      json
 // @Param        page query string false "page"
 // @Param        limit query string false "limit"
+// @Param        cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param        count query bool false "count all rows, true by default"
 // @Param        search query string false "search"
 // @Success      201  {object}  models.IncomesResponse
 // @Failure      400  {object}  models.Response
@@ -78,12 +84,20 @@ func (h Handler) GetIncomeProductsList(c *gin.Context) {
 
 	search = c.Query("search")
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.IncomeProduct().GetList(ctx, models.GetListRequest{
-		Page:   page,
-		Limit:  limit,
-		Search: search,
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		Search:    search,
 	})
 	if err != nil {
 		fmt.Println("error is while getting list", err.Error())
@@ -104,6 +118,7 @@ func (h Handler) GetIncomeProductsList(c *gin.Context) {
 // @Success      201  {object}  models.Response
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateIncomeProducts(c *gin.Context) {
 	body := models.UpdateIncomeProducts{}
@@ -111,10 +126,10 @@ func (h Handler) UpdateIncomeProducts(c *gin.Context) {
 		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
 		return
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	if err := h.services.IncomeProduct().UpdateMultiple(ctx, body); err != nil {
-		handleResponse(c, "error is while updating multiple income products", http.StatusInternalServerError, err.Error())
+		handleIncomeProductsError(c, "error is while updating multiple income products", err)
 		return
 	}
 
@@ -124,12 +139,12 @@ func (h Handler) UpdateIncomeProducts(c *gin.Context) {
 // DeleteIncomeProducts godoc
 // @Router       /income_products [Delete]
 // @Summary      Delete income products
-// @Description  delete income products
+// @Description  delete income products, lines of posted incomes are kept and answered as refused
 // @Tags         income_products
 // @Accept       json
 // @Produce      json
 // @Param        ids body models.DeleteIncomeProducts false "ids"
-// @Success      201  {object}  models.Response
+// @Success      200  {object}  models.DeletedIncomeProducts
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
@@ -137,14 +152,30 @@ func (h Handler) DeleteIncomeProducts(c *gin.Context) {
 	body := models.DeleteIncomeProducts{}
 	if err := c.ShouldBindJSON(&body); err != nil {
 		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
-	if err := h.services.IncomeProduct().DeleteMultiple(ctx, body); err != nil {
+	result, err := h.services.IncomeProduct().DeleteMultiple(ctx, body)
+	if err != nil {
 		handleResponse(c, "error is deleting income product", http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	handleResponse(c, "success", http.StatusOK, "income products deleted!")
+	handleResponse(c, "success", http.StatusOK, result)
+}
+
+// handleIncomeProductsError answers lines of posted incomes with a conflict, they cannot change anymore.
+func handleIncomeProductsError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, service.ErrIncomePosted):
+		handleResponse(c, msg, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidExpiryDate):
+		handleResponse(c, msg, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		handleResponse(c, "income not found", http.StatusNotFound, err.Error())
+	default:
+		handleResponse(c, msg, http.StatusInternalServerError, err.Error())
+	}
 }
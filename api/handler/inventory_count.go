@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"test/api/models"
+	"test/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateInventoryCount godoc
+// @Router       /inventory-count [POST]
+// @Summary      Open an inventory count
+// @Description  open a count of the products of the branch, or of the given categories and their subcategories,
+// @Description  with the quantities expected now
+// @Tags         inventory-count
+// @Accept       json
+// @Produce      json
+// @Param        X-User-ID header string false "id of the user opening the count"
+// @Param        count body models.CreateInventoryCount true "count"
+// @Success      201  {object}  models.InventoryCount
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateInventoryCount(c *gin.Context) {
+	count := models.CreateInventoryCount{}
+
+	if err := c.ShouldBindJSON(&count); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	count.CreatedBy = actorID(c)
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	inventoryCount, err := h.services.InventoryCount().Create(ctx, count)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "branch not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while creating inventory count", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, inventoryCount)
+}
+
+// GetInventoryCount godoc
+// @Router       /inventory-count/{id} [GET]
+// @Summary      Get an inventory count
+// @Description  get the count with the expected and counted quantities of its products, their variances and cost
+// @Tags         inventory-count
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "inventory_count_id"
+// @Success      200  {object}  models.InventoryCount
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetInventoryCount(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	inventoryCount, err := h.services.InventoryCount().Get(ctx, models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "inventory count not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while getting inventory count", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, inventoryCount)
+}
+
+// GetInventoryCountList godoc
+// @Router       /inventory-counts [GET]
+// @Summary      Get inventory counts
+// @Description  get inventory counts without their lines, newest first
+// @Tags         inventory-count
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param 		 count query bool false "count all rows, true by default"
+// @Param 		 branch_id query string false "branch_id"
+// @Param 		 status query string false "open, approved or cancelled"
+// @Success      200  {object}  models.InventoryCountsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetInventoryCountList(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	counts, err := h.services.InventoryCount().GetList(ctx, models.GetListRequest{
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		BranchID:  c.Query("branch_id"),
+		Status:    c.Query("status"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while getting inventory counts", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, counts)
+}
+
+// CountInventory godoc
+// @Router       /inventory-count/{id}/entries [POST]
+// @Summary      Enter counted quantities
+// @Description  add counted quantities to an open count, a product may be counted in parts and by several counters,
+// @Description  its counted quantity is the sum of its entries
+// @Tags         inventory-count
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "inventory_count_id"
+// @Param        X-User-ID header string false "id of the counter"
+// @Param        entries body models.CountInventory true "entries"
+// @Success      200  {object}  models.InventoryCount
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CountInventory(c *gin.Context) {
+	request := models.CountInventory{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.ID, request.CountedBy = c.Param("id"), actorID(c)
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	inventoryCount, err := h.services.InventoryCount().Count(ctx, request)
+	if err != nil {
+		handleInventoryCountError(c, "error is while counting inventory", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, inventoryCount)
+}
+
+// ApproveInventoryCount godoc
+// @Router       /inventory-count/{id}/approve [POST]
+// @Summary      Approve an inventory count
+// @Description  close the count and post the variances of the counted products as stock movements,
+// @Description  products that were not counted keep their stock
+// @Tags         inventory-count
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "inventory_count_id"
+// @Param        X-User-ID header string false "id of the user approving the count"
+// @Success      200  {object}  models.InventoryCount
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ApproveInventoryCount(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*30)
+	defer cancel()
+
+	inventoryCount, err := h.services.InventoryCount().Approve(ctx, models.PrimaryKey{ID: c.Param("id")}, actorID(c))
+	if err != nil {
+		handleInventoryCountError(c, "error is while approving inventory count", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, inventoryCount)
+}
+
+// CancelInventoryCount godoc
+// @Router       /inventory-count/{id}/cancel [POST]
+// @Summary      Cancel an inventory count
+// @Description  close the count without changing the stock
+// @Tags         inventory-count
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "inventory_count_id"
+// @Param        X-User-ID header string false "id of the user cancelling the count"
+// @Success      200  {object}  models.InventoryCount
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CancelInventoryCount(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	inventoryCount, err := h.services.InventoryCount().Cancel(ctx, models.PrimaryKey{ID: c.Param("id")}, actorID(c))
+	if err != nil {
+		handleInventoryCountError(c, "error is while cancelling inventory count", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, inventoryCount)
+}
+
+func handleInventoryCountError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		handleResponse(c, "inventory count not found", http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInventoryCountClosed):
+		handleResponse(c, msg, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidInventoryCount) || errors.Is(err, service.ErrNegativeStock):
+		handleResponse(c, msg, http.StatusBadRequest, err.Error())
+	default:
+		handleResponse(c, msg, http.StatusInternalServerError, err.Error())
+	}
+}
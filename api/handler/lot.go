@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"test/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetExpiringLots godoc
+// @Router       /lots/expiring [GET]
+// @Summary      Get expiring lots
+// @Description  get the lots of the branch with stock left that expire within days, expired ones included,
+// @Description  the ones expiring first first. Stock of expired lots is not sold.
+// @Tags         lot
+// @Accept       json
+// @Produce      json
+// @Param 		 branch_id query string true "branch_id"
+// @Param 		 days query int false "days, 7 by default"
+// @Success      200  {object}  models.LotsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetExpiringLots(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil {
+		handleResponse(c, "error is while converting days", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	lots, err := h.services.Lot().GetExpiring(ctx, c.Query("branch_id"), days)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidLotFilter):
+			handleResponse(c, "error is while getting expiring lots", http.StatusBadRequest, err.Error())
+		case errors.Is(err, pgx.ErrNoRows):
+			handleResponse(c, "branch not found", http.StatusNotFound, err.Error())
+		default:
+			handleResponse(c, "error is while getting expiring lots", http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, lots)
+}
+
+// GetProductLots godoc
+// @Router       /product/{id}/lots [GET]
+// @Summary      Get product lots
+// @Description  get the lots of the product with stock left, the ones expiring first first
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Success      200  {object}  models.LotsResponse
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductLots(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	lots, err := h.services.Lot().GetByProduct(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "product not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while getting product lots", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, lots)
+}
@@ -1,13 +1,22 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"test/api/models"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"test/pkg/sheet"
+	"test/service"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // CreateProduct godoc
@@ -29,10 +38,18 @@ func (h Handler) CreateProduct(c *gin.Context) {
 		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
 		return
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+
+	product.CreatedBy = actorID(c)
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	createdProduct, err := h.services.Product().Create(ctx, product)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidTaxRate) {
+			handleResponse(c, "error is while creating product", http.StatusBadRequest, err.Error())
+			return
+		}
+
 		handleResponse(c, "error is while creating product", http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -49,21 +66,95 @@ func (h Handler) CreateProduct(c *gin.Context) {
 // @Produce      json
 // @Param 		 id path string true "product_id"
 // @Success      200  {object}  models.Product
+// @Header       200  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) GetProduct(c *gin.Context) {
 	uid := c.Param("id")
 
-	product, err := h.services.Product().Get(context.Background(), models.PrimaryKey{ID: uid})
+	product, err := h.services.Product().Get(requestContext(c), models.PrimaryKey{ID: uid})
 	if err != nil {
 		handleResponse(c, "error is while getting by id", http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	setETag(c, product.Version)
+	handleResponse(c, "", http.StatusOK, product)
+}
+
+// GetProductByBarcode godoc
+// @Router       /product/by-barcode/{code} [GET]
+// @Summary      Get product by barcode
+// @Description  get product by EAN-13, EAN-8 or UPC-A barcode
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param 		 code path string true "barcode"
+// @Success      200  {object}  models.Product
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductByBarcode(c *gin.Context) {
+	code := c.Param("code")
+
+	product, err := h.services.Product().GetByBarcode(requestContext(c), code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "product is not found by barcode", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while getting by barcode", http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	handleResponse(c, "", http.StatusOK, product)
 }
 
+// SearchProducts godoc
+// @Router       /products/search [GET]
+// @Summary      Search products
+// @Description  full-text search of products by name, SKU and category name tolerating misspellings, best matches first, exact SKU or barcode first of all
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param 		 q query string true "words to search, the last one may be typed partially"
+// @Param 		 limit query string false "limit, at most 50"
+// @Param 		 branch_id query string false "branch_id, products owned by the branch"
+// @Param 		 price_branch_id query string false "price_branch_id, branch whose price overrides are listed, branch_id by default"
+// @Success      200  {object}  models.ProductResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) SearchProducts(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if strings.TrimSpace(c.Query("q")) == "" {
+		handleResponse(c, "error is while reading query", http.StatusBadRequest, "q is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	products, err := h.services.Product().Search(ctx, models.GetListRequest{
+		Limit:         limit,
+		Search:        c.Query("q"),
+		BranchID:      c.Query("branch_id"),
+		PriceBranchID: c.Query("price_branch_id"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while searching products", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, products)
+}
+
 // GetProductList godoc
 // @Router       /products [GET]
 // @Summary      Get product list
@@ -73,47 +164,223 @@ func (h Handler) GetProduct(c *gin.Context) {
 // @Produce      json
 // @Param 		 page query string false "page"
 // @Param 		 limit query string false "limit"
+// @Param 		 cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param 		 count query bool false "count all rows, true by default"
 // @Param 		 search query string false "search"
-// @Success      200  {object}  models.Product
+// @Param 		 branch_id query string false "branch_id, products owned by the branch"
+// @Param 		 price_branch_id query string false "price_branch_id, branch whose price overrides are listed, branch_id by default"
+// @Param 		 group_variants query bool false "list parent products with their variants nested"
+// @Param 		 category_id query []string false "category_id, products of subcategories are included" collectionFormat(multi)
+// @Param 		 min_price query integer false "min_price in minor units"
+// @Param 		 max_price query integer false "max_price in minor units"
+// @Param 		 stock query string false "stock" Enums(in_stock, out_of_stock, low_stock)
+// @Param 		 low_stock_threshold query number false "low_stock_threshold, quantity at or below which stock is low"
+// @Param 		 created_from query string false "created_from date, YYYY-MM-DD"
+// @Param 		 created_to query string false "created_to date inclusive, YYYY-MM-DD"
+// @Param 		 sort_by query string false "sort_by" Enums(name, price, quantity, created_at)
+// @Param 		 sort_order query string false "sort_order" Enums(asc, desc)
+// @Success      200  {object}  models.ProductResponse
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) GetProductList(c *gin.Context) {
-	var (
-		page, limit int
-		search      string
-		err         error
-	)
+	request, err := productListRequest(c)
+	if err != nil {
+		handleResponse(c, "error is while reading filters", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	products, err := h.services.Product().GetList(ctx, request)
 
-	pageStr := c.DefaultQuery("page", "1")
-	page, err = strconv.Atoi(pageStr)
 	if err != nil {
-		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		handleResponse(c, "error is while getting list", http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err = strconv.Atoi(limitStr)
+	handleResponse(c, "", http.StatusOK, products)
+}
+
+// productListRequest reads the page and the filters of product listing, export takes the same filters.
+func productListRequest(c *gin.Context) (models.GetListRequest, error) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		return models.GetListRequest{}, fmt.Errorf("page: %w", err)
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	if err != nil {
-		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return models.GetListRequest{}, fmt.Errorf("limit: %w", err)
+	}
+
+	groupVariants, err := strconv.ParseBool(c.DefaultQuery("group_variants", "false"))
+	if err != nil {
+		return models.GetListRequest{}, fmt.Errorf("group_variants: %w", err)
+	}
+
+	minPrice, err := strconv.ParseInt(c.DefaultQuery("min_price", "0"), 10, 64)
+	if err != nil {
+		return models.GetListRequest{}, fmt.Errorf("min_price: %w", err)
+	}
+
+	maxPrice, err := strconv.ParseInt(c.DefaultQuery("max_price", "0"), 10, 64)
+	if err != nil {
+		return models.GetListRequest{}, fmt.Errorf("max_price: %w", err)
+	}
+
+	lowStockThreshold, err := measure.Parse(c.DefaultQuery("low_stock_threshold", "0"))
+	if err != nil {
+		return models.GetListRequest{}, fmt.Errorf("low_stock_threshold: %w", err)
+	}
+
+	categoryIDs := []string{}
+	for _, categoryID := range c.QueryArray("category_id") {
+		categoryIDs = append(categoryIDs, strings.Split(categoryID, ",")...)
+	}
+
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		return models.GetListRequest{}, fmt.Errorf("count: %w", err)
+	}
+
+	return models.GetListRequest{
+		Page:              page,
+		Limit:             limit,
+		Cursor:            c.Query("cursor"),
+		SkipCount:         skipCount,
+		Search:            c.Query("search"),
+		BranchID:          c.Query("branch_id"),
+		PriceBranchID:     c.Query("price_branch_id"),
+		GroupVariants:     groupVariants,
+		CategoryIDs:       categoryIDs,
+		MinPrice:          money.Amount(minPrice),
+		MaxPrice:          money.Amount(maxPrice),
+		Stock:             c.Query("stock"),
+		LowStockThreshold: lowStockThreshold,
+		CreatedFrom:       c.Query("created_from"),
+		CreatedTo:         c.Query("created_to"),
+		SortBy:            c.Query("sort_by"),
+		SortOrder:         c.Query("sort_order"),
+	}, nil
+}
+
+// ImportProducts godoc
+// @Router       /products/import [POST]
+// @Summary      Import products
+// @Description  create products from a CSV or XLSX file with the columns name, sku, category, price, original_price, quantity, unit and branch,
+// @Description  prices are in minor units, category and branch are found by name, a branch also by id.
+// @Description  Nothing is created when a row is invalid, the result lists the errors of all rows.
+// @Tags         product
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "CSV or XLSX file, the first row names the columns"
+// @Param        dry_run query bool false "only validate the file"
+// @Success      200  {object}  models.ProductImportResult
+// @Failure      400  {object}  models.Response
+// @Failure      422  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ImportProducts(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		handleResponse(c, "error is while converting dry_run", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		handleResponse(c, "error is while reading file", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		handleResponse(c, "error is while opening file", http.StatusBadRequest, err.Error())
 		return
 	}
+	defer file.Close()
 
-	search = c.Query("search")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	records, err := sheet.Read(file, sheet.FormatOf(fileHeader.Filename))
+	if err != nil {
+		handleResponse(c, "error is while reading file", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Minute)
 	defer cancel()
-	products, err := h.services.Product().GetList(ctx, models.GetListRequest{
-		Page:   page,
-		Limit:  limit,
-		Search: search,
-	})
 
+	result, err := h.services.Product().Import(ctx, records, dryRun)
 	if err != nil {
-		handleResponse(c, "error is while getting list", http.StatusInternalServerError, err.Error())
+		if errors.Is(err, service.ErrInvalidImport) {
+			handleResponse(c, "error is while reading file", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while importing products", http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	handleResponse(c, "", http.StatusOK, products)
+	if len(result.Errors) > 0 && !dryRun {
+		handleResponse(c, "file has invalid rows, nothing is imported", http.StatusUnprocessableEntity, result)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, result)
+}
+
+// ExportProducts godoc
+// @Router       /products/export [GET]
+// @Summary      Export products
+// @Description  download the products matching the filters of the product list as a file that can be imported back
+// @Tags         product
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param 		 format query string false "format of the file, csv by default" Enums(csv, xlsx)
+// @Param 		 search query string false "search"
+// @Param 		 branch_id query string false "branch_id, products owned by the branch"
+// @Param 		 price_branch_id query string false "price_branch_id, branch whose price overrides are listed, branch_id by default"
+// @Param 		 category_id query []string false "category_id, products of subcategories are included" collectionFormat(multi)
+// @Param 		 min_price query integer false "min_price in minor units"
+// @Param 		 max_price query integer false "max_price in minor units"
+// @Param 		 stock query string false "stock" Enums(in_stock, out_of_stock, low_stock)
+// @Param 		 low_stock_threshold query number false "low_stock_threshold, quantity at or below which stock is low"
+// @Param 		 created_from query string false "created_from date, YYYY-MM-DD"
+// @Param 		 created_to query string false "created_to date inclusive, YYYY-MM-DD"
+// @Param 		 sort_by query string false "sort_by" Enums(name, price, quantity, created_at)
+// @Param 		 sort_order query string false "sort_order" Enums(asc, desc)
+// @Success      200  {file}    file
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ExportProducts(c *gin.Context) {
+	format := c.DefaultQuery("format", sheet.CSV)
+	if format != sheet.CSV && format != sheet.XLSX {
+		handleResponse(c, "error is while reading format", http.StatusBadRequest, sheet.ErrUnknownFormat.Error())
+		return
+	}
+
+	request, err := productListRequest(c)
+	if err != nil {
+		handleResponse(c, "error is while reading filters", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Minute)
+	defer cancel()
+
+	records, err := h.services.Product().Export(ctx, request)
+	if err != nil {
+		handleResponse(c, "error is while exporting products", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var file bytes.Buffer
+	if err = sheet.Write(&file, format, records); err != nil {
+		handleResponse(c, "error is while writing file", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="products.`+format+`"`)
+	c.Data(http.StatusOK, sheet.ContentType(format), file.Bytes())
 }
 
 // UpdateProduct godoc
@@ -125,9 +392,13 @@ func (h Handler) GetProductList(c *gin.Context) {
 // @Produce      json
 // @Param 		 id path string true "product_id"
 // @Param 		 product body models.UpdateProduct false "product"
+// @Param        If-Match header string true "version of the row being updated"
 // @Success      200  {object}  models.Product
+// @Header       200  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateProduct(c *gin.Context) {
 	uid := c.Param("id")
@@ -140,21 +411,104 @@ func (h Handler) UpdateProduct(c *gin.Context) {
 	}
 
 	product.ID = uid
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	product.UpdatedBy = actorID(c)
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading version", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	product.Version = version
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	updatedProduct, err := h.services.Product().Update(ctx, product)
 	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "product was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidTaxRate) || errors.Is(err, service.ErrNegativeStock) {
+			handleResponse(c, "error is while updating product", http.StatusBadRequest, err.Error())
+			return
+		}
+
 		handleResponse(c, "error is while updating product", http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	setETag(c, updatedProduct.Version)
 	handleResponse(c, "", http.StatusOK, updatedProduct)
 }
 
+// PatchProduct godoc
+// @Router       /product/{id} [PATCH]
+// @Summary      Patch a product
+// @Description  change only the fields of the product present in the body, a JSON merge patch
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "product_id"
+// @Param        product body models.UpdateProduct false "fields of the product to change"
+// @Param        If-Match header string false "version of the row being patched, the version field of the body is used without it"
+// @Success      200  {object}  models.Product
+// @Header       200  {string}  ETag  "version of the row"
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) PatchProduct(c *gin.Context) {
+	patch, err := readPatch(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	product, err := h.services.Product().Patch(ctx, patch)
+	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "product was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidPatch) || errors.Is(err, service.ErrInvalidTaxRate) || errors.Is(err, service.ErrNegativeStock) {
+			handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "product not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while patching product", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	setETag(c, product.Version)
+	handleResponse(c, "", http.StatusOK, product)
+}
+
 // DeleteProduct godoc
 // @Router       /product/{id} [DELETE]
 // @Summary      Delete product
-// @Description  delete product
+// @Description  delete product, refused with its variants listed unless products cascade on delete
 // @Tags         product
 // @Accept       json
 // @Produce      json
@@ -162,11 +516,17 @@ func (h Handler) UpdateProduct(c *gin.Context) {
 // @Success      200  {object}  models.Response
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteProduct(c *gin.Context) {
 	uid := c.Param("id")
 
-	if err := h.services.Product().Delete(context.Background(), models.PrimaryKey{ID: uid}); err != nil {
+	if err := h.services.Product().Delete(requestContext(c), models.PrimaryKey{ID: uid}); err != nil {
+		if dependentsErr := (service.DependentsError{}); errors.As(err, &dependentsErr) {
+			handleResponse(c, "product is in use", http.StatusConflict, dependentsErr)
+			return
+		}
+
 		handleResponse(c, "error is while delete", http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -177,7 +537,7 @@ func (h Handler) DeleteProduct(c *gin.Context) {
 // StartSellNew godoc
 // @Router       /sell-new [POST]
 // @Summary      Selling products
-// @Description  selling products
+// @Description  selling products, products without enough stock are backordered for the customer when backorder is set
 // @Tags         product
 // @Accept       json
 // @Produce      json
@@ -194,18 +554,25 @@ func (h Handler) StartSellNew(c *gin.Context) {
 		return
 	}
 
-	productSell, err := h.services.Product().StartSellNew(context.Background(), request)
+	productSell, err := h.services.Product().StartSellNew(requestContext(c), request)
 	if err != nil {
+		if errors.Is(err, money.ErrCurrencyMismatch) {
+			handleResponse(c, "error is while start sell new", http.StatusBadRequest, err.Error())
+			return
+		}
+
 		handleResponse(c, "error is while start sell new", http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// dealer
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-	defer cancel()
-	if err = h.services.Dealer().Delivery(ctx, productSell); err != nil {
-		handleResponse(c, "error is while delivery products", http.StatusInternalServerError, err.Error())
-		return
+	// dealer, backordered products wait for an income instead
+	if !request.Backorder {
+		ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+		defer cancel()
+		if err = h.services.Dealer().Delivery(ctx, productSell); err != nil {
+			handleResponse(c, "error is while delivery products", http.StatusInternalServerError, err.Error())
+			return
+		}
 	}
 
 	// report
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"test/api/models"
+	"test/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetProductPriceHistory godoc
+// @Router       /product/{id}/price-history [GET]
+// @Summary      Get product price history
+// @Description  get price changes of product, including scheduled ones
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param 		 count query bool false "count all rows, true by default"
+// @Success      200  {object}  models.ProductPricesResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductPriceHistory(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	prices, err := h.services.ProductPrice().GetHistory(ctx, models.GetListRequest{
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		ProductID: c.Param("id"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while getting price history", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, prices)
+}
+
+// ScheduleProductPrice godoc
+// @Router       /product/{id}/price-schedule [POST]
+// @Summary      Schedule product price
+// @Description  schedule a future price of product, starts_at is RFC3339 time, price should be positive
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Param 		 price body models.CreateProductPrice true "price"
+// @Success      201  {object}  models.ProductPrice
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ScheduleProductPrice(c *gin.Context) {
+	price := models.CreateProductPrice{}
+
+	if err := c.ShouldBindJSON(&price); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if price.StartsAt == "" {
+		handleResponse(c, "starts_at is required", http.StatusBadRequest, "starts_at is required")
+		return
+	}
+
+	price.ProductID = c.Param("id")
+	price.CreatedBy = actorID(c)
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	scheduledPrice, err := h.services.ProductPrice().Schedule(ctx, price)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidProductPrice) {
+			handleResponse(c, "error is while scheduling price", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "product not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while scheduling price", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, scheduledPrice)
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetProfitReport godoc
+// @Router       /report/profit [GET]
+// @Summary      Get consolidated profit report
+// @Description  get profit and losses of written off goods of every branch converted into the base currency with rates on date
+// @Tags         report
+// @Accept       json
+// @Produce      json
+// @Param 		 date query string false "date (YYYY-MM-DD), today by default"
+// @Success      200  {object}  models.ProfitReport
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProfitReport(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	report, err := h.services.Report().ConsolidatedProfit(ctx, c.Query("date"))
+	if err != nil {
+		handleResponse(c, "error is while getting profit report", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, report)
+}
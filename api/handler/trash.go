@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"test/api/models"
+	"test/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// restoreEntities maps restore routes to the entity whose row they bring back.
+var restoreEntities = map[string]string{
+	"/user/:id/restore":            models.EntityUser,
+	"/category/:id/restore":        models.EntityCategory,
+	"/product/:id/restore":         models.EntityProduct,
+	"/basket/:id/restore":          models.EntityBasket,
+	"/basketProduct/:id/restore":   models.EntityBasketProduct,
+	"/branch/:id/restore":          models.EntityBranch,
+	"/income/:id/restore":          models.EntityIncome,
+	"/income_products/:id/restore": models.EntityIncomeProduct,
+}
+
+// GetTrashList godoc
+// @Router       /trash/{entity} [GET]
+// @Summary      Get trash list
+// @Description  get soft-deleted rows of entity, the response has the shape of the entity's own list
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Param        entity path string true "users, categories, products, baskets, basket_products, branches, incomes or income_products"
+// @Param        page query string false "page"
+// @Param        limit query string false "limit"
+// @Param        cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param        count query bool false "count all rows, true by default"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetTrashList(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	list, err := h.services.Trash().GetList(ctx, c.Param("entity"), models.GetListRequest{
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownEntity) {
+			handleResponse(c, "error is while getting trash list", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while getting trash list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, list)
+}
+
+// RestoreDeleted godoc
+// @Router       /user/{id}/restore [POST]
+// @Router       /category/{id}/restore [POST]
+// @Router       /product/{id}/restore [POST]
+// @Router       /basket/{id}/restore [POST]
+// @Router       /basketProduct/{id}/restore [POST]
+// @Router       /branch/{id}/restore [POST]
+// @Router       /income/{id}/restore [POST]
+// @Router       /income_products/{id}/restore [POST]
+// @Summary      Restore deleted row
+// @Description  bring a soft-deleted row back from the trash, conflicts when a live row took its unique values
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) RestoreDeleted(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	if err := h.services.Trash().Restore(ctx, restoreEntities[c.FullPath()], c.Param("id")); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "deleted row is not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		pgErr := &pgconn.PgError{}
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			handleResponse(c, "error is while restoring deleted row", http.StatusConflict, pgErr.Detail)
+			return
+		}
+
+		handleResponse(c, "error is while restoring deleted row", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "data successfully restored")
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"test/api/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUnitList godoc
+// @Router       /units [GET]
+// @Summary      Get unit list
+// @Description  get units of measure, fractional units allow quantities like 1.25
+// @Tags         unit
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.UnitsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetUnitList(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	resp, err := h.services.Unit().GetList(ctx)
+	if err != nil {
+		handleResponse(c, "error is while getting units", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, resp)
+}
+
+// SetProductUnits godoc
+// @Router       /product/{id}/units [PUT]
+// @Summary      Set product units
+// @Description  replace packagings of product, factor is how many product units one packaging holds
+// @Tags         unit
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Param 		 units body models.SetProductUnits true "units"
+// @Success      200  {object}  models.ProductUnitsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) SetProductUnits(c *gin.Context) {
+	request := models.SetProductUnits{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.ProductID = c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	resp, err := h.services.Unit().SetProductUnits(ctx, request)
+	if err != nil {
+		handleResponse(c, "error is while setting product units", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, resp)
+}
+
+// GetProductUnits godoc
+// @Router       /product/{id}/units [GET]
+// @Summary      Get product units
+// @Description  get packagings of product
+// @Tags         unit
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Success      200  {object}  models.ProductUnitsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductUnits(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+	resp, err := h.services.Unit().GetProductUnits(ctx, c.Param("id"))
+	if err != nil {
+		handleResponse(c, "error is while getting product units", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, resp)
+}
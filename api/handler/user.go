@@ -5,9 +5,11 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"net/http"
 	"strconv"
 	"test/api/models"
+	"test/service"
 	"time"
 )
 
@@ -31,7 +33,7 @@ func (h Handler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.User().Create(ctx, createUser)
 	if err != nil {
@@ -51,6 +53,7 @@ func (h Handler) CreateUser(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "user"
 // @Success      200  {object}  models.User
+// @Header       200  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
@@ -65,7 +68,7 @@ func (h Handler) GetUser(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	user, err := h.services.User().GetUser(ctx, models.PrimaryKey{
 		ID: id.String(),
@@ -75,6 +78,7 @@ func (h Handler) GetUser(c *gin.Context) {
 		return
 	}
 
+	setETag(c, user.Version)
 	handleResponse(c, "", http.StatusOK, user)
 }
 
@@ -87,6 +91,8 @@ func (h Handler) GetUser(c *gin.Context) {
 // @Produce      json
 // @Param        page query string false "page"
 // @Param 		 limit query string false "limit"
+// @Param 		 cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param 		 count query bool false "count all rows, true by default"
 // @Param 		 search query string false "search"
 // @Success      200  {object}  models.UsersResponse
 // @Failure      400  {object}  models.Response
@@ -115,12 +121,20 @@ func (h Handler) GetUserList(c *gin.Context) {
 
 	search = c.Query("search")
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.User().GetUsers(ctx, models.GetListRequest{
-		Page:   page,
-		Limit:  limit,
-		Search: search,
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		Search:    search,
 	})
 	if err != nil {
 		handleResponse(c, "error while getting users", http.StatusInternalServerError, err)
@@ -139,9 +153,13 @@ func (h Handler) GetUserList(c *gin.Context) {
 // @Produce      json
 // @Param 		 id path string true "user_id"
 // @Param        user body models.UpdateUser true "user"
+// @Param        If-Match header string true "version of the row being updated"
 // @Success      200  {object}  models.User
+// @Header       200  {string}  ETag  "version of the row"
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateUser(c *gin.Context) {
 	updateUser := models.UpdateUser{}
@@ -159,17 +177,93 @@ func (h Handler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading version", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updateUser.Version = version
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	resp, err := h.services.User().Update(ctx, updateUser)
 	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "user was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
 		handleResponse(c, "error while updating user", http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	setETag(c, resp.Version)
 	handleResponse(c, "", http.StatusOK, resp)
 }
 
+// PatchUser godoc
+// @Router       /user/{id} [PATCH]
+// @Summary      Patch a customer
+// @Description  change only the fields of the customer present in the body, a JSON merge patch
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "user_id"
+// @Param        user body models.UpdateUser false "fields of the customer to change"
+// @Param        If-Match header string false "version of the row being patched, the version field of the body is used without it"
+// @Success      200  {object}  models.User
+// @Header       200  {string}  ETag  "version of the row"
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      428  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) PatchUser(c *gin.Context) {
+	patch, err := readPatch(c)
+	if err != nil {
+		if errors.Is(err, errVersionRequired) {
+			handleResponse(c, "error is while reading version", http.StatusPreconditionRequired, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	user, err := h.services.User().Patch(ctx, patch)
+	if err != nil {
+		if conflict := (service.VersionConflictError{}); errors.As(err, &conflict) {
+			handleResponse(c, "customer was changed by someone else", http.StatusConflict, conflict.Current)
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidPatch) {
+			handleResponse(c, "error is while reading patch", http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "customer not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleResponse(c, "error is while patching customer", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	setETag(c, user.Version)
+	handleResponse(c, "", http.StatusOK, user)
+}
+
 // DeleteUser godoc
 // @Router       /user/{id} [DELETE]
 // @Summary      Delete user
@@ -190,7 +284,7 @@ func (h Handler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	if err = h.services.User().Delete(ctx, models.PrimaryKey{
 		ID: id.String(),
@@ -203,7 +297,7 @@ func (h Handler) DeleteUser(c *gin.Context) {
 }
 
 // UpdateUserPassword godoc
-// @Router       /user/{id} [PATCH]
+// @Router       /user/{id}/password [PUT]
 // @Summary      Update user password
 // @Description  update user password
 // @Tags         user
@@ -231,7 +325,7 @@ func (h Handler) UpdateUserPassword(c *gin.Context) {
 
 	updateUserPassword.ID = uid.String()
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
 	defer cancel()
 	if err = h.services.User().UpdatePassword(ctx, updateUserPassword); err != nil {
 		handleResponse(c, "error while updating user password", http.StatusInternalServerError, err.Error())
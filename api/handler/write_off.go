@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"test/api/models"
+	"test/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateWriteOff godoc
+// @Router       /write-off [POST]
+// @Summary      Create a write-off
+// @Description  record damaged, expired, stolen or internally used goods of the branch,
+// @Description  stock is decreased only when a manager approves the write-off
+// @Tags         write-off
+// @Accept       json
+// @Produce      json
+// @Param        X-User-ID header string false "id of the user writing off the goods"
+// @Param        write_off body models.CreateWriteOff true "write-off, reason is damage, expiry, theft or internal_use"
+// @Success      201  {object}  models.WriteOff
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateWriteOff(c *gin.Context) {
+	writeOff := models.CreateWriteOff{}
+
+	if err := c.ShouldBindJSON(&writeOff); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeOff.CreatedBy = actorID(c)
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	createdWriteOff, err := h.services.WriteOff().Create(ctx, writeOff)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "branch or product not found", http.StatusNotFound, err.Error())
+			return
+		}
+
+		handleWriteOffError(c, "error is while creating write-off", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdWriteOff)
+}
+
+// GetWriteOff godoc
+// @Router       /write-off/{id} [GET]
+// @Summary      Get a write-off
+// @Description  get the write-off with the quantities and costs of its products
+// @Tags         write-off
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "write_off_id"
+// @Success      200  {object}  models.WriteOff
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetWriteOff(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	writeOff, err := h.services.WriteOff().Get(ctx, models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		handleWriteOffError(c, "error is while getting write-off", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, writeOff)
+}
+
+// GetWriteOffList godoc
+// @Router       /write-offs [GET]
+// @Summary      Get write-offs
+// @Description  get write-offs without their lines, newest first
+// @Tags         write-off
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 cursor query string false "cursor of the next or previous page, page is ignored when it is given"
+// @Param 		 count query bool false "count all rows, true by default"
+// @Param 		 branch_id query string false "branch_id"
+// @Param 		 status query string false "pending, posted or rejected"
+// @Success      200  {object}  models.WriteOffsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetWriteOffList(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	skipCount, err := listSkipCount(c)
+	if err != nil {
+		handleResponse(c, "error is while converting count", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	writeOffs, err := h.services.WriteOff().GetList(ctx, models.GetListRequest{
+		Page:      page,
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		SkipCount: skipCount,
+		BranchID:  c.Query("branch_id"),
+		Status:    c.Query("status"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while getting write-offs", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, writeOffs)
+}
+
+// ApproveWriteOff godoc
+// @Router       /write-off/{id}/approve [POST]
+// @Summary      Approve a write-off
+// @Description  post the write-off: its goods leave stock and their cost is added to the losses of the branch,
+// @Description  only managers (admin users) can approve
+// @Tags         write-off
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "write_off_id"
+// @Param        X-User-ID header string true "id of the manager approving the write-off"
+// @Success      200  {object}  models.WriteOff
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ApproveWriteOff(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*30)
+	defer cancel()
+
+	writeOff, err := h.services.WriteOff().Approve(ctx, models.PrimaryKey{ID: c.Param("id")}, actorID(c))
+	if err != nil {
+		handleWriteOffError(c, "error is while approving write-off", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, writeOff)
+}
+
+// RejectWriteOff godoc
+// @Router       /write-off/{id}/reject [POST]
+// @Summary      Reject a write-off
+// @Description  close the write-off without changing the stock, only managers (admin users) can reject
+// @Tags         write-off
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "write_off_id"
+// @Param        X-User-ID header string true "id of the manager rejecting the write-off"
+// @Success      200  {object}  models.WriteOff
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) RejectWriteOff(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(requestContext(c), time.Second*5)
+	defer cancel()
+
+	writeOff, err := h.services.WriteOff().Reject(ctx, models.PrimaryKey{ID: c.Param("id")}, actorID(c))
+	if err != nil {
+		handleWriteOffError(c, "error is while rejecting write-off", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, writeOff)
+}
+
+func handleWriteOffError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		handleResponse(c, "write-off not found", http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotManager):
+		handleResponse(c, msg, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrWriteOffClosed):
+		handleResponse(c, msg, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidWriteOff) || errors.Is(err, service.ErrNegativeStock):
+		handleResponse(c, msg, http.StatusBadRequest, err.Error())
+	default:
+		handleResponse(c, msg, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import "encoding/json"
+
+// Actions of audit log entries.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+)
+
+// Entities audited besides the soft-deletable ones.
+const (
+	EntityCurrencyRate       = "currency_rates"
+	EntityProductPrice       = "product_prices"
+	EntityBranchProductPrice = "branch_product_prices"
+	EntityProductUnit        = "product_units"
+	EntityInventoryCount     = "inventory_counts"
+	EntityWriteOff           = "write_offs"
+	EntityBackorder          = "backorders"
+)
+
+// AuditLog is one change made through the API, Before and After hold only the fields that changed.
+type AuditLog struct {
+	ID        string          `json:"id"`
+	ActorID   string          `json:"actor_id"`
+	Entity    string          `json:"entity"`
+	EntityID  string          `json:"entity_id"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" swaggertype:"object"`
+	RequestID string          `json:"request_id"`
+	IP        string          `json:"ip"`
+	CreatedAt string          `json:"created_at"`
+}
+
+type CreateAuditLog struct {
+	ActorID   string
+	Entity    string
+	EntityID  string
+	Action    string
+	Before    json.RawMessage
+	After     json.RawMessage
+	RequestID string
+	IP        string
+}
+
+type AuditLogsResponse struct {
+	AuditLogs []AuditLog `json:"audit_logs"`
+	Count     int        `json:"count"`
+	Cursors
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
+// Statuses of backorders, open ones are fulfilled oldest first when an income posts stock of the product.
+// A fulfilled backorder is held until it is collected as a sale or released back to stock.
+const (
+	BackorderOpen      = "open"
+	BackorderFulfilled = "fulfilled"
+	BackorderCancelled = "cancelled"
+	BackorderCollected = "collected"
+	BackorderReleased  = "released"
+)
+
+// Backorder is a quantity of a product a customer ordered while it was out of stock, Price and TaxRate
+// are quoted in the branch when it is ordered. A fulfilled backorder holds the quantity for the customer,
+// NotifiedAt is set once the customer is told, SaleBasketID is the sale it is collected in.
+type Backorder struct {
+	ID           string           `json:"id"`
+	CustomerID   string           `json:"customer_id"`
+	ProductID    string           `json:"product_id"`
+	ProductName  string           `json:"product_name"`
+	BasketID     string           `json:"basket_id"`
+	BranchID     string           `json:"branch_id"`
+	Quantity     measure.Quantity `json:"quantity" swaggertype:"number"`
+	Price        money.Amount     `json:"price"`
+	TaxRate      *float64         `json:"tax_rate"`
+	AverageCost  money.Amount     `json:"-"`
+	Status       string           `json:"status"`
+	SaleBasketID string           `json:"sale_basket_id"`
+	FulfilledAt  string           `json:"fulfilled_at"`
+	NotifiedAt   string           `json:"notified_at"`
+	CreatedAt    string           `json:"created_at"`
+	UpdatedAt    string           `json:"updated_at"`
+}
+
+type CreateBackorder struct {
+	CustomerID string
+	ProductID  string
+	BasketID   string
+	BranchID   string
+	Quantity   measure.Quantity
+	Price      money.Amount
+	TaxRate    float64
+}
+
+type BackordersResponse struct {
+	Backorders []Backorder `json:"backorders"`
+	Count      int         `json:"count"`
+	Cursors
+}
+
+// BackorderNotification is the body of the webhook sent when a backorder is fulfilled.
+type BackorderNotification struct {
+	Event         string           `json:"event"`
+	BackorderID   string           `json:"backorder_id"`
+	CustomerID    string           `json:"customer_id"`
+	CustomerPhone string           `json:"customer_phone"`
+	ProductID     string           `json:"product_id"`
+	ProductName   string           `json:"product_name"`
+	Quantity      measure.Quantity `json:"quantity" swaggertype:"number"`
+	FulfilledAt   string           `json:"fulfilled_at"`
+}
@@ -1,25 +1,43 @@
 package models
 
+import "test/pkg/money"
+
 type Basket struct {
-	ID         string `json:"id"`
-	CustomerID string `json:"customer_id"`
-	TotalSum   uint   `json:"total_sum"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
+	ID           string       `json:"id"`
+	Version      int          `json:"version"`
+	CustomerID   string       `json:"customer_id"`
+	NetSum       money.Amount `json:"net_sum"`
+	TaxSum       money.Amount `json:"tax_sum"`
+	TotalSum     money.Amount `json:"total_sum"`
+	TaxInclusive bool         `json:"tax_inclusive"`
+	Currency     string       `json:"currency"`
+	CreatedAt    string       `json:"created_at"`
+	UpdatedAt    string       `json:"updated_at"`
 }
 
 type CreateBasket struct {
-	CustomerID string `json:"customer_id"`
-	TotalSum   uint   `json:"total_sum"`
+	CustomerID string       `json:"customer_id"`
+	TotalSum   money.Amount `json:"total_sum"`
 }
 
 type UpdateBasket struct {
-	ID         string `json:"-"`
-	CustomerID string `json:"customer_id"`
-	TotalSum   uint   `json:"total_sum"`
+	ID         string       `json:"-"`
+	Version    int          `json:"version"`
+	CustomerID string       `json:"customer_id"`
+	TotalSum   money.Amount `json:"total_sum"`
+}
+
+type UpdateBasketSums struct {
+	ID           string       `json:"-"`
+	NetSum       money.Amount `json:"net_sum"`
+	TaxSum       money.Amount `json:"tax_sum"`
+	TotalSum     money.Amount `json:"total_sum"`
+	TaxInclusive bool         `json:"tax_inclusive"`
+	Currency     string       `json:"currency"`
 }
 
 type BasketResponse struct {
 	Baskets []Basket `json:"baskets"`
 	Count   int      `json:"count"`
+	Cursors
 }
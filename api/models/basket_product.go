@@ -1,32 +1,47 @@
 package models
 
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
+// BasketProduct is a sold line of a basket, Cost is its cost of goods and is null for sales made before costs were kept.
 type BasketProduct struct {
-	ID        string `json:"id"`
-	BasketID  string `json:"basket_id"`
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID        string           `json:"id"`
+	Version   int              `json:"version"`
+	BasketID  string           `json:"basket_id"`
+	ProductID string           `json:"product_id"`
+	Quantity  measure.Quantity `json:"quantity" swaggertype:"number"`
+	Price     money.Amount     `json:"price"`
+	TaxRate   float64          `json:"tax_rate"`
+	NetSum    money.Amount     `json:"net_sum"`
+	TaxSum    money.Amount     `json:"tax_sum"`
+	GrossSum  money.Amount     `json:"gross_sum"`
+	Cost      *money.Amount    `json:"cost"`
+	CreatedAt string           `json:"created_at"`
+	UpdatedAt string           `json:"updated_at"`
 }
 
 type CreateBasketProduct struct {
-	BasketID  string `json:"basket_id"`
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
+	BasketID  string           `json:"basket_id"`
+	ProductID string           `json:"product_id"`
+	Quantity  measure.Quantity `json:"quantity" swaggertype:"number"`
 }
 
 type UpdateBasketProduct struct {
-	ID        string `json:"-"`
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
+	ID        string           `json:"-"`
+	Version   int              `json:"version"`
+	ProductID string           `json:"product_id"`
+	Quantity  measure.Quantity `json:"quantity" swaggertype:"number"`
 }
 
 type BasketProductResponse struct {
 	BasketProducts []BasketProduct
 	Count          int
+	Cursors
 }
 
 type BasketProductSell struct {
-	ProductName string `json:"product_name"`
-	Quantity    uint   `json:"quantity"`
+	ProductName string           `json:"product_name"`
+	Quantity    measure.Quantity `json:"quantity" swaggertype:"number"`
 }
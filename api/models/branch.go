@@ -2,9 +2,11 @@ package models
 
 type Branch struct {
 	ID          string `json:"id"`
+	Version     int    `json:"version"`
 	Name        string `json:"name"`
 	Address     string `json:"address"`
 	PhoneNumber string `json:"phone_number"`
+	Currency    string `json:"currency"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
 }
@@ -13,16 +15,20 @@ type CreateBranch struct {
 	Name        string `json:"name"`
 	Address     string `json:"address"`
 	PhoneNumber string `json:"phone_number"`
+	Currency    string `json:"currency"`
 }
 
 type UpdateBranch struct {
 	ID          string `json:"-"`
+	Version     int    `json:"version"`
 	Name        string `json:"name"`
 	Address     string `json:"address"`
 	PhoneNumber string `json:"phone_number"`
+	Currency    string `json:"currency"`
 }
 
 type BranchResponse struct {
 	Branches []Branch
 	Count    int
+	Cursors
 }
@@ -0,0 +1,34 @@
+package models
+
+import "test/pkg/money"
+
+type BranchProductPrice struct {
+	ID        string       `json:"id"`
+	BranchID  string       `json:"branch_id"`
+	ProductID string       `json:"product_id"`
+	Price     money.Amount `json:"price"`
+	Currency  string       `json:"currency"`
+	CreatedAt string       `json:"created_at"`
+	UpdatedAt string       `json:"updated_at"`
+}
+
+type SetBranchProductPrice struct {
+	ProductID string       `json:"product_id"`
+	Price     money.Amount `json:"price"`
+}
+
+type SetBranchProductPrices struct {
+	BranchID string                  `json:"-"`
+	Prices   []SetBranchProductPrice `json:"prices"`
+}
+
+type DeleteBranchProductPrices struct {
+	BranchID   string   `json:"-"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+type BranchProductPricesResponse struct {
+	Prices []BranchProductPrice `json:"prices"`
+	Count  int                  `json:"count"`
+	Cursors
+}
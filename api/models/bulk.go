@@ -0,0 +1,46 @@
+package models
+
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
+// Operations of bulk price updates, increase and decrease are by Amount or by Percent.
+const (
+	BulkPriceSet      = "set"
+	BulkPriceIncrease = "increase"
+	BulkPriceDecrease = "decrease"
+)
+
+// ProductFilter selects the products of a bulk operation, the given parts must all match
+// and a category takes products of its subcategories too.
+type ProductFilter struct {
+	CategoryIDs []string `json:"category_ids"`
+	BranchID    string   `json:"branch_id"`
+	ProductIDs  []string `json:"product_ids"`
+}
+
+// BulkPriceUpdate changes prices of the filtered products, Amount is in minor units.
+type BulkPriceUpdate struct {
+	Filter    ProductFilter `json:"filter"`
+	Operation string        `json:"operation" enums:"set,increase,decrease"`
+	Amount    money.Amount  `json:"amount"`
+	Percent   float64       `json:"percent"`
+}
+
+// StockAdjustmentItem adds Quantity to the stock of the product, a negative one removes it.
+type StockAdjustmentItem struct {
+	ProductID string           `json:"product_id"`
+	Quantity  measure.Quantity `json:"quantity" swaggertype:"number"`
+}
+
+type BulkStockAdjustment struct {
+	Items  []StockAdjustmentItem `json:"items"`
+	Reason string                `json:"reason"`
+}
+
+// BulkResult counts the products a bulk operation matched and the ones it changed.
+type BulkResult struct {
+	Matched int `json:"matched"`
+	Updated int `json:"updated"`
+}
@@ -1,22 +1,38 @@
 package models
 
 type Category struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID                string     `json:"id"`
+	Version           int        `json:"version"`
+	ParentID          string     `json:"parent_id"`
+	Name              string     `json:"name"`
+	TaxRate           *float64   `json:"tax_rate"`
+	Children          []Category `json:"children,omitempty"`
+	DeletedReferences []string   `json:"deleted_references,omitempty"`
+	CreatedAt         string     `json:"created_at"`
+	UpdatedAt         string     `json:"updated_at"`
 }
 
 type CreateCategory struct {
-	Name string `json:"name"`
+	ParentID string   `json:"parent_id"`
+	Name     string   `json:"name"`
+	TaxRate  *float64 `json:"tax_rate"`
 }
 
 type UpdateCategory struct {
-	ID   string `json:"-"`
-	Name string `json:"name"`
+	ID       string   `json:"-"`
+	Version  int      `json:"version"`
+	ParentID string   `json:"parent_id"`
+	Name     string   `json:"name"`
+	TaxRate  *float64 `json:"tax_rate"`
 }
 
 type CategoryResponse struct {
 	Category []Category `json:"category"`
 	Count    int        `json:"count"`
+	Cursors
+}
+
+// CategoryTree holds root categories with their subcategories nested in Children.
+type CategoryTree struct {
+	Categories []Category `json:"categories"`
 }
@@ -1,12 +1,58 @@
 package models
 
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
 type PrimaryKey struct {
 	ID string `json:"id"`
 }
 
 type GetListRequest struct {
-	Page     int    `json:"page"`
-	Limit    int    `json:"limit"`
-	Search   string `json:"search"`
-	BasketID string `json:"basket_id"`
+	Page          int    `json:"page"`
+	Limit         int    `json:"limit"`
+	Cursor        string `json:"cursor"`
+	SkipCount     bool   `json:"skip_count"`
+	Search        string `json:"search"`
+	BasketID      string `json:"basket_id"`
+	UserID        string `json:"user_id"`
+	ProductID     string `json:"product_id"`
+	BranchID      string `json:"branch_id"`
+	Currency      string `json:"currency"`
+	GroupVariants bool   `json:"group_variants"`
+	Deleted       bool   `json:"deleted"`
+	Status        string `json:"status"`
+
+	// product listing filters, zero values do not filter;
+	// PriceBranchID is the branch whose price overrides are listed, the one of BranchID when it is empty
+	PriceBranchID     string           `json:"price_branch_id"`
+	CategoryIDs       []string         `json:"category_ids"`
+	MinPrice          money.Amount     `json:"min_price"`
+	MaxPrice          money.Amount     `json:"max_price"`
+	Stock             string           `json:"stock"`
+	LowStockThreshold measure.Quantity `json:"low_stock_threshold" swaggertype:"number"`
+	CreatedFrom       string           `json:"created_from"`
+	CreatedTo         string           `json:"created_to"`
+	SortBy            string           `json:"sort_by"`
+	SortOrder         string           `json:"sort_order"`
+
+	// audit log filters
+	Entity   string `json:"entity"`
+	EntityID string `json:"entity_id"`
+	ActorID  string `json:"actor_id"`
 }
+
+// Cursors point to the neighbouring pages of a list, they are empty when there is no such page.
+// A cursor given back in GetListRequest.Cursor takes the page instead of Page.
+type Cursors struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Stock filters of product listing.
+const (
+	StockIn  = "in_stock"
+	StockOut = "out_of_stock"
+	StockLow = "low_stock"
+)
@@ -0,0 +1,23 @@
+package models
+
+type CurrencyRate struct {
+	ID        string  `json:"id"`
+	Currency  string  `json:"currency"`
+	Rate      float64 `json:"rate"`
+	RateDate  string  `json:"rate_date"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// CreateCurrencyRate sets the price of one unit of Currency in the base currency on RateDate (YYYY-MM-DD).
+type CreateCurrencyRate struct {
+	Currency string  `json:"currency"`
+	Rate     float64 `json:"rate"`
+	RateDate string  `json:"rate_date"`
+}
+
+type CurrencyRatesResponse struct {
+	CurrencyRates []CurrencyRate `json:"currency_rates"`
+	Count         int            `json:"count"`
+	Cursors
+}
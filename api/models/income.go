@@ -1,12 +1,16 @@
 package models
 
+import "test/pkg/money"
+
 type Income struct {
-	ID         string `json:"id"`
-	ExternalID string `json:"external_id"`
-	TotalSum   int    `json:"total_sum"`
+	ID         string       `json:"id"`
+	ExternalID string       `json:"external_id"`
+	TotalSum   money.Amount `json:"total_sum"`
+	PostedAt   string       `json:"posted_at"`
 }
 
 type IncomesResponse struct {
 	Incomes []Income `json:"incomes"`
 	Count   int      `json:"count"`
+	Cursors
 }
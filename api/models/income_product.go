@@ -1,18 +1,35 @@
 package models
 
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
+// IncomeProduct is kept in the unit of the product, an update may take Quantity and Price in another Unit
+// of the product like CreateIncomeProduct does.
 type IncomeProduct struct {
-	ID        string `json:"id"`
-	IncomeID  string `json:"income_id"`
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	Price     int    `json:"price"`
+	ID         string           `json:"id"`
+	IncomeID   string           `json:"income_id"`
+	ProductID  string           `json:"product_id"`
+	Quantity   measure.Quantity `json:"quantity" swaggertype:"number"`
+	Unit       string           `json:"unit,omitempty"`
+	Price      money.Amount     `json:"price"`
+	LotNumber  string           `json:"lot_number"`
+	ExpiryDate string           `json:"expiry_date"`
+	CreatedAt  string           `json:"created_at"`
 }
 
+// CreateIncomeProduct may take Quantity and Price in a unit of the product other than its own (a box of 12),
+// they are converted into the product unit before saving. The line becomes a lot of the product when the income is posted,
+// LotNumber is the external id of the income when it is not given and ExpiryDate (YYYY-MM-DD) is empty for goods that do not expire.
 type CreateIncomeProduct struct {
-	IncomeID  string `json:"income_id"`
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	Price     int    `json:"price"`
+	IncomeID   string           `json:"income_id"`
+	ProductID  string           `json:"product_id"`
+	Quantity   measure.Quantity `json:"quantity" swaggertype:"number"`
+	Unit       string           `json:"unit"`
+	Price      money.Amount     `json:"price"`
+	LotNumber  string           `json:"lot_number"`
+	ExpiryDate string           `json:"expiry_date"`
 }
 
 type CreateIncomeProducts struct {
@@ -22,6 +39,7 @@ type CreateIncomeProducts struct {
 type IncomeProductsResponse struct {
 	IncomeProducts []IncomeProduct
 	Count          int
+	Cursors
 }
 
 type UpdateIncomeProducts struct {
@@ -35,3 +53,9 @@ type IncomeProducts struct {
 type DeleteIncomeProducts struct {
 	IDs []PrimaryKey
 }
+
+// DeletedIncomeProducts tells the lines that were deleted and the ones refused as their income is posted.
+type DeletedIncomeProducts struct {
+	Deleted []string `json:"deleted"`
+	Refused []string `json:"refused"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
+// Statuses of inventory counts, only open ones take counted quantities.
+const (
+	InventoryCountOpen      = "open"
+	InventoryCountApproved  = "approved"
+	InventoryCountCancelled = "cancelled"
+)
+
+// InventoryCount is a stock-taking of a branch, costs are in the currency of the branch.
+type InventoryCount struct {
+	ID           string               `json:"id"`
+	BranchID     string               `json:"branch_id"`
+	Status       string               `json:"status"`
+	Note         string               `json:"note"`
+	Lines        []InventoryCountLine `json:"lines,omitempty"`
+	CountedLines int                  `json:"counted_lines"`
+	ShortageCost money.Amount         `json:"shortage_cost"`
+	SurplusCost  money.Amount         `json:"surplus_cost"`
+	VarianceCost money.Amount         `json:"variance_cost"`
+	CreatedBy    string               `json:"created_by"`
+	ClosedBy     string               `json:"closed_by"`
+	ClosedAt     string               `json:"closed_at"`
+	CreatedAt    string               `json:"created_at"`
+	UpdatedAt    string               `json:"updated_at"`
+}
+
+// InventoryCountLine compares the quantity expected when the count was opened with the counted one,
+// CountedQuantity is null until the product is counted.
+type InventoryCountLine struct {
+	ProductID        string            `json:"product_id"`
+	ProductName      string            `json:"product_name"`
+	ExpectedQuantity measure.Quantity  `json:"expected_quantity" swaggertype:"number"`
+	CountedQuantity  *measure.Quantity `json:"counted_quantity" swaggertype:"number"`
+	Variance         measure.Quantity  `json:"variance" swaggertype:"number"`
+	Cost             money.Amount      `json:"cost"`
+	VarianceCost     money.Amount      `json:"variance_cost"`
+}
+
+// CreateInventoryCount opens a count of the products of the branch, of the categories only when they are given.
+type CreateInventoryCount struct {
+	BranchID    string   `json:"branch_id"`
+	CategoryIDs []string `json:"category_ids"`
+	Note        string   `json:"note"`
+	CreatedBy   string   `json:"-"`
+}
+
+type InventoryCountEntry struct {
+	ProductID string           `json:"product_id"`
+	Quantity  measure.Quantity `json:"quantity" swaggertype:"number"`
+}
+
+// CountInventory adds what a counter counted to the count, entries of several counters add up.
+type CountInventory struct {
+	ID        string                `json:"-"`
+	Entries   []InventoryCountEntry `json:"entries"`
+	CountedBy string                `json:"-"`
+}
+
+type InventoryCountsResponse struct {
+	InventoryCounts []InventoryCount `json:"inventory_counts"`
+	Count           int              `json:"count"`
+	Cursors
+}
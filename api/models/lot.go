@@ -0,0 +1,55 @@
+package models
+
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
+// Lot is the stock of a product received with one income line, Cost is per unit of the product
+// and Remaining is what is left of the lot.
+type Lot struct {
+	ID              string           `json:"id"`
+	ProductID       string           `json:"product_id"`
+	ProductName     string           `json:"product_name"`
+	BranchID        string           `json:"branch_id"`
+	IncomeProductID string           `json:"income_product_id"`
+	LotNumber       string           `json:"lot_number"`
+	ExpiryDate      string           `json:"expiry_date"`
+	Expired         bool             `json:"expired"`
+	Cost            money.Amount     `json:"cost"`
+	Quantity        measure.Quantity `json:"quantity" swaggertype:"number"`
+	Remaining       measure.Quantity `json:"remaining" swaggertype:"number"`
+	CreatedAt       string           `json:"created_at"`
+}
+
+type CreateLot struct {
+	ProductID       string
+	IncomeProductID string
+	LotNumber       string
+	ExpiryDate      string
+	Cost            money.Amount
+	Quantity        measure.Quantity
+}
+
+// LotFilter selects lots with stock left, of the product or of the branch,
+// ExpiringWithinDays keeps only lots expiring within as many days, expired ones included.
+type LotFilter struct {
+	ProductID          string
+	BranchID           string
+	ExpiringWithinDays *int
+}
+
+// TakeLot takes quantity out of a lot for a sale or a write-off, ReferenceID is the basket or the write-off
+// and Cost is the unit cost of the lot.
+type TakeLot struct {
+	LotID       string
+	Quantity    measure.Quantity
+	Cost        money.Amount
+	Kind        string
+	ReferenceID string
+}
+
+type LotsResponse struct {
+	Lots  []Lot `json:"lots"`
+	Count int   `json:"count"`
+}
@@ -0,0 +1,33 @@
+package models
+
+type LoyaltyTransaction struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	BasketID  string `json:"basket_id"`
+	Type      string `json:"type"`
+	Points    int    `json:"points"`
+	Remaining int    `json:"remaining"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedAt string `json:"created_at"`
+}
+
+type EarnLoyaltyPoints struct {
+	UserID   string `json:"user_id"`
+	BasketID string `json:"basket_id"`
+	Points   int    `json:"points"`
+	TTLDays  int    `json:"ttl_days"`
+}
+
+type RedeemLoyaltyPoints struct {
+	UserID   string `json:"user_id"`
+	BasketID string `json:"basket_id"`
+	Points   int    `json:"points"`
+}
+
+type LoyaltyResponse struct {
+	UserID  string               `json:"user_id"`
+	Balance int                  `json:"balance"`
+	History []LoyaltyTransaction `json:"history"`
+	Count   int                  `json:"count"`
+	Cursors
+}
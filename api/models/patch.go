@@ -0,0 +1,61 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Patch is a JSON merge patch of a row: only the fields present in Document are changed,
+// a null value clears the field.
+type Patch struct {
+	ID       string
+	Version  int
+	Document json.RawMessage
+}
+
+// Fields returns the names of the fields the patch changes, the version it was made on is not one of them.
+func (p Patch) Fields() ([]string, error) {
+	document := map[string]json.RawMessage{}
+	if err := json.Unmarshal(p.Document, &document); err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(document))
+	for field := range document {
+		if field != "version" {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+
+	return fields, nil
+}
+
+// NullFields returns the names of the fields the patch sets to null.
+func (p Patch) NullFields() ([]string, error) {
+	document := map[string]json.RawMessage{}
+	if err := json.Unmarshal(p.Document, &document); err != nil {
+		return nil, err
+	}
+
+	fields := []string{}
+	for field, value := range document {
+		if string(bytes.TrimSpace(value)) == "null" {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+
+	return fields, nil
+}
+
+// Decode reads the patch into the update model of the row, fields the model does not have are refused.
+func (p Patch) Decode(v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(p.Document))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(v)
+}
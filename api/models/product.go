@@ -1,62 +1,121 @@
 package models
 
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
 type Product struct {
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	Price         int    `json:"price"`
-	OriginalPrice int    `json:"original_price"`
-	Quantity      int    `json:"quantity"`
-	CategoryID    string `json:"category_id"`
-	BranchID      string `json:"branch_id"`
-	CreatedAt     string `json:"created_at"`
-	UpdatedAt     string `json:"updated_at"`
+	ID                string            `json:"id"`
+	Version           int               `json:"version"`
+	Name              string            `json:"name"`
+	SKU               string            `json:"sku"`
+	Barcodes          []string          `json:"barcodes"`
+	ParentID          string            `json:"parent_id"`
+	Attributes        map[string]string `json:"attributes"`
+	Price             money.Amount      `json:"price"`
+	OriginalPrice     money.Amount      `json:"original_price"`
+	Quantity          measure.Quantity  `json:"quantity" swaggertype:"number"`
+	Unit              string            `json:"unit"`
+	CategoryID        string            `json:"category_id"`
+	BranchID          string            `json:"branch_id"`
+	TaxRate           *float64          `json:"tax_rate"`
+	Currency          string            `json:"currency"`
+	Variants          []Product         `json:"variants,omitempty"`
+	DeletedReferences []string          `json:"deleted_references,omitempty"`
+	CreatedAt         string            `json:"created_at"`
+	UpdatedAt         string            `json:"updated_at"`
 }
 
+// CreateProduct with ParentID creates a variant of that product, Attributes (size, color, volume) tell variants apart.
 type CreateProduct struct {
-	Name          string `json:"name"`
-	Price         int    `json:"price"`
-	OriginalPrice int    `json:"original_price"`
-	Quantity      int    `json:"quantity"`
-	CategoryID    string `json:"category_id"`
-	BranchID      string `json:"branch_id"`
+	Name          string            `json:"name"`
+	SKU           string            `json:"sku"`
+	Barcodes      []string          `json:"barcodes"`
+	ParentID      string            `json:"parent_id"`
+	Attributes    map[string]string `json:"attributes"`
+	Price         money.Amount      `json:"price"`
+	OriginalPrice money.Amount      `json:"original_price"`
+	Quantity      measure.Quantity  `json:"quantity" swaggertype:"number"`
+	Unit          string            `json:"unit"`
+	CategoryID    string            `json:"category_id"`
+	BranchID      string            `json:"branch_id"`
+	TaxRate       *float64          `json:"tax_rate"`
+	CreatedBy     string            `json:"-"`
 }
 
+// UpdateProduct keeps barcodes of the product when Barcodes is omitted and replaces them otherwise.
 type UpdateProduct struct {
-	ID            string `json:"-"`
-	Name          string `json:"name"`
-	Price         int    `json:"price"`
-	OriginalPrice int    `json:"original_price"`
-	Quantity      int    `json:"quantity"`
-	CategoryID    string `json:"category_id"`
+	ID            string            `json:"-"`
+	Version       int               `json:"version"`
+	Name          string            `json:"name"`
+	SKU           string            `json:"sku"`
+	Barcodes      []string          `json:"barcodes"`
+	Attributes    map[string]string `json:"attributes"`
+	Price         money.Amount      `json:"price"`
+	OriginalPrice money.Amount      `json:"original_price"`
+	Quantity      measure.Quantity  `json:"quantity" swaggertype:"number"`
+	Unit          string            `json:"unit"`
+	CategoryID    string            `json:"category_id"`
+	TaxRate       *float64          `json:"tax_rate"`
+	UpdatedBy     string            `json:"-"`
 }
 
 type ProductResponse struct {
 	Products []Product
 	Count    int
+	Cursors
 }
 
 type ProductSell struct {
-	SelectedProducts       SellRequest    `json:"selected_products"`
-	ProductPrices          map[string]int `json:"product_prices"`
-	NotEnoughProducts      map[string]int `json:"not_enough_products"`
-	NotEnoughProductPrices map[string]int `json:"prices"`
-	ProductsBranchID       string         `json:"products_branch_id"`
-	Check                  Check          `json:"check"`
+	SelectedProducts       SellRequest                 `json:"selected_products"`
+	ProductCosts           map[string]money.Amount     `json:"product_costs"`
+	TaxRates               map[string]float64          `json:"tax_rates"`
+	NotEnoughProducts      map[string]measure.Quantity `json:"not_enough_products" swaggertype:"object,number"`
+	NotEnoughProductPrices map[string]money.Amount     `json:"prices"`
+	ProductsBranchID       string                      `json:"products_branch_id"`
+	Check                  Check                       `json:"check"`
 }
 
+// SellRequest takes quantities of products by their ids in Products and by barcodes in Barcodes.
+// Products without enough stock are backordered when Backorder is set, instead of being delivered by the dealer.
 type SellRequest struct {
-	Products map[string]int `json:"products"`
-	BasketID string         `json:"basket_id"`
-	BranchID string         `json:"branch_id"`
+	Products      map[string]measure.Quantity `json:"products" swaggertype:"object,number"`
+	Barcodes      map[string]measure.Quantity `json:"barcodes" swaggertype:"object,number"`
+	BasketID      string                      `json:"basket_id"`
+	BranchID      string                      `json:"branch_id"`
+	LoyaltyPoints int                         `json:"loyalty_points"`
+	Backorder     bool                        `json:"backorder"`
 }
 
 type DeliverProducts struct {
-	NotEnoughProducts map[string]int `json:"not_enough_products"`
-	NewProducts       map[string]int `json:"new_products"`
-	NewProductPrices  map[string]int `json:"new_product_prices"`
+	NotEnoughProducts map[string]measure.Quantity `json:"not_enough_products" swaggertype:"object,number"`
+	NewProducts       map[string]measure.Quantity `json:"new_products" swaggertype:"object,number"`
+	NewProductPrices  map[string]money.Amount     `json:"new_product_prices"`
+}
+
+type CheckProduct struct {
+	ID       string           `json:"id"`
+	Name     string           `json:"name"`
+	Price    money.Amount     `json:"price"`
+	Quantity measure.Quantity `json:"quantity" swaggertype:"number"`
+	Unit     string           `json:"unit"`
+	TaxRate  float64          `json:"tax_rate"`
+	NetSum   money.Amount     `json:"net_sum"`
+	TaxSum   money.Amount     `json:"tax_sum"`
+	GrossSum money.Amount     `json:"gross_sum"`
+	Cost     money.Amount     `json:"-"`
 }
 
 type Check struct {
-	Products []Product `json:"products"`
-	TotalSum int       `json:"total_sum"`
+	Products            []CheckProduct `json:"products"`
+	TaxInclusive        bool           `json:"tax_inclusive"`
+	Currency            string         `json:"currency"`
+	NetSum              money.Amount   `json:"net_sum"`
+	TaxSum              money.Amount   `json:"tax_sum"`
+	TotalSum            money.Amount   `json:"total_sum"`
+	PaidSum             money.Amount   `json:"paid_sum"`
+	LoyaltyPointsUsed   int            `json:"loyalty_points_used"`
+	LoyaltyPointsEarned int            `json:"loyalty_points_earned"`
+	Backorders          []Backorder    `json:"backorders,omitempty"`
 }
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
+// ProductImportRow is a product read from a line of an import file, the header being line 1.
+type ProductImportRow struct {
+	Line          int
+	ID            string
+	Name          string
+	SKU           string
+	Category      string
+	Price         money.Amount
+	OriginalPrice money.Amount
+	Quantity      measure.Quantity
+	Unit          string
+	Branch        string
+}
+
+type ProductImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ProductImportResult reports an import, products are only created when no row has errors.
+type ProductImportResult struct {
+	DryRun   bool                 `json:"dry_run"`
+	Rows     int                  `json:"rows"`
+	Valid    int                  `json:"valid"`
+	Imported int                  `json:"imported"`
+	Errors   []ProductImportError `json:"errors"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "test/pkg/money"
+
+type ProductPrice struct {
+	ID            string       `json:"id"`
+	ProductID     string       `json:"product_id"`
+	Price         money.Amount `json:"price"`
+	OriginalPrice money.Amount `json:"original_price"`
+	Currency      string       `json:"currency"`
+	Status        string       `json:"status"`
+	StartsAt      string       `json:"starts_at"`
+	CreatedBy     string       `json:"created_by"`
+	CreatedAt     string       `json:"created_at"`
+}
+
+type CreateProductPrice struct {
+	ProductID     string       `json:"-"`
+	Price         money.Amount `json:"price"`
+	OriginalPrice money.Amount `json:"original_price"`
+	StartsAt      string       `json:"starts_at"`
+	CreatedBy     string       `json:"-"`
+}
+
+type ProductPricesResponse struct {
+	ProductPrices []ProductPrice `json:"product_prices"`
+	Count         int            `json:"count"`
+	Cursors
+}
@@ -0,0 +1,22 @@
+package models
+
+import "test/pkg/money"
+
+type BranchProfit struct {
+	BranchID   string       `json:"branch_id"`
+	BranchName string       `json:"branch_name"`
+	Currency   string       `json:"currency"`
+	Profit     money.Amount `json:"profit"`
+	Rate       float64      `json:"rate"`
+	BaseProfit money.Amount `json:"base_profit"`
+	Loss       money.Amount `json:"loss"`
+	BaseLoss   money.Amount `json:"base_loss"`
+}
+
+type ProfitReport struct {
+	BaseCurrency string         `json:"base_currency"`
+	Date         string         `json:"date"`
+	Branches     []BranchProfit `json:"branches"`
+	TotalProfit  money.Amount   `json:"total_profit"`
+	TotalLoss    money.Amount   `json:"total_loss"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "test/pkg/measure"
+
+// Kinds of stock movements.
+const (
+	StockMovementAdjustment = "adjustment"
+	StockMovementInventory  = "inventory"
+	StockMovementWriteOff   = "write_off"
+	StockMovementIncome     = "income"
+	StockMovementSale       = "sale"
+	StockMovementBackorder  = "backorder"
+)
+
+// StockMovement is a change of product stock, Quantity is negative when stock leaves and Balance is the stock after it.
+type StockMovement struct {
+	ID        string           `json:"id"`
+	ProductID string           `json:"product_id"`
+	Kind      string           `json:"kind"`
+	Quantity  measure.Quantity `json:"quantity" swaggertype:"number"`
+	Balance   measure.Quantity `json:"balance" swaggertype:"number"`
+	Reason    string           `json:"reason"`
+	CreatedBy string           `json:"created_by"`
+	CreatedAt string           `json:"created_at"`
+}
+
+type CreateStockMovement struct {
+	ProductID string
+	Kind      string
+	Quantity  measure.Quantity
+	Balance   measure.Quantity
+	Reason    string
+	CreatedBy string
+}
+
+type StockMovementsResponse struct {
+	StockMovements []StockMovement `json:"stock_movements"`
+	Count          int             `json:"count"`
+	Cursors
+}
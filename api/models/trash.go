@@ -0,0 +1,25 @@
+package models
+
+import "encoding/json"
+
+// Entities with soft-deleted rows that can be listed in the trash and restored.
+const (
+	EntityUser          = "users"
+	EntityCategory      = "categories"
+	EntityProduct       = "products"
+	EntityBasket        = "baskets"
+	EntityBasketProduct = "basket_products"
+	EntityBranch        = "branches"
+	EntityIncome        = "incomes"
+	EntityIncomeProduct = "income_products"
+)
+
+// Dependents counts live rows referencing a row, keyed by their entity.
+type Dependents map[string]int
+
+// DeletedRow is a row deleted along with a row it depends on, Row is its state before the delete.
+type DeletedRow struct {
+	Entity string
+	ID     string
+	Row    json.RawMessage
+}
@@ -0,0 +1,40 @@
+package models
+
+import "test/pkg/measure"
+
+// Unit is a unit of measure, only fractional units allow quantities like 1.25.
+type Unit struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Fractional bool   `json:"fractional"`
+}
+
+type UnitsResponse struct {
+	Units []Unit `json:"units"`
+	Count int    `json:"count"`
+}
+
+// ProductUnit is a packaging of a product, Factor is how many product units it holds (a box of 12 pieces).
+type ProductUnit struct {
+	ID        string           `json:"id"`
+	ProductID string           `json:"product_id"`
+	Unit      string           `json:"unit"`
+	Factor    measure.Quantity `json:"factor" swaggertype:"number"`
+	CreatedAt string           `json:"created_at"`
+	UpdatedAt string           `json:"updated_at"`
+}
+
+type SetProductUnit struct {
+	Unit   string           `json:"unit"`
+	Factor measure.Quantity `json:"factor" swaggertype:"number"`
+}
+
+type SetProductUnits struct {
+	ProductID string           `json:"-"`
+	Units     []SetProductUnit `json:"units"`
+}
+
+type ProductUnitsResponse struct {
+	ProductUnits []ProductUnit `json:"product_units"`
+	Count        int           `json:"count"`
+}
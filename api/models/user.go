@@ -1,38 +1,45 @@
 package models
 
-import "time"
+import (
+	"test/pkg/money"
+	"time"
+)
 
 type User struct {
-	ID        string    `json:"id"`
-	FullName  string    `json:"full_name"`
-	Phone     string    `json:"phone"`
-	Password  string    `json:"password"`
-	Cash      uint      `json:"cash"`
-	UserType  string    `json:"user_type"`
-	BranchID  string    `json:"branch_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt string    `json:"updated_at"`
+	ID                string       `json:"id"`
+	Version           int          `json:"version"`
+	FullName          string       `json:"full_name"`
+	Phone             string       `json:"phone"`
+	Password          string       `json:"password"`
+	Cash              money.Amount `json:"cash"`
+	UserType          string       `json:"user_type"`
+	BranchID          string       `json:"branch_id"`
+	DeletedReferences []string     `json:"deleted_references,omitempty"`
+	CreatedAt         time.Time    `json:"created_at"`
+	UpdatedAt         string       `json:"updated_at"`
 }
 
 type CreateUser struct {
-	FullName string `json:"full_name"`
-	Phone    string `json:"phone"`
-	Password string `json:"password"`
-	Cash     uint   `json:"cash"`
-	UserType string `json:"user_type"`
-	BranchID string `json:"branch_id"`
+	FullName string       `json:"full_name"`
+	Phone    string       `json:"phone"`
+	Password string       `json:"password"`
+	Cash     money.Amount `json:"cash"`
+	UserType string       `json:"user_type"`
+	BranchID string       `json:"branch_id"`
 }
 
 type UpdateUser struct {
-	ID       string `json:"-"`
-	FullName string `json:"full_name"`
-	Phone    string `json:"phone"`
-	Cash     uint   `json:"cash"`
+	ID       string       `json:"-"`
+	Version  int          `json:"version"`
+	FullName string       `json:"full_name"`
+	Phone    string       `json:"phone"`
+	Cash     money.Amount `json:"cash"`
 }
 
 type UsersResponse struct {
 	Users []User `json:"users"`
 	Count int    `json:"count"`
+	Cursors
 }
 
 type UpdateUserPassword struct {
@@ -42,8 +49,8 @@ type UpdateUserPassword struct {
 }
 
 type UserSell struct {
-	FullName string `json:"full_name"`
-	Phone    string `json:"phone"`
-	Password string `json:"password"`
-	Cash     uint   `json:"cash"`
+	FullName string       `json:"full_name"`
+	Phone    string       `json:"phone"`
+	Password string       `json:"password"`
+	Cash     money.Amount `json:"cash"`
 }
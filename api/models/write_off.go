@@ -0,0 +1,65 @@
+package models
+
+import (
+	"test/pkg/measure"
+	"test/pkg/money"
+)
+
+// Reasons goods are written off for.
+const (
+	WriteOffDamage      = "damage"
+	WriteOffExpiry      = "expiry"
+	WriteOffTheft       = "theft"
+	WriteOffInternalUse = "internal_use"
+)
+
+// Statuses of write-offs, stock is decreased only when a write-off is posted.
+const (
+	WriteOffPending  = "pending"
+	WriteOffPosted   = "posted"
+	WriteOffRejected = "rejected"
+)
+
+// WriteOff takes goods of a branch out of stock without a sale, costs are in the currency of the branch.
+type WriteOff struct {
+	ID        string         `json:"id"`
+	BranchID  string         `json:"branch_id"`
+	Reason    string         `json:"reason"`
+	Status    string         `json:"status"`
+	Note      string         `json:"note"`
+	Lines     []WriteOffLine `json:"lines,omitempty"`
+	TotalCost money.Amount   `json:"total_cost"`
+	CreatedBy string         `json:"created_by"`
+	ClosedBy  string         `json:"closed_by"`
+	ClosedAt  string         `json:"closed_at"`
+	CreatedAt string         `json:"created_at"`
+	UpdatedAt string         `json:"updated_at"`
+}
+
+// WriteOffLine is a product written off, its Cost is the current cost of the product until the write-off is posted.
+type WriteOffLine struct {
+	ProductID   string           `json:"product_id"`
+	ProductName string           `json:"product_name"`
+	Quantity    measure.Quantity `json:"quantity" swaggertype:"number"`
+	Cost        money.Amount     `json:"cost"`
+	TotalCost   money.Amount     `json:"total_cost"`
+}
+
+type CreateWriteOffLine struct {
+	ProductID string           `json:"product_id"`
+	Quantity  measure.Quantity `json:"quantity" swaggertype:"number"`
+}
+
+type CreateWriteOff struct {
+	BranchID  string               `json:"branch_id"`
+	Reason    string               `json:"reason"`
+	Note      string               `json:"note"`
+	Lines     []CreateWriteOffLine `json:"lines"`
+	CreatedBy string               `json:"-"`
+}
+
+type WriteOffsResponse struct {
+	WriteOffs []WriteOff `json:"write_offs"`
+	Count     int        `json:"count"`
+	Cursors
+}
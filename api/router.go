@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"log"
@@ -25,6 +26,7 @@ func New(services service.IServiceManager, log logger.ILogger) *gin.Engine {
 	r := gin.New()
 
 	r.Use(authenticateMiddleware)
+	r.Use(requestIDMiddleware)
 	r.Use(gin.Logger())
 
 	{
@@ -33,50 +35,115 @@ func New(services service.IServiceManager, log logger.ILogger) *gin.Engine {
 		r.GET("/users", h.GetUserList)
 		r.PUT("/user/:id", h.UpdateUser)
 		r.DELETE("/user/:id", h.DeleteUser)
-		r.PATCH("/user/:id", h.UpdateUserPassword)
+		r.PATCH("/user/:id", h.PatchUser)
+		r.PUT("/user/:id/password", h.UpdateUserPassword)
+		r.GET("/user/:id/loyalty", h.GetUserLoyalty)
+		r.POST("/user/:id/restore", h.RestoreDeleted)
 
 		r.POST("/category", h.CreateCategory)
 		r.GET("/category/:id", h.GetCategory)
 		r.GET("/categories", h.GetCategoryList)
+		r.GET("/categories/tree", h.GetCategoryTree)
 		r.PUT("/category/:id", h.UpdateCategory)
+		r.PATCH("/category/:id", h.PatchCategory)
 		r.DELETE("/category/:id", h.DeleteCategory)
+		r.POST("/category/:id/restore", h.RestoreDeleted)
 
 		r.POST("/product", h.CreateProduct)
 		r.GET("/product/:id", h.GetProduct)
+		r.GET("/product/by-barcode/:code", h.GetProductByBarcode)
 		r.GET("/products", h.GetProductList)
+		r.GET("/products/search", h.SearchProducts)
+		r.POST("/products/import", h.ImportProducts)
+		r.GET("/products/export", h.ExportProducts)
+		r.POST("/products/prices", h.BulkUpdateProductPrices)
+		r.POST("/products/stock", h.AdjustProductStock)
 		r.PUT("/product/:id", h.UpdateProduct)
+		r.PATCH("/product/:id", h.PatchProduct)
 		r.DELETE("/product/:id", h.DeleteProduct)
+		r.POST("/product/:id/restore", h.RestoreDeleted)
+		r.GET("/product/:id/price-history", h.GetProductPriceHistory)
+		r.GET("/product/:id/stock-history", h.GetProductStockHistory)
+		r.GET("/product/:id/lots", h.GetProductLots)
+		r.POST("/product/:id/price-schedule", h.ScheduleProductPrice)
+		r.GET("/product/:id/units", h.GetProductUnits)
+		r.PUT("/product/:id/units", h.SetProductUnits)
 
 		r.POST("/basket", h.CreateBasket)
 		r.GET("/basket/:id", h.GetBasket)
 		r.GET("/baskets", h.GetBasketList)
 		r.PUT("basket/:id", h.UpdateBasket)
+		r.PATCH("basket/:id", h.PatchBasket)
 		r.DELETE("basket/:id", h.DeleteBasket)
+		r.POST("/basket/:id/restore", h.RestoreDeleted)
 
 		r.POST("/basketProduct", h.CreateBasketProduct)
 		r.GET("/basketProduct/:id", h.GetBasketProduct)
 		r.GET("/basketProducts", h.GetBasketProductList)
 		r.PUT("/basketProduct/:id", h.UpdateBasketProduct)
+		r.PATCH("/basketProduct/:id", h.PatchBasketProduct)
 		r.DELETE("/basketProduct/:id", h.DeleteBasketProduct)
+		r.POST("/basketProduct/:id/restore", h.RestoreDeleted)
 
 		r.POST("/branch", h.CreateBranch)
 		r.GET("/branch/:id", h.GetBranch)
 		r.GET("/branches", h.GetBranchList)
 		r.PUT("/branch/:id", h.UpdateBranch)
+		r.PATCH("/branch/:id", h.PatchBranch)
 		r.DELETE("/branch/:id", h.DeleteBranch)
+		r.POST("/branch/:id/restore", h.RestoreDeleted)
+		r.GET("/branch/:id/prices", h.GetBranchProductPrices)
+		r.PUT("/branch/:id/prices", h.SetBranchProductPrices)
+		r.DELETE("/branch/:id/prices", h.DeleteBranchProductPrices)
 
 		r.POST("/income", h.CreateIncome)       // create
 		r.GET("/income/:id", h.GetIncome)       // get by id
 		r.GET("/incomes", h.GetIncomeList)      // get list
 		r.DELETE("/income/:id", h.DeleteIncome) // delete
+		r.POST("/income/:id/restore", h.RestoreDeleted)
+		r.POST("/income/:id/post", h.PostIncome)
 
 		r.POST("/income_products", h.CreateIncomeProducts)   // create multiple
 		r.GET("/income_products", h.GetIncomeProductsList)   // get income products (filter => by income_id)
 		r.PUT("/income_products", h.UpdateIncomeProducts)    // update multiple
 		r.DELETE("/income_products", h.DeleteIncomeProducts) // delete multiple
+		r.POST("/income_products/:id/restore", h.RestoreDeleted)
 
 		r.POST("/sell-new", h.StartSellNew)
 
+		r.POST("/currency-rate", h.SetCurrencyRate)
+		r.GET("/currency-rates", h.GetCurrencyRateList)
+		r.DELETE("/currency-rate/:id", h.DeleteCurrencyRate)
+
+		r.POST("/inventory-count", h.CreateInventoryCount)
+		r.GET("/inventory-count/:id", h.GetInventoryCount)
+		r.GET("/inventory-counts", h.GetInventoryCountList)
+		r.POST("/inventory-count/:id/entries", h.CountInventory)
+		r.POST("/inventory-count/:id/approve", h.ApproveInventoryCount)
+		r.POST("/inventory-count/:id/cancel", h.CancelInventoryCount)
+
+		r.POST("/write-off", h.CreateWriteOff)
+		r.GET("/write-off/:id", h.GetWriteOff)
+		r.GET("/write-offs", h.GetWriteOffList)
+		r.POST("/write-off/:id/approve", h.ApproveWriteOff)
+		r.POST("/write-off/:id/reject", h.RejectWriteOff)
+
+		r.GET("/lots/expiring", h.GetExpiringLots)
+
+		r.GET("/backorder/:id", h.GetBackorder)
+		r.GET("/backorders", h.GetBackorderList)
+		r.POST("/backorder/:id/cancel", h.CancelBackorder)
+		r.POST("/backorder/:id/collect", h.CollectBackorder)
+		r.POST("/backorder/:id/release", h.ReleaseBackorder)
+
+		r.GET("/report/profit", h.GetProfitReport)
+
+		r.GET("/units", h.GetUnitList)
+
+		r.GET("/trash/:entity", h.GetTrashList)
+
+		r.GET("/audit", h.GetAuditLog)
+
 		r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	}
 
@@ -92,6 +159,19 @@ func authenticateMiddleware(c *gin.Context) {
 	}
 }
 
+// requestIDMiddleware gives every request an id, the one sent by the client is kept.
+// The id is sent back in the response so that the request can be found in the audit log.
+func requestIDMiddleware(c *gin.Context) {
+	requestID := c.GetHeader(handler.RequestIDHeader)
+	if requestID == "" {
+		requestID = uuid.New().String()
+		c.Request.Header.Set(handler.RequestIDHeader, requestID)
+	}
+
+	c.Header(handler.RequestIDHeader, requestID)
+	c.Next()
+}
+
 func traceRequest(c *gin.Context) {
 	beforeRequest(c)
 
@@ -21,7 +21,14 @@ func main() {
 	}
 	defer pgStore.Close()
 
-	services := service.New(pgStore, log)
+	services := service.New(cfg, pgStore, log)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go services.ProductPrice().RunScheduler(ctx, cfg.PriceSchedulerInterval)
+	go services.Trash().RunPurger(ctx, cfg.TrashPurgeInterval)
+	go services.Backorder().RunNotifier(ctx, cfg.BackorderNotifyInterval)
 
 	server := api.New(services, log)
 
@@ -3,11 +3,20 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
 )
 
+// Methods of costing sold goods: fifo costs a sale with the lots it takes, average with the moving
+// average cost of the product.
+const (
+	CostFIFO    = "fifo"
+	CostAverage = "average"
+)
+
 type Config struct {
 	PostgresHost     string
 	PostgresPort     string
@@ -17,6 +26,34 @@ type Config struct {
 
 	ServiceName string
 	LoggerLevel string
+
+	LoyaltyPercent       float64
+	LoyaltyPointsTTLDays int
+
+	// PriceSchedulerInterval of zero turns the activation of scheduled prices off.
+	PriceSchedulerInterval time.Duration
+
+	DefaultTaxRate   float64
+	PricesIncludeTax bool
+
+	BaseCurrency string
+
+	LowStockThreshold float64
+
+	CostMethod string
+
+	// BackorderWebhookURL is called when backorders are fulfilled, notifications are off while it is empty.
+	// Requests are signed with BackorderWebhookSecret when it is set, a zero BackorderNotifyInterval turns them off.
+	BackorderWebhookURL     string
+	BackorderWebhookSecret  string
+	BackorderNotifyInterval time.Duration
+
+	// TrashRetentionDays is at least a day, a zero TrashPurgeInterval turns purging off.
+	TrashRetentionDays int
+	TrashPurgeInterval time.Duration
+
+	// DeleteCascade lists entities whose delete also deletes their dependents, others refuse while they have any.
+	DeleteCascade []string
 }
 
 func Load() Config {
@@ -35,9 +72,55 @@ func Load() Config {
 	cfg.ServiceName = cast.ToString(getOrReturnDefault("SERVICE_NAME", "store"))
 	cfg.LoggerLevel = cast.ToString(getOrReturnDefault("LOGGER_LEVEL", "debug"))
 
+	cfg.LoyaltyPercent = cast.ToFloat64(getOrReturnDefault("LOYALTY_PERCENT", 1))
+	cfg.LoyaltyPointsTTLDays = cast.ToInt(getOrReturnDefault("LOYALTY_POINTS_TTL_DAYS", 365))
+
+	cfg.PriceSchedulerInterval = getInterval("PRICE_SCHEDULER_INTERVAL", "1m")
+
+	cfg.DefaultTaxRate = cast.ToFloat64(getOrReturnDefault("DEFAULT_TAX_RATE", 12))
+	cfg.PricesIncludeTax = cast.ToBool(getOrReturnDefault("PRICES_INCLUDE_TAX", true))
+
+	cfg.BaseCurrency = cast.ToString(getOrReturnDefault("BASE_CURRENCY", "UZS"))
+
+	cfg.LowStockThreshold = cast.ToFloat64(getOrReturnDefault("LOW_STOCK_THRESHOLD", 5))
+
+	cfg.CostMethod = cast.ToString(getOrReturnDefault("COST_METHOD", CostFIFO))
+	if cfg.CostMethod != CostFIFO && cfg.CostMethod != CostAverage {
+		fmt.Printf("error!!! COST_METHOD should be %s or %s, not %q, costing with %s\n", CostFIFO, CostAverage, cfg.CostMethod, CostFIFO)
+		cfg.CostMethod = CostFIFO
+	}
+
+	cfg.BackorderWebhookURL = cast.ToString(getOrReturnDefault("BACKORDER_WEBHOOK_URL", ""))
+	cfg.BackorderWebhookSecret = cast.ToString(getOrReturnDefault("BACKORDER_WEBHOOK_SECRET", ""))
+	cfg.BackorderNotifyInterval = getInterval("BACKORDER_NOTIFY_INTERVAL", "1m")
+
+	cfg.TrashRetentionDays = cast.ToInt(getOrReturnDefault("TRASH_RETENTION_DAYS", 30))
+	if cfg.TrashRetentionDays < 1 {
+		fmt.Printf("error!!! TRASH_RETENTION_DAYS should be positive, not %d, keeping the trash for 30 days\n", cfg.TrashRetentionDays)
+		cfg.TrashRetentionDays = 30
+	}
+	cfg.TrashPurgeInterval = getInterval("TRASH_PURGE_INTERVAL", "24h")
+
+	cfg.DeleteCascade = strings.FieldsFunc(cast.ToString(getOrReturnDefault("DELETE_CASCADE", "")), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
 	return cfg
 }
 
+// getInterval reads the interval of a background job, a zero or negative one turns the job off
+// and is kept as zero. A value that is not a duration falls back to the default.
+func getInterval(key string, defaultValue string) time.Duration {
+	interval, err := cast.ToDurationE(getOrReturnDefault(key, defaultValue))
+	if err != nil {
+		fmt.Printf("error!!! %s should be a duration like %s: %v\n", key, defaultValue, err)
+
+		return cast.ToDuration(defaultValue)
+	}
+
+	return max(interval, 0)
+}
+
 func getOrReturnDefault(key string, defaultValue interface{}) interface{} {
 	value := os.Getenv(key)
 	if value != "" {
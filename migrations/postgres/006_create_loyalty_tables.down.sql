@@ -0,0 +1,3 @@
+drop table if exists loyalty_transactions;
+
+drop type if exists loyalty_transaction_type_enum;
\ No newline at end of file
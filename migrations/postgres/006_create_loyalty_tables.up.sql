@@ -0,0 +1,14 @@
+create type loyalty_transaction_type_enum as enum ('earn', 'redeem', 'expire');
+
+create table if not exists loyalty_transactions (
+    id uuid primary key,
+    user_id uuid references users(id) not null,
+    basket_id uuid references baskets(id),
+    type loyalty_transaction_type_enum not null,
+    points int not null,
+    remaining int default 0,
+    expires_at timestamp,
+    created_at timestamp default now()
+);
+
+create index if not exists loyalty_transactions_user_id_idx on loyalty_transactions(user_id);
@@ -0,0 +1,3 @@
+drop table if exists product_prices;
+
+drop type if exists product_price_status_enum;
\ No newline at end of file
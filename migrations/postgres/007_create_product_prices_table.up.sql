@@ -0,0 +1,16 @@
+create type product_price_status_enum as enum ('scheduled', 'active');
+
+create table if not exists product_prices (
+    id uuid primary key,
+    product_id uuid references products(id) not null,
+    price int not null,
+    original_price int not null,
+    status product_price_status_enum not null,
+    starts_at timestamp not null default now(),
+    created_by varchar(50),
+    created_at timestamp default now()
+);
+
+create index if not exists product_prices_product_id_idx on product_prices(product_id);
+
+create index if not exists product_prices_scheduled_idx on product_prices(starts_at) where status = 'scheduled';
@@ -0,0 +1 @@
+drop table if exists branch_product_prices;
\ No newline at end of file
@@ -0,0 +1,9 @@
+create table if not exists branch_product_prices (
+    id uuid primary key,
+    branch_id uuid references branches(id) not null,
+    product_id uuid references products(id) not null,
+    price int not null,
+    created_at timestamp default now(),
+    updated_at timestamp,
+    unique (branch_id, product_id)
+);
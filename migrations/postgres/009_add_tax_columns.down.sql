@@ -0,0 +1,17 @@
+alter table basket_products
+    drop column if exists price,
+    drop column if exists tax_rate,
+    drop column if exists net_sum,
+    drop column if exists tax_sum,
+    drop column if exists gross_sum;
+
+alter table baskets
+    drop column if exists net_sum,
+    drop column if exists tax_sum,
+    drop column if exists tax_inclusive;
+
+alter table products
+    drop column if exists tax_rate;
+
+alter table categories
+    drop column if exists tax_rate;
\ No newline at end of file
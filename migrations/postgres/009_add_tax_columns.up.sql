@@ -0,0 +1,17 @@
+alter table categories
+    add column if not exists tax_rate numeric(5, 2);
+
+alter table products
+    add column if not exists tax_rate numeric(5, 2);
+
+alter table baskets
+    add column if not exists net_sum int default 0,
+    add column if not exists tax_sum int default 0,
+    add column if not exists tax_inclusive boolean default true;
+
+alter table basket_products
+    add column if not exists price int default 0,
+    add column if not exists tax_rate numeric(5, 2) default 0,
+    add column if not exists net_sum int default 0,
+    add column if not exists tax_sum int default 0,
+    add column if not exists gross_sum int default 0;
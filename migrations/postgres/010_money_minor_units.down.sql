@@ -0,0 +1,39 @@
+update loyalty_transactions set points = round(points / 100.0), remaining = round(remaining / 100.0);
+
+alter table branch_product_prices
+    alter column price type int using round(price / 100.0);
+
+alter table product_prices
+    alter column price type int using round(price / 100.0),
+    alter column original_price type int using round(original_price / 100.0);
+
+alter table income_products
+    alter column price type int using round(price / 100.0);
+
+alter table incomes
+    alter column total_sum type integer using round(total_sum / 100.0);
+
+alter table dealer
+    alter column sum type integer using round(sum / 100.0);
+
+alter table store
+    alter column profit type numeric(100, 2) using profit / 100.0,
+    alter column budget type numeric(100, 2) using budget / 100.0;
+
+alter table basket_products
+    alter column price type int using round(price / 100.0),
+    alter column net_sum type int using round(net_sum / 100.0),
+    alter column tax_sum type int using round(tax_sum / 100.0),
+    alter column gross_sum type int using round(gross_sum / 100.0);
+
+alter table baskets
+    alter column total_sum type integer using round(total_sum / 100.0),
+    alter column net_sum type int using round(net_sum / 100.0),
+    alter column tax_sum type int using round(tax_sum / 100.0);
+
+alter table users
+    alter column cash type int using round(cash / 100.0);
+
+alter table products
+    alter column price type int using round(price / 100.0),
+    alter column original_price type int using round(original_price / 100.0);
\ No newline at end of file
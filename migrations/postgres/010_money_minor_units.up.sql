@@ -0,0 +1,41 @@
+-- amounts were kept in whole units, they are kept in minor units (cents) from now on
+alter table products
+    alter column price type bigint using round(price * 100)::bigint,
+    alter column original_price type bigint using round(original_price * 100)::bigint;
+
+alter table users
+    alter column cash type bigint using round(cash * 100)::bigint;
+
+alter table baskets
+    alter column total_sum type bigint using round(total_sum * 100)::bigint,
+    alter column net_sum type bigint using round(net_sum * 100)::bigint,
+    alter column tax_sum type bigint using round(tax_sum * 100)::bigint;
+
+alter table basket_products
+    alter column price type bigint using round(price * 100)::bigint,
+    alter column net_sum type bigint using round(net_sum * 100)::bigint,
+    alter column tax_sum type bigint using round(tax_sum * 100)::bigint,
+    alter column gross_sum type bigint using round(gross_sum * 100)::bigint;
+
+alter table store
+    alter column profit type bigint using round(profit * 100)::bigint,
+    alter column budget type bigint using round(budget * 100)::bigint;
+
+alter table dealer
+    alter column sum type bigint using round(sum * 100)::bigint;
+
+alter table incomes
+    alter column total_sum type bigint using round(total_sum * 100)::bigint;
+
+alter table income_products
+    alter column price type bigint using round(price * 100)::bigint;
+
+alter table product_prices
+    alter column price type bigint using round(price * 100)::bigint,
+    alter column original_price type bigint using round(original_price * 100)::bigint;
+
+alter table branch_product_prices
+    alter column price type bigint using round(price * 100)::bigint;
+
+-- a loyalty point pays one unit of the sum, so points are rescaled with the amounts
+update loyalty_transactions set points = points * 100, remaining = remaining * 100;
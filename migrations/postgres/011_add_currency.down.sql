@@ -0,0 +1,16 @@
+drop table if exists currency_rates;
+
+alter table baskets
+    drop column if exists currency;
+
+alter table branch_product_prices
+    drop column if exists currency;
+
+alter table product_prices
+    drop column if exists currency;
+
+alter table products
+    drop column if exists currency;
+
+alter table branches
+    drop column if exists currency;
@@ -0,0 +1,24 @@
+alter table branches
+    add column if not exists currency varchar(3) not null default 'UZS';
+
+alter table products
+    add column if not exists currency varchar(3) not null default 'UZS';
+
+alter table product_prices
+    add column if not exists currency varchar(3) not null default 'UZS';
+
+alter table branch_product_prices
+    add column if not exists currency varchar(3) not null default 'UZS';
+
+alter table baskets
+    add column if not exists currency varchar(3) not null default 'UZS';
+
+create table if not exists currency_rates (
+    id uuid primary key,
+    currency varchar(3) not null,
+    rate numeric(20, 6) not null,
+    rate_date date not null,
+    created_at timestamp default now(),
+    updated_at timestamp,
+    unique (currency, rate_date)
+);
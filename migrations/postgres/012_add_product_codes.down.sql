@@ -0,0 +1,6 @@
+drop table if exists product_barcodes;
+
+drop index if exists products_sku_key;
+
+alter table products
+    drop column if exists sku;
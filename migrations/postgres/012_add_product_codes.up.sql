@@ -0,0 +1,14 @@
+alter table products
+    alter column name type varchar(255),
+    add column if not exists sku varchar(64);
+
+create unique index if not exists products_sku_key on products (sku) where deleted_at = 0;
+
+create table if not exists product_barcodes (
+    id uuid primary key,
+    product_id uuid references products(id) not null,
+    barcode varchar(14) unique not null,
+    created_at timestamp default now()
+);
+
+create index if not exists product_barcodes_product_id_idx on product_barcodes (product_id);
@@ -0,0 +1,7 @@
+drop index if exists products_parent_id_attributes_key;
+
+drop index if exists products_parent_id_idx;
+
+alter table products
+    drop column if exists attributes,
+    drop column if exists parent_id;
@@ -0,0 +1,8 @@
+alter table products
+    add column if not exists parent_id uuid references products(id),
+    add column if not exists attributes jsonb not null default '{}';
+
+create index if not exists products_parent_id_idx on products (parent_id);
+
+create unique index if not exists products_parent_id_attributes_key on products (parent_id, attributes)
+    where parent_id is not null and deleted_at = 0;
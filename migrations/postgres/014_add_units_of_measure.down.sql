@@ -0,0 +1,13 @@
+drop table if exists product_units;
+
+alter table income_products
+    alter column quantity type int using round(quantity);
+
+alter table basket_products
+    alter column quantity type int using round(quantity);
+
+alter table products
+    alter column quantity type int using round(quantity),
+    drop column if exists unit;
+
+drop table if exists units;
@@ -0,0 +1,32 @@
+create table if not exists units (
+    code varchar(16) primary key,
+    name varchar(64) not null,
+    fractional boolean not null default false
+);
+
+insert into units (code, name, fractional) values
+    ('piece', 'Piece', false),
+    ('kg', 'Kilogram', true),
+    ('litre', 'Litre', true),
+    ('pack', 'Pack', false)
+on conflict (code) do nothing;
+
+alter table products
+    add column if not exists unit varchar(16) not null default 'piece' references units(code),
+    alter column quantity type numeric(14, 3);
+
+alter table basket_products
+    alter column quantity type numeric(14, 3);
+
+alter table income_products
+    alter column quantity type numeric(14, 3);
+
+create table if not exists product_units (
+    id uuid primary key,
+    product_id uuid references products(id) not null,
+    unit varchar(32) not null,
+    factor numeric(14, 3) not null check (factor > 0),
+    created_at timestamp default now(),
+    updated_at timestamp,
+    unique (product_id, unit)
+);
@@ -0,0 +1,4 @@
+drop index if exists categories_parent_id_idx;
+
+alter table categories
+    drop column if exists parent_id;
@@ -0,0 +1,4 @@
+alter table categories
+    add column if not exists parent_id uuid references categories(id);
+
+create index if not exists categories_parent_id_idx on categories (parent_id);
@@ -0,0 +1,14 @@
+drop index if exists products_name_trgm_idx;
+
+drop index if exists products_search_vector_idx;
+
+drop trigger if exists categories_search_vector_trigger on categories;
+
+drop function if exists categories_search_vector();
+
+drop trigger if exists products_search_vector_trigger on products;
+
+drop function if exists products_search_vector();
+
+alter table products
+    drop column if exists search_vector;
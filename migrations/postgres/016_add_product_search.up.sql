@@ -0,0 +1,36 @@
+create extension if not exists pg_trgm;
+
+alter table products
+    add column if not exists search_vector tsvector;
+
+-- the vector takes the category name too, so it is kept by triggers instead of a generated column
+create or replace function products_search_vector() returns trigger as $$
+begin
+    new.search_vector :=
+        setweight(to_tsvector('simple', coalesce(new.name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(new.sku, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce((select name from categories where id = new.category_id), '')), 'B');
+    return new;
+end
+$$ language plpgsql;
+
+create trigger products_search_vector_trigger
+    before insert or update of name, sku, category_id on products
+    for each row execute function products_search_vector();
+
+create or replace function categories_search_vector() returns trigger as $$
+begin
+    update products set name = name where category_id = new.id;
+    return new;
+end
+$$ language plpgsql;
+
+create trigger categories_search_vector_trigger
+    after update of name on categories
+    for each row when (old.name is distinct from new.name) execute function categories_search_vector();
+
+update products set name = name;
+
+create index if not exists products_search_vector_idx on products using gin (search_vector);
+
+create index if not exists products_name_trgm_idx on products using gin (name gin_trgm_ops);
@@ -0,0 +1,8 @@
+drop index if exists users_phone_key;
+alter table users add constraint users_phone_key unique (phone);
+
+drop index if exists branches_address_key;
+alter table branches add constraint branches_address_key unique (address);
+
+drop index if exists branches_phone_number_key;
+alter table branches add constraint branches_phone_number_key unique (phone_number);
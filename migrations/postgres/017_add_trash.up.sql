@@ -0,0 +1,8 @@
+alter table users drop constraint if exists users_phone_key;
+create unique index if not exists users_phone_key on users (phone) where deleted_at = 0;
+
+alter table branches drop constraint if exists branches_address_key;
+create unique index if not exists branches_address_key on branches (address) where deleted_at = 0;
+
+alter table branches drop constraint if exists branches_phone_number_key;
+create unique index if not exists branches_phone_number_key on branches (phone_number) where deleted_at = 0;
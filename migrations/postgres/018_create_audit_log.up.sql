@@ -0,0 +1,16 @@
+create table if not exists audit_log (
+    id uuid primary key,
+    actor_id varchar(64),
+    entity varchar(32) not null,
+    entity_id varchar(64) not null,
+    action varchar(16) not null,
+    before jsonb,
+    after jsonb,
+    request_id varchar(64),
+    ip varchar(64),
+    created_at timestamp default now()
+);
+
+create index if not exists audit_log_entity_idx on audit_log (entity, entity_id);
+create index if not exists audit_log_actor_id_idx on audit_log (actor_id);
+create index if not exists audit_log_created_at_idx on audit_log (created_at);
@@ -0,0 +1,15 @@
+drop trigger if exists users_bump_version on users;
+drop trigger if exists categories_bump_version on categories;
+drop trigger if exists products_bump_version on products;
+drop trigger if exists baskets_bump_version on baskets;
+drop trigger if exists basket_products_bump_version on basket_products;
+drop trigger if exists branches_bump_version on branches;
+
+alter table users drop column if exists version;
+alter table categories drop column if exists version;
+alter table products drop column if exists version;
+alter table baskets drop column if exists version;
+alter table basket_products drop column if exists version;
+alter table branches drop column if exists version;
+
+drop function if exists bump_version();
@@ -0,0 +1,21 @@
+-- every change of a row, stock taken by a sale too, gives it a new version
+create or replace function bump_version() returns trigger as $$
+begin
+    new.version := old.version + 1;
+    return new;
+end
+$$ language plpgsql;
+
+alter table users add column if not exists version integer not null default 1;
+alter table categories add column if not exists version integer not null default 1;
+alter table products add column if not exists version integer not null default 1;
+alter table baskets add column if not exists version integer not null default 1;
+alter table basket_products add column if not exists version integer not null default 1;
+alter table branches add column if not exists version integer not null default 1;
+
+create trigger users_bump_version before update on users for each row execute function bump_version();
+create trigger categories_bump_version before update on categories for each row execute function bump_version();
+create trigger products_bump_version before update on products for each row execute function bump_version();
+create trigger baskets_bump_version before update on baskets for each row execute function bump_version();
+create trigger basket_products_bump_version before update on basket_products for each row execute function bump_version();
+create trigger branches_bump_version before update on branches for each row execute function bump_version();
@@ -0,0 +1,13 @@
+-- every change of product stock with its reason, quantity is negative when stock leaves
+create table if not exists stock_movements (
+    id uuid primary key,
+    product_id uuid references products(id) not null,
+    kind varchar(32) not null,
+    quantity numeric(14, 3) not null,
+    balance numeric(14, 3) not null,
+    reason text,
+    created_by varchar(64),
+    created_at timestamp default now()
+);
+
+create index if not exists stock_movements_product_id_idx on stock_movements (product_id, created_at);
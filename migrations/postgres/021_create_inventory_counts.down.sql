@@ -0,0 +1,4 @@
+drop table if exists inventory_count_entries;
+drop table if exists inventory_count_lines;
+drop table if exists inventory_counts;
+drop type if exists inventory_count_status_enum;
@@ -0,0 +1,35 @@
+-- a stock-taking of a branch: expected quantities are taken when it is opened, counters add what they count
+create type inventory_count_status_enum as enum ('open', 'approved', 'cancelled');
+
+create table if not exists inventory_counts (
+    id uuid primary key,
+    branch_id uuid references branches(id) not null,
+    status inventory_count_status_enum not null default 'open',
+    note text,
+    created_by varchar(64),
+    closed_by varchar(64),
+    closed_at timestamp,
+    created_at timestamp default now(),
+    updated_at timestamp
+);
+
+create index if not exists inventory_counts_branch_id_idx on inventory_counts (branch_id, created_at);
+
+create table if not exists inventory_count_lines (
+    inventory_count_id uuid references inventory_counts(id) not null,
+    product_id uuid references products(id) not null,
+    expected_quantity numeric(14, 3) not null,
+    cost bigint not null,
+    primary key (inventory_count_id, product_id)
+);
+
+-- several counters may count the same product on different shelves, their entries add up
+create table if not exists inventory_count_entries (
+    id uuid primary key,
+    inventory_count_id uuid not null,
+    product_id uuid not null,
+    quantity numeric(14, 3) not null,
+    counted_by varchar(64),
+    created_at timestamp default now(),
+    foreign key (inventory_count_id, product_id) references inventory_count_lines (inventory_count_id, product_id)
+);
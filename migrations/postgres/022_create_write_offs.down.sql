@@ -0,0 +1,7 @@
+alter table store
+    drop column if exists loss;
+
+drop table if exists write_off_lines;
+drop table if exists write_offs;
+drop type if exists write_off_status_enum;
+drop type if exists write_off_reason_enum;
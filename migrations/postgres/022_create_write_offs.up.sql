@@ -0,0 +1,30 @@
+-- goods leaving stock without a sale, their cost is kept as a loss of the branch
+create type write_off_reason_enum as enum ('damage', 'expiry', 'theft', 'internal_use');
+create type write_off_status_enum as enum ('pending', 'posted', 'rejected');
+
+create table if not exists write_offs (
+    id uuid primary key,
+    branch_id uuid references branches(id) not null,
+    reason write_off_reason_enum not null,
+    status write_off_status_enum not null default 'pending',
+    note text,
+    created_by varchar(64),
+    closed_by varchar(64),
+    closed_at timestamp,
+    created_at timestamp default now(),
+    updated_at timestamp
+);
+
+create index if not exists write_offs_branch_id_idx on write_offs (branch_id, created_at);
+
+-- cost is the unit cost of the product when the write-off is posted
+create table if not exists write_off_lines (
+    write_off_id uuid references write_offs(id) not null,
+    product_id uuid references products(id) not null,
+    quantity numeric(14, 3) not null,
+    cost bigint,
+    primary key (write_off_id, product_id)
+);
+
+alter table store
+    add column if not exists loss bigint not null default 0;
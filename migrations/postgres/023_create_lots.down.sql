@@ -0,0 +1,9 @@
+drop table if exists lot_movements;
+drop table if exists lots;
+
+alter table income_products
+    drop column if exists lot_number,
+    drop column if exists expiry_date;
+
+alter table incomes
+    drop column if exists posted_at;
@@ -0,0 +1,34 @@
+-- an income posts its lines to stock once, every line becomes a lot of the product
+alter table incomes
+    add column if not exists posted_at timestamp;
+
+alter table income_products
+    add column if not exists lot_number varchar(64),
+    add column if not exists expiry_date date;
+
+-- a lot without an expiry date does not expire, stock received before lots were kept belongs to no lot
+create table if not exists lots (
+    id uuid primary key,
+    product_id uuid references products(id) not null,
+    income_product_id uuid references income_products(id),
+    lot_number varchar(64) not null,
+    expiry_date date,
+    cost bigint not null,
+    quantity numeric(14, 3) not null,
+    remaining numeric(14, 3) not null check (remaining >= 0),
+    created_at timestamp default now()
+);
+
+create index if not exists lots_product_id_idx on lots (product_id, expiry_date);
+
+-- what left a lot and by which sale or write-off, reference_id is the basket or the write-off
+create table if not exists lot_movements (
+    id uuid primary key,
+    lot_id uuid references lots(id) not null,
+    kind varchar(32) not null,
+    quantity numeric(14, 3) not null,
+    reference_id uuid,
+    created_at timestamp default now()
+);
+
+create index if not exists lot_movements_lot_id_idx on lot_movements (lot_id, created_at);
@@ -0,0 +1,5 @@
+alter table basket_products
+    drop column if exists cost;
+
+alter table products
+    drop column if exists average_cost;
@@ -0,0 +1,7 @@
+-- moving average cost of the stock of a product, null until an income is posted for it and original_price stands for it
+alter table products
+    add column if not exists average_cost bigint;
+
+-- cost of goods of a sold line, null for sales made before it was kept
+alter table basket_products
+    add column if not exists cost bigint;
@@ -0,0 +1,2 @@
+drop table if exists backorders;
+drop type if exists backorder_status_enum;
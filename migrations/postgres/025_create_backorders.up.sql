@@ -0,0 +1,21 @@
+-- quantities a customer ordered while they were out of stock, fulfilled oldest first when an income posts stock
+create type backorder_status_enum as enum ('open', 'fulfilled', 'cancelled');
+
+create table if not exists backorders (
+    id uuid primary key,
+    customer_id uuid references users(id) not null,
+    product_id uuid references products(id) not null,
+    basket_id uuid references baskets(id),
+    quantity numeric(14, 3) not null check (quantity > 0),
+    status backorder_status_enum not null default 'open',
+    fulfilled_at timestamp,
+    -- the customer is notified after fulfilment, failed notifications are retried
+    notified_at timestamp,
+    notify_attempts int not null default 0,
+    created_at timestamp default now(),
+    updated_at timestamp
+);
+
+create index if not exists backorders_product_id_idx on backorders (product_id, created_at) where status = 'open';
+create index if not exists backorders_customer_id_idx on backorders (customer_id, created_at);
+create index if not exists backorders_unnotified_idx on backorders (fulfilled_at) where status = 'fulfilled' and notified_at is null;
@@ -0,0 +1 @@
+-- normalized barcodes are kept, every UPC-A barcode is a valid EAN-13 barcode
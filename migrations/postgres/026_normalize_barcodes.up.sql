@@ -0,0 +1,6 @@
+-- UPC-A barcodes are stored as the EAN-13 barcodes they are, with a leading zero;
+-- a UPC-A barcode whose EAN-13 form is already stored is dropped
+delete from product_barcodes u
+    where length(u.barcode) = 12 and exists(select 1 from product_barcodes e where e.barcode = '0' || u.barcode);
+
+update product_barcodes set barcode = '0' || barcode where length(barcode) = 12;
@@ -0,0 +1,11 @@
+drop trigger if exists products_trash_barcodes on products;
+
+drop function if exists trash_product_barcodes();
+
+drop index if exists product_barcodes_barcode_key;
+
+delete from product_barcodes where deleted_at <> 0;
+
+alter table product_barcodes
+    add constraint product_barcodes_barcode_key unique (barcode),
+    drop column if exists deleted_at;
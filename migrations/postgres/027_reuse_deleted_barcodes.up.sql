@@ -0,0 +1,18 @@
+-- barcodes follow their product to the trash and back, the ones of deleted products can be given to other products
+alter table product_barcodes
+    add column if not exists deleted_at integer not null default 0;
+
+update product_barcodes b set deleted_at = p.deleted_at from products p where p.id = b.product_id and p.deleted_at <> 0;
+
+alter table product_barcodes drop constraint if exists product_barcodes_barcode_key;
+create unique index if not exists product_barcodes_barcode_key on product_barcodes (barcode) where deleted_at = 0;
+
+create or replace function trash_product_barcodes() returns trigger as $$
+begin
+    update product_barcodes set deleted_at = new.deleted_at where product_id = new.id;
+    return new;
+end
+$$ language plpgsql;
+
+create trigger products_trash_barcodes after update of deleted_at on products
+    for each row when (old.deleted_at is distinct from new.deleted_at) execute function trash_product_barcodes();
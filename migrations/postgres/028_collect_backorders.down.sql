@@ -0,0 +1,28 @@
+alter table backorders
+    drop column if exists branch_id,
+    drop column if exists price,
+    drop column if exists tax_rate,
+    drop column if exists average_cost,
+    drop column if exists sale_basket_id;
+
+-- values cannot be dropped from an enum, the type is made again without them
+drop index if exists backorders_product_id_idx;
+drop index if exists backorders_unnotified_idx;
+
+alter type backorder_status_enum rename to backorder_status_enum_old;
+
+create type backorder_status_enum as enum ('open', 'fulfilled', 'cancelled');
+
+alter table backorders alter column status drop default;
+
+alter table backorders alter column status type backorder_status_enum using (case status::text
+    when 'collected' then 'fulfilled'
+    when 'released' then 'cancelled'
+    else status::text end)::backorder_status_enum;
+
+alter table backorders alter column status set default 'open';
+
+drop type backorder_status_enum_old;
+
+create index if not exists backorders_product_id_idx on backorders (product_id, created_at) where status = 'open';
+create index if not exists backorders_unnotified_idx on backorders (fulfilled_at) where status = 'fulfilled' and notified_at is null;
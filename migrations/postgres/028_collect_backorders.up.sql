@@ -0,0 +1,22 @@
+-- a fulfilled backorder is held until the customer collects it as a sale or it is released back to stock
+alter type backorder_status_enum add value if not exists 'collected';
+alter type backorder_status_enum add value if not exists 'released';
+
+-- the price is quoted in the selling branch when the customer orders, the average cost is kept when
+-- the stock is held, a collected backorder is sold in a basket of its own
+alter table backorders
+    add column if not exists branch_id uuid references branches(id),
+    add column if not exists price bigint,
+    add column if not exists tax_rate numeric(5, 2),
+    add column if not exists average_cost bigint,
+    add column if not exists sale_basket_id uuid references baskets(id);
+
+update backorders b set branch_id = u.branch_id from users u where u.id = b.customer_id and b.branch_id is null;
+
+update backorders b set
+    price = coalesce((select bpp.price from branch_product_prices bpp where bpp.product_id = p.id and bpp.branch_id = b.branch_id), p.price),
+    tax_rate = coalesce(p.tax_rate, c.tax_rate),
+    average_cost = case when b.status = 'fulfilled' then coalesce(p.average_cost, p.original_price) end
+from products p
+    left join categories c on c.id = p.category_id
+where p.id = b.product_id and b.price is null;
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+type row struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+func TestDiff(t *testing.T) {
+	before, after, err := Diff(row{Name: "milk", Price: 10}, row{Name: "milk", Price: 12})
+	if err != nil {
+		t.Fatalf("error while diffing: %v", err)
+	}
+
+	assert.Equal(t, string(before), `{"price":10}`)
+	assert.Equal(t, string(after), `{"price":12}`)
+
+	before, after, err = Diff(nil, row{Name: "bread"})
+	if err != nil {
+		t.Fatalf("error while diffing: %v", err)
+	}
+
+	assert.Equal(t, before == nil, true)
+	assert.Equal(t, string(after), `{"name":"bread","price":0}`)
+}
+
+func TestMetaFrom(t *testing.T) {
+	meta := Meta{ActorID: "admin", RequestID: "1", IP: "127.0.0.1"}
+
+	assert.Equal(t, MetaFrom(WithMeta(context.Background(), meta)), meta)
+	assert.Equal(t, MetaFrom(context.Background()), Meta{})
+}
@@ -26,6 +26,63 @@ func ValidateCarYear(year int) error {
 	return nil
 }
 
+// ValidateCurrency checks that code looks like an ISO 4217 code, three upper case letters.
+func ValidateCurrency(code string) error {
+	if len(code) != 3 {
+		return errors.New("currency should be a 3 letter ISO 4217 code")
+	}
+
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return errors.New("currency should be a 3 letter ISO 4217 code")
+		}
+	}
+
+	return nil
+}
+
+// ValidateBarcode checks length and GS1 check digit of EAN-8, UPC-A and EAN-13 barcodes.
+func ValidateBarcode(code string) error {
+	if len(code) != 8 && len(code) != 12 && len(code) != 13 {
+		return errors.New("barcode should have 8, 12 or 13 digits")
+	}
+
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		if code[i] < '0' || code[i] > '9' {
+			return errors.New("barcode should contain only digits")
+		}
+
+		digit := int(code[i] - '0')
+		// digits are weighted 3 and 1 alternately starting next to the check digit
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	last := code[len(code)-1]
+	if last < '0' || last > '9' {
+		return errors.New("barcode should contain only digits")
+	}
+
+	if int(last-'0') != (10-sum%10)%10 {
+		return errors.New("barcode check digit is not correct")
+	}
+
+	return nil
+}
+
+// NormalizeBarcode turns a UPC-A barcode into the EAN-13 barcode it is, a leading zero,
+// so that both forms of a code are stored and looked up as one. Other barcodes are kept.
+func NormalizeBarcode(code string) string {
+	if len(code) == 12 {
+		return "0" + code
+	}
+
+	return code
+}
+
 func ValidatePassword(password string) error {
 	if len(password) < 6 {
 		return errors.New("password length should be more than 6")
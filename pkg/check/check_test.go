@@ -0,0 +1,43 @@
+package check
+
+import (
+	"test/pkg/helper"
+	"testing"
+)
+
+func TestValidateBarcode(t *testing.T) {
+	for _, code := range []string{"4006381333931", "036000291452", "96385074"} {
+		if err := ValidateBarcode(code); err != nil {
+			t.Errorf("expected %s to be valid, got: %v", code, err)
+		}
+	}
+
+	for _, code := range []string{"4006381333932", "036000291453", "9638507", "40063813339a1", ""} {
+		if err := ValidateBarcode(code); err == nil {
+			t.Errorf("expected %s to be invalid", code)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		if code := helper.GenerateBarcode(); ValidateBarcode(code) != nil {
+			t.Errorf("expected generated %s to be valid", code)
+		}
+	}
+}
+
+func TestNormalizeBarcode(t *testing.T) {
+	for code, normalized := range map[string]string{
+		"036000291452":  "0036000291452",
+		"0036000291452": "0036000291452",
+		"4006381333931": "4006381333931",
+		"96385074":      "96385074",
+	} {
+		if got := NormalizeBarcode(code); got != normalized {
+			t.Errorf("expected %s to be normalized to %s, got %s", code, normalized, got)
+		}
+
+		if err := ValidateBarcode(NormalizeBarcode(code)); err != nil {
+			t.Errorf("expected normalized %s to be valid, got: %v", code, err)
+		}
+	}
+}
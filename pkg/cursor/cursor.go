@@ -0,0 +1,38 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalid = errors.New("invalid cursor")
+
+// Cursor points to a row of a keyset paginated list by its sort value and unique id.
+// Backward cursors select rows before the row, forward ones rows after it.
+type Cursor struct {
+	Value    string `json:"v"`
+	ID       string `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// Encode returns the cursor as an opaque url safe string.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func Decode(value string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalid
+	}
+
+	c := Cursor{}
+	if err = json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return Cursor{}, ErrInvalid
+	}
+
+	return c, nil
+}
@@ -0,0 +1,24 @@
+package cursor
+
+import (
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestCursor_Decode(t *testing.T) {
+	c := Cursor{Value: "2024-02-01T10:11:12.123456Z", ID: "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677", Backward: true}
+
+	decoded, err := Decode(c.Encode())
+	if err != nil {
+		t.Fatalf("error while decoding cursor: %v", err)
+	}
+
+	assert.Equal(t, decoded, c)
+
+	for _, value := range []string{"", "not a cursor", Cursor{Value: "1"}.Encode()} {
+		if _, err = Decode(value); err != ErrInvalid {
+			t.Errorf("expected invalid cursor error for %q, got: %v", value, err)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package helper
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+// GenerateBarcode returns a random EAN-13 barcode with a correct check digit.
+func GenerateBarcode() string {
+	digits := make([]byte, 0, 13)
+	sum := 0
+	for i := 0; i < 12; i++ {
+		digit := rand.Intn(10)
+		if i%2 == 1 {
+			sum += digit * 3
+		} else {
+			sum += digit
+		}
+		digits = append(digits, byte('0'+digit))
+	}
+
+	return string(digits) + strconv.Itoa((10-sum%10)%10)
+}
@@ -0,0 +1,168 @@
+package measure
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Scale is the number of Quantity units in one whole unit of measure, quantities keep 3 decimal places.
+const Scale = 1000
+
+// Quantity is an amount of goods in thousandths of its unit of measure (grams of kg, millilitres of litre).
+// It reads and writes JSON as a decimal number, 1.25 kg is 1.25.
+type Quantity int64
+
+// Whole returns the quantity of n whole units.
+func Whole(n int) Quantity {
+	return Quantity(n) * Scale
+}
+
+// Parse reads a decimal with at most 3 fractional digits.
+func Parse(value string) (Quantity, error) {
+	value = strings.TrimSpace(value)
+
+	negative := strings.HasPrefix(value, "-")
+	integer, fraction, _ := strings.Cut(strings.TrimPrefix(value, "-"), ".")
+
+	if len(fraction) > 3 {
+		// numeric columns may pad the fraction with zeros
+		if strings.Trim(fraction[3:], "0") != "" {
+			return 0, fmt.Errorf("quantity %q has more than 3 decimal places", value)
+		}
+		fraction = fraction[:3]
+	}
+
+	if integer == "" {
+		integer = "0"
+	}
+
+	whole, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse %q as quantity: %w", value, err)
+	}
+
+	thousandths := int64(0)
+	if fraction != "" {
+		if thousandths, err = strconv.ParseInt(fraction+strings.Repeat("0", 3-len(fraction)), 10, 64); err != nil {
+			return 0, fmt.Errorf("cannot parse %q as quantity: %w", value, err)
+		}
+	}
+
+	quantity := Quantity(whole*Scale + thousandths)
+	if negative {
+		quantity = -quantity
+	}
+
+	return quantity, nil
+}
+
+func (q Quantity) String() string {
+	sign := ""
+	if q < 0 {
+		sign, q = "-", -q
+	}
+
+	if q%Scale == 0 {
+		return fmt.Sprintf("%s%d", sign, q/Scale)
+	}
+
+	return sign + strings.TrimRight(fmt.Sprintf("%d.%03d", q/Scale, q%Scale), "0")
+}
+
+// IsWhole reports whether the quantity has no fractional part.
+func (q Quantity) IsWhole() bool {
+	return q%Scale == 0
+}
+
+// Times returns perUnit multiplied by the quantity, rounded half away from zero.
+// It prices a quantity: Times(price of one kg) is the price of q kg.
+func (q Quantity) Times(perUnit int64) int64 {
+	product := new(big.Int).Mul(big.NewInt(int64(q)), big.NewInt(perUnit))
+
+	return roundDiv(product, Scale)
+}
+
+// Mul returns the quantity multiplied by factor, rounded half away from zero to thousandths.
+func (q Quantity) Mul(factor Quantity) Quantity {
+	return Quantity(q.Times(int64(factor)))
+}
+
+// Div returns the quantity divided by divisor, rounded half away from zero to thousandths.
+func (q Quantity) Div(divisor Quantity) Quantity {
+	dividend := new(big.Int).Mul(big.NewInt(int64(q)), big.NewInt(Scale))
+
+	return Quantity(roundDiv(dividend, int64(divisor)))
+}
+
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	quantity, err := Parse(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+
+	*q = quantity
+
+	return nil
+}
+
+// Scan reads integer and numeric columns exactly.
+func (q *Quantity) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*q = 0
+	case int64:
+		*q = Whole(int(value))
+	case []byte:
+		return q.parse(string(value))
+	case string:
+		return q.parse(value)
+	default:
+		return fmt.Errorf("cannot scan %T into measure.Quantity", src)
+	}
+
+	return nil
+}
+
+func (q Quantity) Value() (driver.Value, error) {
+	return q.String(), nil
+}
+
+// NumericValue lets pgx write the quantity into numeric columns as a decimal, not as thousandths.
+func (q Quantity) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(int64(q)), Exp: -3, Valid: true}, nil
+}
+
+func (q *Quantity) parse(value string) error {
+	quantity, err := Parse(value)
+	if err != nil {
+		return err
+	}
+
+	*q = quantity
+
+	return nil
+}
+
+func roundDiv(dividend *big.Int, divisor int64) int64 {
+	quotient, remainder := new(big.Int).QuoRem(dividend, big.NewInt(divisor), new(big.Int))
+
+	// remainder has the sign of the dividend, round away from zero when it is at least half of divisor
+	if new(big.Int).Abs(new(big.Int).Mul(remainder, big.NewInt(2))).Cmp(new(big.Int).Abs(big.NewInt(divisor))) >= 0 {
+		if (dividend.Sign() < 0) != (divisor < 0) {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient.Int64()
+}
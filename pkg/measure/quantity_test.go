@@ -0,0 +1,58 @@
+package measure
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]Quantity{
+		"1.25":    1250,
+		"2":       2000,
+		"0.005":   5,
+		"-0.5":    -500,
+		"12.5000": 12500,
+		".75":     750,
+	}
+
+	for value, expected := range cases {
+		quantity, err := Parse(value)
+		if err != nil {
+			t.Fatalf("error while parsing %s: %v", value, err)
+		}
+
+		assert.Equal(t, quantity, expected)
+	}
+
+	if _, err := Parse("1.2345"); err == nil {
+		t.Errorf("expected error while parsing more than 3 decimal places")
+	}
+}
+
+func TestQuantity_JSON(t *testing.T) {
+	request := map[string]Quantity{}
+	if err := json.Unmarshal([]byte(`{"cheese": 1.25, "bread": 2}`), &request); err != nil {
+		t.Fatalf("error while unmarshalling quantities: %v", err)
+	}
+
+	assert.Equal(t, request["cheese"], Quantity(1250))
+	assert.Equal(t, request["bread"], Whole(2))
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("error while marshalling quantities: %v", err)
+	}
+
+	assert.Equal(t, string(data), `{"bread":2,"cheese":1.25}`)
+}
+
+func TestQuantity_Mul(t *testing.T) {
+	// 3 boxes of 12 pieces
+	assert.Equal(t, Whole(3).Mul(Whole(12)), Whole(36))
+	assert.Equal(t, Quantity(1250).Mul(Quantity(333)), Quantity(416))
+	assert.Equal(t, Whole(36).Div(Whole(12)), Whole(3))
+	assert.Equal(t, Quantity(1250).Times(4999), int64(6249))
+	assert.Equal(t, Quantity(-1250).Times(4999), int64(-6249))
+}
@@ -0,0 +1,121 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"test/pkg/measure"
+)
+
+// Amount is a sum of money in minor units of its currency (tiyin, cents).
+type Amount int64
+
+// Money is an amount together with its ISO 4217 currency code.
+type Money struct {
+	Amount   Amount `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+var ErrCurrencyMismatch = errors.New("currencies of amounts do not match")
+
+func New(amount Amount, currency string) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	return New(m.Amount+other.Amount, m.Currency), nil
+}
+
+// Convert returns the money in currency, rate is the price of one unit of m.Currency in currency.
+func (m Money) Convert(rate float64, currency string) Money {
+	if m.Currency == currency {
+		return m
+	}
+
+	return New(Amount(math.Round(float64(m.Amount)*rate)), currency)
+}
+
+// Mul returns the amount for the given quantity.
+func (a Amount) Mul(quantity int) Amount {
+	return a * Amount(quantity)
+}
+
+// MulQuantity returns the amount for a quantity that may be fractional, rounded half away from zero.
+func (a Amount) MulQuantity(quantity measure.Quantity) Amount {
+	return Amount(quantity.Times(int64(a)))
+}
+
+// DivQuantity returns the amount per one unit when the amount is paid for quantity, rounded half away from zero.
+func (a Amount) DivQuantity(quantity measure.Quantity) Amount {
+	return Amount(measure.Quantity(a).Div(quantity))
+}
+
+// Percent returns rate percent of the amount rounded half away from zero.
+func (a Amount) Percent(rate float64) Amount {
+	return Amount(math.Round(float64(a) * rate / 100))
+}
+
+func Sum(amounts ...Amount) Amount {
+	var total Amount
+	for _, amount := range amounts {
+		total += amount
+	}
+
+	return total
+}
+
+// Scan reads integer and numeric columns without going through floats,
+// a fractional part of numeric values is rounded half away from zero.
+func (a *Amount) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*a = 0
+	case int64:
+		*a = Amount(value)
+	case int32:
+		*a = Amount(value)
+	case []byte:
+		return a.parse(string(value))
+	case string:
+		return a.parse(value)
+	default:
+		return fmt.Errorf("cannot scan %T into money.Amount", src)
+	}
+
+	return nil
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	return int64(a), nil
+}
+
+func (a *Amount) parse(value string) error {
+	integer, fraction, _ := strings.Cut(strings.TrimSpace(value), ".")
+
+	amount, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return fmt.Errorf("cannot parse %q as money.Amount: %w", value, err)
+	}
+
+	if fraction != "" && fraction[0] >= '5' {
+		if strings.HasPrefix(integer, "-") {
+			amount--
+		} else {
+			amount++
+		}
+	}
+
+	*a = Amount(amount)
+
+	return nil
+}
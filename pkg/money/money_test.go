@@ -0,0 +1,75 @@
+package money
+
+import (
+	"test/pkg/measure"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestAmount_Scan(t *testing.T) {
+	cases := map[string]struct {
+		src      interface{}
+		expected Amount
+	}{
+		"bigint":           {src: int64(9007199254740993), expected: 9007199254740993},
+		"numeric":          {src: "12345678901234567", expected: 12345678901234567},
+		"numeric fraction": {src: []byte("100.50"), expected: 101},
+		"numeric negative": {src: "-100.50", expected: -101},
+		"numeric down":     {src: "100.49", expected: 100},
+		"null":             {src: nil, expected: 0},
+	}
+
+	for name, c := range cases {
+		var amount Amount
+		if err := amount.Scan(c.src); err != nil {
+			t.Fatalf("%s: error while scanning amount: %v", name, err)
+		}
+
+		assert.Equal(t, amount, c.expected)
+	}
+
+	var amount Amount
+	if err := amount.Scan(float64(1.5)); err == nil {
+		t.Errorf("expected error while scanning float into amount")
+	}
+}
+
+func TestAmount_LargeBasketTotal(t *testing.T) {
+	var (
+		lines    = make([]Amount, 0, 100000)
+		price    = Amount(1999)
+		quantity = 3
+	)
+
+	for i := 0; i < 100000; i++ {
+		lines = append(lines, price.Mul(quantity))
+	}
+
+	assert.Equal(t, Sum(lines...), Amount(599700000))
+	assert.Equal(t, Sum(lines...).Percent(12), Amount(71964000))
+}
+
+func TestMoney_Add(t *testing.T) {
+	total, err := New(1050, "UZS").Add(New(2050, "UZS"))
+	if err != nil {
+		t.Fatalf("error while adding money: %v", err)
+	}
+
+	assert.Equal(t, total, New(3100, "UZS"))
+
+	if _, err = New(1050, "UZS").Add(New(100, "USD")); err != ErrCurrencyMismatch {
+		t.Errorf("expected currency mismatch error, got: %v", err)
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	assert.Equal(t, New(1050, "USD").Convert(12650.5, "UZS"), New(13283025, "UZS"))
+	assert.Equal(t, New(1050, "UZS").Convert(12650.5, "UZS"), New(1050, "UZS"))
+}
+
+func TestAmount_Quantity(t *testing.T) {
+	assert.Equal(t, Amount(1999).MulQuantity(measure.Quantity(1250)), Amount(2499))
+	assert.Equal(t, Amount(12000).DivQuantity(measure.Whole(12)), Amount(1000))
+	assert.Equal(t, Amount(1000).DivQuantity(measure.Whole(3)), Amount(333))
+}
@@ -0,0 +1,93 @@
+// Package sheet reads and writes tables of strings as CSV and XLSX files.
+package sheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Formats of the files.
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown file format, csv and xlsx are supported")
+
+// FormatOf returns the format of a file by its extension.
+func FormatOf(fileName string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))
+}
+
+// ContentType returns the media type of files in the format.
+func ContentType(format string) string {
+	if format == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv"
+}
+
+// Read returns the rows of a file, of its first sheet for XLSX.
+func Read(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case CSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		return reader.ReadAll()
+	case XLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return file.GetRows(file.GetSheetName(0))
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// Write writes the rows as a file in the format.
+func Write(w io.Writer, format string, rows [][]string) error {
+	switch format {
+	case CSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+
+		return writer.Error()
+	case XLSX:
+		file := excelize.NewFile()
+		defer file.Close()
+
+		sheet := file.GetSheetName(0)
+		for i, row := range rows {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+
+			values := make([]interface{}, len(row))
+			for j, value := range row {
+				values[j] = value
+			}
+
+			if err = file.SetSheetRow(sheet, cell, &values); err != nil {
+				return err
+			}
+		}
+
+		return file.Write(w)
+	}
+
+	return ErrUnknownFormat
+}
@@ -0,0 +1,37 @@
+package sheet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestWriteRead(t *testing.T) {
+	rows := [][]string{
+		{"name", "sku", "price"},
+		{"milk, 1l", "MLK-1", "12000"},
+		{"bread", "", "4000"},
+	}
+
+	for _, format := range []string{CSV, XLSX} {
+		var file bytes.Buffer
+		if err := Write(&file, format, rows); err != nil {
+			t.Fatalf("error while writing %s: %v", format, err)
+		}
+
+		read, err := Read(&file, format)
+		if err != nil {
+			t.Fatalf("error while reading %s: %v", format, err)
+		}
+
+		assert.Equal(t, len(read), len(rows))
+		assert.Equal(t, read[1], rows[1])
+		assert.Equal(t, read[2][0], "bread")
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	_, err := Read(bytes.NewReader(nil), FormatOf("products.ods"))
+	assert.Equal(t, err, ErrUnknownFormat)
+}
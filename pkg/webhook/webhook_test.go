@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPost(t *testing.T) {
+	var signature, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		signature, body = r.Header.Get(SignatureHeader), string(data)
+
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	if err := Post(context.Background(), server.Client(), server.URL, "secret", map[string]string{"event": "ping"}); err != nil {
+		t.Fatalf("expected webhook to be sent, got: %v", err)
+	}
+
+	if body != `{"event":"ping"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	if signature != Sign("secret", []byte(body)) {
+		t.Errorf("unexpected signature %s", signature)
+	}
+
+	if err := Post(context.Background(), server.Client(), server.URL, "", nil); err != nil || signature != "" {
+		t.Errorf("expected unsigned webhook, got signature %q and error %v", signature, err)
+	}
+
+	if err := Post(context.Background(), server.Client(), server.URL+"/down", "", nil); err == nil {
+		t.Error("expected error when the receiver is down")
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"test/api/models"
+	"test/pkg/audit"
+	"test/pkg/logger"
+	"test/storage"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type auditService struct {
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewAuditService(storage storage.IStorage, log logger.ILogger) auditService {
+	return auditService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+func (a auditService) GetList(ctx context.Context, request models.GetListRequest) (models.AuditLogsResponse, error) {
+	for _, date := range []string{request.CreatedFrom, request.CreatedTo} {
+		if date == "" {
+			continue
+		}
+
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return models.AuditLogsResponse{}, fmt.Errorf("date %s should be in %s format", date, time.DateOnly)
+		}
+	}
+
+	auditLogs, err := a.storage.Audit().GetList(ctx, request)
+	if err != nil {
+		a.log.Error("error in service layer while getting audit log", logger.Error(err))
+
+		return models.AuditLogsResponse{}, err
+	}
+
+	return auditLogs, nil
+}
+
+// loader adapts a GetByID of storage to load rows for the audit log.
+func loader[T any](get func(context.Context, models.PrimaryKey) (T, error)) func(context.Context, string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		return get(ctx, models.PrimaryKey{ID: id})
+	}
+}
+
+// audited runs change and writes its audit log entry in one transaction.
+// load reads the row by id before and after the change so that the entry keeps what changed,
+// change returns the id of the row, which is only known after creating it.
+func audited(ctx context.Context, storage storage.IStorage, entity, action, id string,
+	load func(context.Context, string) (interface{}, error), change func(ctx context.Context) (string, error)) (string, error) {
+	err := storage.WithTx(ctx, func(ctx context.Context) error {
+		var (
+			before, after interface{}
+			err           error
+		)
+
+		if action != models.AuditCreate {
+			if before, err = loadAudited(ctx, load, id); err != nil {
+				return err
+			}
+		}
+
+		if id, err = change(ctx); err != nil {
+			return err
+		}
+
+		if action != models.AuditDelete {
+			if after, err = loadAudited(ctx, load, id); err != nil {
+				return err
+			}
+		}
+
+		return recordAudit(ctx, storage, entity, action, id, before, after)
+	})
+
+	return id, err
+}
+
+// loadAudited loads the row, a row that can not be read, like an admin user, is audited without its state.
+func loadAudited(ctx context.Context, load func(context.Context, string) (interface{}, error), id string) (interface{}, error) {
+	row, err := load(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return row, err
+}
+
+// recordAudit writes an audit log entry of a change to the entity, call it with the context of the change's transaction.
+func recordAudit(ctx context.Context, storage storage.IStorage, entity, action, id string, before, after interface{}) error {
+	beforeDiff, afterDiff, err := audit.Diff(before, after)
+	if err != nil {
+		return err
+	}
+
+	meta := audit.MetaFrom(ctx)
+
+	return storage.Audit().Create(ctx, models.CreateAuditLog{
+		ActorID:   meta.ActorID,
+		Entity:    entity,
+		EntityID:  id,
+		Action:    action,
+		Before:    beforeDiff,
+		After:     afterDiff,
+		RequestID: meta.RequestID,
+		IP:        meta.IP,
+	})
+}
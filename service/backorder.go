@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/webhook"
+	"test/storage"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrBackorderClosed  = errors.New("backorder is not open")
+	ErrBackorderNotHeld = errors.New("backorder is not held for the customer")
+	ErrNotEnoughCash    = errors.New("not enough customer cash")
+)
+
+const (
+	// backorderNotifyBatch is the number of notifications sent in one run of the notifier.
+	backorderNotifyBatch = 100
+	// backorderNotifyAttempts is how many times a notification is tried before it is given up.
+	backorderNotifyAttempts = 5
+)
+
+type backorderService struct {
+	cfg     config.Config
+	storage storage.IStorage
+	log     logger.ILogger
+	client  *http.Client
+}
+
+func NewBackorderService(cfg config.Config, storage storage.IStorage, log logger.ILogger) backorderService {
+	return backorderService{
+		cfg:     cfg,
+		storage: storage,
+		log:     log,
+		client:  &http.Client{Timeout: time.Second * 10},
+	}
+}
+
+func (b backorderService) Get(ctx context.Context, key models.PrimaryKey) (models.Backorder, error) {
+	backorder, err := b.storage.Backorder().GetByID(ctx, key)
+	if err != nil {
+		b.log.Error("error in service layer while getting backorder by id", logger.Error(err))
+
+		return models.Backorder{}, err
+	}
+
+	return backorder, nil
+}
+
+func (b backorderService) GetList(ctx context.Context, request models.GetListRequest) (models.BackordersResponse, error) {
+	backorders, err := b.storage.Backorder().GetList(ctx, request)
+	if err != nil {
+		b.log.Error("error in service layer while getting backorders", logger.Error(err))
+
+		return models.BackordersResponse{}, err
+	}
+
+	return backorders, nil
+}
+
+// Cancel cancels an open backorder, fulfilled ones are held for the customer until they are collected or released.
+func (b backorderService) Cancel(ctx context.Context, key models.PrimaryKey) (models.Backorder, error) {
+	_, err := audited(ctx, b.storage, models.EntityBackorder, models.AuditUpdate, key.ID, loader(b.storage.Backorder().GetByID), func(ctx context.Context) (string, error) {
+		if err := b.storage.Backorder().Cancel(ctx, key.ID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return "", ErrBackorderClosed
+			}
+
+			return "", err
+		}
+
+		return key.ID, nil
+	})
+	if err != nil {
+		b.log.Error("error in service layer while cancelling backorder", logger.Error(err))
+
+		return models.Backorder{}, err
+	}
+
+	return b.Get(ctx, key)
+}
+
+// Collect sells a held backorder to its customer at the price quoted when it was ordered. The sale is a basket
+// of its own, paid from the customer cash, and is costed with the lots the backorder took like any other sale.
+func (b backorderService) Collect(ctx context.Context, key models.PrimaryKey) (models.Backorder, error) {
+	backorder, err := b.storage.Backorder().GetByID(ctx, key)
+	if err != nil {
+		b.log.Error("error in service layer while getting backorder by id", logger.Error(err))
+
+		return models.Backorder{}, err
+	}
+
+	if backorder.Status != models.BackorderFulfilled {
+		return models.Backorder{}, ErrBackorderNotHeld
+	}
+
+	customer, err := b.storage.User().GetByID(ctx, models.PrimaryKey{ID: backorder.CustomerID})
+	if err != nil {
+		b.log.Error("error in service layer while getting user by id", logger.Error(err))
+
+		return models.Backorder{}, err
+	}
+
+	branch, err := b.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: backorder.BranchID})
+	if err != nil {
+		b.log.Error("error in service layer while getting branch by id", logger.Error(err))
+
+		return models.Backorder{}, err
+	}
+
+	product, err := b.storage.Product().GetByID(ctx, models.PrimaryKey{ID: backorder.ProductID})
+	if err != nil {
+		b.log.Error("error in service layer while getting product by id", logger.Error(err))
+
+		return models.Backorder{}, err
+	}
+
+	taxRate := b.cfg.DefaultTaxRate
+	if backorder.TaxRate != nil {
+		taxRate = *backorder.TaxRate
+	}
+
+	line := models.CheckProduct{
+		ID:       backorder.ProductID,
+		Name:     backorder.ProductName,
+		Price:    backorder.Price,
+		Quantity: backorder.Quantity,
+		Unit:     product.Unit,
+		TaxRate:  taxRate,
+	}
+	line.NetSum, line.TaxSum, line.GrossSum = calculateTax(backorder.Price.MulQuantity(backorder.Quantity), taxRate, b.cfg.PricesIncludeTax)
+
+	if customer.Cash < line.GrossSum {
+		return models.Backorder{}, ErrNotEnoughCash
+	}
+
+	pointsEarned := int(line.GrossSum.Percent(b.cfg.LoyaltyPercent))
+	err = b.storage.WithTx(ctx, func(ctx context.Context) error {
+		basketID, err := audited(ctx, b.storage, models.EntityBasket, models.AuditCreate, "", loader(b.storage.Basket().GetByID), func(ctx context.Context) (string, error) {
+			return b.storage.Basket().Create(ctx, models.CreateBasket{CustomerID: customer.ID, TotalSum: line.GrossSum})
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = b.storage.Backorder().Collect(ctx, backorder.ID, basketID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrBackorderNotHeld
+			}
+
+			return err
+		}
+
+		if err = b.storage.User().UpdateCustomerCash(ctx, customer.ID, line.GrossSum); err != nil {
+			return err
+		}
+
+		// the goods left stock and their lots when the backorder was fulfilled
+		takes, err := b.storage.Lot().GetTakes(ctx, models.StockMovementBackorder, backorder.ID)
+		if err != nil {
+			return err
+		}
+
+		line.Cost = saleCost(b.cfg.CostMethod, takes, backorder.Quantity, backorder.AverageCost)
+
+		if err = b.storage.BasketProduct().AddProducts(ctx, basketID, []models.CheckProduct{line}); err != nil {
+			return err
+		}
+
+		if err = b.storage.Basket().UpdateSums(ctx, models.UpdateBasketSums{
+			ID:           basketID,
+			NetSum:       line.NetSum,
+			TaxSum:       line.TaxSum,
+			TotalSum:     line.GrossSum,
+			TaxInclusive: b.cfg.PricesIncludeTax,
+			Currency:     branch.Currency,
+		}); err != nil {
+			return err
+		}
+
+		if err = b.storage.Store().AddProfit(ctx, line.NetSum-line.Cost, backorder.BranchID); err != nil {
+			return err
+		}
+
+		if pointsEarned > 0 {
+			if err = b.storage.Loyalty().Earn(ctx, models.EarnLoyaltyPoints{
+				UserID:   customer.ID,
+				BasketID: basketID,
+				Points:   pointsEarned,
+				TTLDays:  b.cfg.LoyaltyPointsTTLDays,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return recordAudit(ctx, b.storage, models.EntityBackorder, models.AuditUpdate, backorder.ID,
+			map[string]string{"status": backorder.Status}, map[string]string{"status": models.BackorderCollected, "sale_basket_id": basketID})
+	})
+	if err != nil {
+		b.log.Error("error in service layer while collecting backorder", logger.Error(err))
+
+		return models.Backorder{}, err
+	}
+
+	return b.Get(ctx, key)
+}
+
+// Release gives up a held backorder, its quantity goes back to stock and to the lots it was taken from
+// and fulfills the open backorders of the product waiting for it.
+func (b backorderService) Release(ctx context.Context, key models.PrimaryKey) (models.Backorder, error) {
+	_, err := audited(ctx, b.storage, models.EntityBackorder, models.AuditUpdate, key.ID, loader(b.storage.Backorder().GetByID), func(ctx context.Context) (string, error) {
+		if err := b.storage.Backorder().Release(ctx, key.ID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return "", ErrBackorderNotHeld
+			}
+
+			return "", err
+		}
+
+		backorder, err := b.storage.Backorder().GetByID(ctx, key)
+		if err != nil {
+			return "", err
+		}
+
+		if _, err = moveStock(ctx, b.storage, backorder.ProductID, backorder.Quantity, models.StockMovementBackorder,
+			fmt.Sprintf("backorder %s released", backorder.ID)); err != nil {
+			return "", err
+		}
+
+		takes, err := b.storage.Lot().GetTakes(ctx, models.StockMovementBackorder, backorder.ID)
+		if err != nil {
+			return "", err
+		}
+
+		// a take is given back as a take of the negative quantity
+		for _, take := range takes {
+			take.Quantity = -take.Quantity
+			if err = b.storage.Lot().Take(ctx, take); err != nil {
+				return "", err
+			}
+		}
+
+		if _, err = fulfillBackorders(ctx, b.storage, backorder.ProductID); err != nil {
+			return "", err
+		}
+
+		return key.ID, nil
+	})
+	if err != nil {
+		b.log.Error("error in service layer while releasing backorder", logger.Error(err))
+
+		return models.Backorder{}, err
+	}
+
+	return b.Get(ctx, key)
+}
+
+// Notify tells the customers of fulfilled backorders through the webhook. A failed notification is
+// tried again on the next run until it is given up, nothing is sent while no webhook is configured.
+func (b backorderService) Notify(ctx context.Context) error {
+	if b.cfg.BackorderWebhookURL == "" {
+		return nil
+	}
+
+	backorders, err := b.storage.Backorder().GetUnnotified(ctx, backorderNotifyBatch, backorderNotifyAttempts)
+	if err != nil {
+		b.log.Error("error in service layer while getting unnotified backorders", logger.Error(err))
+
+		return err
+	}
+
+	for _, backorder := range backorders {
+		customer, err := b.storage.User().GetByID(ctx, models.PrimaryKey{ID: backorder.CustomerID})
+		if err != nil {
+			b.log.Error("error in service layer while getting user by id", logger.Error(err))
+
+			return err
+		}
+
+		err = webhook.Post(ctx, b.client, b.cfg.BackorderWebhookURL, b.cfg.BackorderWebhookSecret, backorderNotification(backorder, customer))
+		if err != nil {
+			b.log.Error("error in service layer while notifying about backorder", logger.String("backorder_id", backorder.ID), logger.Error(err))
+		}
+
+		if err = b.storage.Backorder().MarkNotified(ctx, backorder.ID, err == nil); err != nil {
+			b.log.Error("error in service layer while marking backorder notified", logger.Error(err))
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunNotifier sends notifications of fulfilled backorders every interval until ctx is done,
+// a non-positive interval turns it off.
+func (b backorderService) RunNotifier(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		b.log.Info("backorder notifier is off")
+
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		b.Notify(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// createBackorders backorders the products the sale in the basket had not enough of for the customer.
+func createBackorders(ctx context.Context, store storage.IStorage, basketID string, requests []models.CreateBackorder) ([]models.Backorder, error) {
+	backorders := make([]models.Backorder, 0, len(requests))
+	for _, request := range requests {
+		request.BasketID = basketID
+		id, err := audited(ctx, store, models.EntityBackorder, models.AuditCreate, "", loader(store.Backorder().GetByID), func(ctx context.Context) (string, error) {
+			return store.Backorder().Create(ctx, request)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		backorder, err := store.Backorder().GetByID(ctx, models.PrimaryKey{ID: id})
+		if err != nil {
+			return nil, err
+		}
+
+		backorders = append(backorders, backorder)
+	}
+
+	return backorders, nil
+}
+
+// fulfillBackorders fulfills the open backorders of the product oldest first while its sellable stock covers them,
+// a fulfilled quantity leaves stock and its lots and is held for the customer. A backorder is not fulfilled in part
+// and the ones after a backorder that does not fit wait for the next income.
+func fulfillBackorders(ctx context.Context, store storage.IStorage, productID string) ([]models.Backorder, error) {
+	backorders, err := store.Backorder().LockOpen(ctx, productID)
+	if err != nil || len(backorders) == 0 {
+		return nil, err
+	}
+
+	product, err := store.Product().GetByID(ctx, models.PrimaryKey{ID: productID})
+	if err != nil {
+		return nil, err
+	}
+
+	expired, err := store.Lot().ExpiredRemaining(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfilled := []models.Backorder{}
+	for _, backorder := range fillableBackorders(backorders, product.Quantity-expired) {
+		if _, err = moveStock(ctx, store, productID, -backorder.Quantity, models.StockMovementBackorder, fmt.Sprintf("backorder %s", backorder.ID)); err != nil {
+			return nil, err
+		}
+
+		if _, err = takeLots(ctx, store, productID, backorder.Quantity, false, models.StockMovementBackorder, backorder.ID); err != nil {
+			return nil, err
+		}
+
+		if err = store.Backorder().Fulfill(ctx, backorder.ID); err != nil {
+			return nil, err
+		}
+
+		if err = recordAudit(ctx, store, models.EntityBackorder, models.AuditUpdate, backorder.ID,
+			map[string]string{"status": backorder.Status}, map[string]string{"status": models.BackorderFulfilled}); err != nil {
+			return nil, err
+		}
+
+		fulfilled = append(fulfilled, backorder)
+	}
+
+	return fulfilled, nil
+}
+
+// fillableBackorders takes the backorders in their order until one needs more than is available.
+func fillableBackorders(backorders []models.Backorder, available measure.Quantity) []models.Backorder {
+	fillable := []models.Backorder{}
+	for _, backorder := range backorders {
+		if backorder.Quantity > available {
+			break
+		}
+
+		fillable = append(fillable, backorder)
+		available -= backorder.Quantity
+	}
+
+	return fillable
+}
+
+func backorderNotification(backorder models.Backorder, customer models.User) models.BackorderNotification {
+	return models.BackorderNotification{
+		Event:         "backorder.fulfilled",
+		BackorderID:   backorder.ID,
+		CustomerID:    customer.ID,
+		CustomerPhone: customer.Phone,
+		ProductID:     backorder.ProductID,
+		ProductName:   backorder.ProductName,
+		Quantity:      backorder.Quantity,
+		FulfilledAt:   backorder.FulfilledAt,
+	}
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"test/api/models"
+	"test/pkg/measure"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestFillableBackorders(t *testing.T) {
+	backorders := []models.Backorder{
+		{ID: "first", Quantity: measure.Whole(2)},
+		{ID: "second", Quantity: measure.Whole(5)},
+		{ID: "third", Quantity: measure.Whole(1)},
+	}
+
+	assert.Equal(t, len(fillableBackorders(backorders, measure.Whole(1))), 0)
+	assert.Equal(t, len(fillableBackorders(backorders, measure.Whole(7))), 2)
+	assert.Equal(t, len(fillableBackorders(backorders, measure.Whole(8))), 3)
+
+	// the oldest backorders go first, a later one does not jump ahead of one that does not fit
+	fillable := fillableBackorders(backorders, measure.Whole(4))
+	assert.Equal(t, len(fillable), 1)
+	assert.Equal(t, fillable[0].ID, "first")
+}
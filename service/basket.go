@@ -20,7 +20,9 @@ func NewBasketService(storage storage.IStorage, log logger.ILogger) basketServic
 }
 
 func (b basketService) Create(ctx context.Context, basket models.CreateBasket) (models.Basket, error) {
-	id, err := b.storage.Basket().Create(ctx, basket)
+	id, err := audited(ctx, b.storage, models.EntityBasket, models.AuditCreate, "", loader(b.storage.Basket().GetByID), func(ctx context.Context) (string, error) {
+		return b.storage.Basket().Create(ctx, basket)
+	})
 	if err != nil {
 		b.log.Error("error in service layer while creating basket", logger.Error(err))
 
@@ -60,8 +62,11 @@ func (b basketService) GetList(ctx context.Context, request models.GetListReques
 }
 
 func (b basketService) Update(ctx context.Context, basket models.UpdateBasket) (models.Basket, error) {
-	id, err := b.storage.Basket().Update(ctx, basket)
+	id, err := audited(ctx, b.storage, models.EntityBasket, models.AuditUpdate, basket.ID, loader(b.storage.Basket().GetByID), func(ctx context.Context) (string, error) {
+		return b.storage.Basket().Update(ctx, basket)
+	})
 	if err != nil {
+		err = versionConflict(ctx, err, loader(b.storage.Basket().GetByID), basket.ID)
 		b.log.Error("error in service layer while updating", logger.Error(err))
 
 		return models.Basket{}, err
@@ -77,8 +82,40 @@ func (b basketService) Update(ctx context.Context, basket models.UpdateBasket) (
 	return updatedBasket, nil
 }
 
+// Patch changes only the fields of the basket present in the merge patch.
+func (b basketService) Patch(ctx context.Context, patch models.Patch) (models.Basket, error) {
+	basket := models.UpdateBasket{}
+	fields, err := decodePatch(patch, &basket, "customer_id", "total_sum")
+	if err != nil {
+		return models.Basket{}, err
+	}
+
+	basket.ID, basket.Version = patch.ID, patch.Version
+
+	id, err := audited(ctx, b.storage, models.EntityBasket, models.AuditUpdate, basket.ID, loader(b.storage.Basket().GetByID), func(ctx context.Context) (string, error) {
+		return b.storage.Basket().Patch(ctx, basket, fields)
+	})
+	if err != nil {
+		err = versionConflict(ctx, err, loader(b.storage.Basket().GetByID), basket.ID)
+		b.log.Error("error in service layer while patching basket", logger.Error(err))
+
+		return models.Basket{}, err
+	}
+
+	patchedBasket, err := b.storage.Basket().GetByID(ctx, models.PrimaryKey{ID: id})
+	if err != nil {
+		b.log.Error("error in service layer while getting basket by id", logger.Error(err))
+
+		return models.Basket{}, err
+	}
+
+	return patchedBasket, nil
+}
+
 func (b basketService) Delete(ctx context.Context, key models.PrimaryKey) error {
-	err := b.storage.Basket().Delete(ctx, key)
+	_, err := audited(ctx, b.storage, models.EntityBasket, models.AuditDelete, key.ID, loader(b.storage.Basket().GetByID), func(ctx context.Context) (string, error) {
+		return key.ID, b.storage.Basket().Delete(ctx, key)
+	})
 
 	return err
 }
@@ -20,7 +20,9 @@ func NewBasketProductService(storage storage.IStorage, log logger.ILogger) baske
 }
 
 func (b basketProductService) Create(ctx context.Context, createProduct models.CreateBasketProduct) (models.BasketProduct, error) {
-	id, err := b.storage.BasketProduct().Create(ctx, createProduct)
+	id, err := audited(ctx, b.storage, models.EntityBasketProduct, models.AuditCreate, "", loader(b.storage.BasketProduct().GetByID), func(ctx context.Context) (string, error) {
+		return b.storage.BasketProduct().Create(ctx, createProduct)
+	})
 	if err != nil {
 		b.log.Error("error in service layer while creating basket product", logger.Error(err))
 
@@ -60,8 +62,11 @@ func (b basketProductService) GetList(ctx context.Context, request models.GetLis
 }
 
 func (b basketProductService) Update(ctx context.Context, product models.UpdateBasketProduct) (models.BasketProduct, error) {
-	id, err := b.storage.BasketProduct().Update(ctx, product)
+	id, err := audited(ctx, b.storage, models.EntityBasketProduct, models.AuditUpdate, product.ID, loader(b.storage.BasketProduct().GetByID), func(ctx context.Context) (string, error) {
+		return b.storage.BasketProduct().Update(ctx, product)
+	})
 	if err != nil {
+		err = versionConflict(ctx, err, loader(b.storage.BasketProduct().GetByID), product.ID)
 		b.log.Error("error in service layer while getting list", logger.Error(err))
 
 		return models.BasketProduct{}, err
@@ -77,7 +82,39 @@ func (b basketProductService) Update(ctx context.Context, product models.UpdateB
 	return updatedBasketProduct, nil
 }
 
+// Patch changes only the fields of the basket product present in the merge patch.
+func (b basketProductService) Patch(ctx context.Context, patch models.Patch) (models.BasketProduct, error) {
+	product := models.UpdateBasketProduct{}
+	fields, err := decodePatch(patch, &product, "product_id", "quantity")
+	if err != nil {
+		return models.BasketProduct{}, err
+	}
+
+	product.ID, product.Version = patch.ID, patch.Version
+
+	id, err := audited(ctx, b.storage, models.EntityBasketProduct, models.AuditUpdate, product.ID, loader(b.storage.BasketProduct().GetByID), func(ctx context.Context) (string, error) {
+		return b.storage.BasketProduct().Patch(ctx, product, fields)
+	})
+	if err != nil {
+		err = versionConflict(ctx, err, loader(b.storage.BasketProduct().GetByID), product.ID)
+		b.log.Error("error in service layer while patching basket product", logger.Error(err))
+
+		return models.BasketProduct{}, err
+	}
+
+	patchedBasketProduct, err := b.storage.BasketProduct().GetByID(ctx, models.PrimaryKey{ID: id})
+	if err != nil {
+		b.log.Error("error in service layer while getting by id", logger.Error(err))
+
+		return models.BasketProduct{}, err
+	}
+
+	return patchedBasketProduct, nil
+}
+
 func (b basketProductService) Delete(ctx context.Context, key models.PrimaryKey) error {
-	err := b.storage.BasketProduct().Delete(ctx, key)
+	_, err := audited(ctx, b.storage, models.EntityBasketProduct, models.AuditDelete, key.ID, loader(b.storage.BasketProduct().GetByID), func(ctx context.Context) (string, error) {
+		return key.ID, b.storage.BasketProduct().Delete(ctx, key)
+	})
 	return err
 }
@@ -2,25 +2,41 @@ package service
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"test/api/models"
+	"test/config"
+	"test/pkg/check"
 	"test/pkg/logger"
 	"test/storage"
 )
 
 type branchService struct {
+	cfg     config.Config
 	storage storage.IStorage
 	log     logger.ILogger
 }
 
-func NewBranchService(storage storage.IStorage, log logger.ILogger) branchService {
+func NewBranchService(cfg config.Config, storage storage.IStorage, log logger.ILogger) branchService {
 	return branchService{
+		cfg:     cfg,
 		storage: storage,
 		log:     log,
 	}
 }
 
 func (b branchService) Create(ctx context.Context, branch models.CreateBranch) (models.Branch, error) {
-	id, err := b.storage.Branch().Create(ctx, branch)
+	if branch.Currency == "" {
+		branch.Currency = b.cfg.BaseCurrency
+	}
+
+	if err := check.ValidateCurrency(branch.Currency); err != nil {
+		return models.Branch{}, err
+	}
+
+	id, err := audited(ctx, b.storage, models.EntityBranch, models.AuditCreate, "", loader(b.storage.Branch().GetByID), func(ctx context.Context) (string, error) {
+		return b.storage.Branch().Create(ctx, branch)
+	})
 	if err != nil {
 		b.log.Error("error in service layer while creating branch", logger.Error(err))
 
@@ -60,8 +76,25 @@ func (b branchService) GetList(ctx context.Context, request models.GetListReques
 }
 
 func (b branchService) Update(ctx context.Context, branch models.UpdateBranch) (models.Branch, error) {
-	id, err := b.storage.Branch().Update(ctx, branch)
+	oldBranch, err := b.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: branch.ID})
+	if err != nil {
+		b.log.Error("error in service layer while getting branch by id", logger.Error(err))
+
+		return models.Branch{}, err
+	}
+
+	// prices and sales of the branch are kept in its currency, so it is fixed once the branch is created
+	if branch.Currency == "" {
+		branch.Currency = oldBranch.Currency
+	} else if branch.Currency != oldBranch.Currency {
+		return models.Branch{}, errors.New("branch currency cannot be changed")
+	}
+
+	id, err := audited(ctx, b.storage, models.EntityBranch, models.AuditUpdate, branch.ID, loader(b.storage.Branch().GetByID), func(ctx context.Context) (string, error) {
+		return b.storage.Branch().Update(ctx, branch)
+	})
 	if err != nil {
+		err = versionConflict(ctx, err, loader(b.storage.Branch().GetByID), branch.ID)
 		b.log.Error("error in service layer while updating branch", logger.Error(err))
 
 		return models.Branch{}, err
@@ -77,8 +110,51 @@ func (b branchService) Update(ctx context.Context, branch models.UpdateBranch) (
 	return updatedBranch, nil
 }
 
-func (b branchService) Delete(ctx context.Context, key models.PrimaryKey) error {
-	err := b.storage.Branch().Delete(ctx, key)
+// Patch changes only the fields of the branch present in the merge patch.
+func (b branchService) Patch(ctx context.Context, patch models.Patch) (models.Branch, error) {
+	branch := models.UpdateBranch{}
+	fields, err := decodePatch(patch, &branch, "name", "address", "phone_number", "currency")
+	if err != nil {
+		return models.Branch{}, err
+	}
+
+	branch.ID, branch.Version = patch.ID, patch.Version
 
-	return err
+	if slices.Contains(fields, "currency") {
+		oldBranch, err := b.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: branch.ID})
+		if err != nil {
+			b.log.Error("error in service layer while getting branch by id", logger.Error(err))
+
+			return models.Branch{}, err
+		}
+
+		if branch.Currency != oldBranch.Currency {
+			return models.Branch{}, errors.New("branch currency cannot be changed")
+		}
+	}
+
+	id, err := audited(ctx, b.storage, models.EntityBranch, models.AuditUpdate, branch.ID, loader(b.storage.Branch().GetByID), func(ctx context.Context) (string, error) {
+		return b.storage.Branch().Patch(ctx, branch, fields)
+	})
+	if err != nil {
+		err = versionConflict(ctx, err, loader(b.storage.Branch().GetByID), branch.ID)
+		b.log.Error("error in service layer while patching branch", logger.Error(err))
+
+		return models.Branch{}, err
+	}
+
+	patchedBranch, err := b.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: id})
+	if err != nil {
+		b.log.Error("error in service layer while getting branch by id", logger.Error(err))
+
+		return models.Branch{}, err
+	}
+
+	return patchedBranch, nil
+}
+
+func (b branchService) Delete(ctx context.Context, key models.PrimaryKey) error {
+	return deleteChecked(ctx, b.cfg, b.storage, b.log, models.EntityBranch, key.ID, loader(b.storage.Branch().GetByID), func(ctx context.Context) error {
+		return b.storage.Branch().Delete(ctx, key)
+	})
 }
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/pkg/money"
+	"test/storage"
+)
+
+var ErrInvalidBranchProductPrice = errors.New("invalid branch product price")
+
+type branchProductPriceService struct {
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewBranchProductPriceService(storage storage.IStorage, log logger.ILogger) branchProductPriceService {
+	return branchProductPriceService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+func (b branchProductPriceService) SetMultiple(ctx context.Context, request models.SetBranchProductPrices) error {
+	if len(request.Prices) == 0 {
+		return fmt.Errorf("%w: prices should not be empty", ErrInvalidBranchProductPrice)
+	}
+
+	for _, price := range request.Prices {
+		if price.Price <= 0 {
+			return fmt.Errorf("%w: price of product %s should be positive", ErrInvalidBranchProductPrice, price.ProductID)
+		}
+	}
+
+	branch, err := b.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: request.BranchID})
+	if err != nil {
+		b.log.Error("error in service layer while getting branch by id", logger.Error(err))
+
+		return err
+	}
+
+	// an override is kept in the currency of the branch, so it only replaces a price in that currency
+	for _, price := range request.Prices {
+		product, err := b.storage.Product().GetByID(ctx, models.PrimaryKey{ID: price.ProductID})
+		if err != nil {
+			b.log.Error("error in service layer while getting product by id", logger.Error(err))
+
+			return fmt.Errorf("product %s: %w", price.ProductID, err)
+		}
+
+		if product.Currency != branch.Currency {
+			return fmt.Errorf("%w: product %s is priced in %s, the branch sells in %s",
+				money.ErrCurrencyMismatch, product.ID, product.Currency, branch.Currency)
+		}
+	}
+
+	if err := b.storage.WithTx(ctx, func(ctx context.Context) error {
+		if err := b.storage.BranchProductPrice().UpsertMultiple(ctx, request); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, b.storage, models.EntityBranchProductPrice, models.AuditUpdate, request.BranchID, nil, request)
+	}); err != nil {
+		b.log.Error("error in service layer while setting branch product prices", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (b branchProductPriceService) GetList(ctx context.Context, request models.GetListRequest) (models.BranchProductPricesResponse, error) {
+	prices, err := b.storage.BranchProductPrice().GetList(ctx, request)
+	if err != nil {
+		b.log.Error("error in service layer while getting branch product prices", logger.Error(err))
+
+		return models.BranchProductPricesResponse{}, err
+	}
+
+	return prices, nil
+}
+
+func (b branchProductPriceService) DeleteMultiple(ctx context.Context, request models.DeleteBranchProductPrices) error {
+	err := b.storage.WithTx(ctx, func(ctx context.Context) error {
+		if err := b.storage.BranchProductPrice().DeleteMultiple(ctx, request); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, b.storage, models.EntityBranchProductPrice, models.AuditDelete, request.BranchID, request, nil)
+	})
+
+	return err
+}
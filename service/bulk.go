@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"test/api/models"
+	"test/pkg/audit"
+	"test/pkg/logger"
+	"test/pkg/money"
+)
+
+var (
+	ErrInvalidBulk      = errors.New("invalid bulk operation")
+	ErrPriceNotPositive = errors.New("price would not be positive")
+	ErrNegativeStock    = errors.New("stock would be negative")
+)
+
+// BulkUpdatePrices changes the prices of the filtered products in one transaction,
+// every changed price is kept in the price history and the audit log.
+func (p productService) BulkUpdatePrices(ctx context.Context, update models.BulkPriceUpdate) (models.BulkResult, error) {
+	if err := validateBulkPriceUpdate(update); err != nil {
+		return models.BulkResult{}, err
+	}
+
+	result := models.BulkResult{}
+	err := p.storage.WithTx(ctx, func(ctx context.Context) error {
+		prices, err := p.storage.Product().LockPrices(ctx, update.Filter)
+		if err != nil {
+			return err
+		}
+
+		result.Matched = len(prices)
+
+		changed := make([]models.ProductPrice, 0, len(prices))
+		for _, price := range prices {
+			newPrice, err := checkedBulkPrice(price, update)
+			if err != nil {
+				return err
+			}
+
+			if newPrice == price.Price {
+				continue
+			}
+
+			if err = recordAudit(ctx, p.storage, models.EntityProduct, models.AuditUpdate, price.ProductID,
+				map[string]money.Amount{"price": price.Price}, map[string]money.Amount{"price": newPrice}); err != nil {
+				return err
+			}
+
+			price.Price = newPrice
+			changed = append(changed, price)
+		}
+
+		if len(changed) == 0 {
+			return nil
+		}
+
+		if err = p.storage.Product().SetPrices(ctx, changed); err != nil {
+			return err
+		}
+
+		for _, price := range changed {
+			if _, err = p.storage.ProductPrice().Create(ctx, models.CreateProductPrice{
+				ProductID:     price.ProductID,
+				Price:         price.Price,
+				OriginalPrice: price.OriginalPrice,
+				CreatedBy:     audit.MetaFrom(ctx).ActorID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		result.Updated = len(changed)
+
+		return nil
+	})
+	if err != nil {
+		p.log.Error("error in service layer while updating prices in bulk", logger.Error(err))
+
+		return models.BulkResult{}, err
+	}
+
+	return result, nil
+}
+
+// AdjustStock changes the stock of the products in one transaction, every change is kept
+// in the stock movements with the reason and in the audit log.
+func (p productService) AdjustStock(ctx context.Context, adjustment models.BulkStockAdjustment) (models.BulkResult, error) {
+	if len(adjustment.Items) == 0 {
+		return models.BulkResult{}, fmt.Errorf("%w: no products to adjust", ErrInvalidBulk)
+	}
+
+	if adjustment.Reason == "" {
+		return models.BulkResult{}, fmt.Errorf("%w: reason is required", ErrInvalidBulk)
+	}
+
+	units, err := unitsByCode(ctx, p.storage)
+	if err != nil {
+		p.log.Error("error in service layer while getting units", logger.Error(err))
+
+		return models.BulkResult{}, err
+	}
+
+	result := models.BulkResult{Matched: len(adjustment.Items)}
+	err = p.storage.WithTx(ctx, func(ctx context.Context) error {
+		for _, item := range adjustment.Items {
+			if item.Quantity == 0 {
+				continue
+			}
+
+			product, err := p.storage.Product().GetByID(ctx, models.PrimaryKey{ID: item.ProductID})
+			if err != nil {
+				return fmt.Errorf("product %s: %w", item.ProductID, err)
+			}
+
+			if !units[product.Unit].Fractional && !item.Quantity.IsWhole() {
+				return fmt.Errorf("%w: quantity %s is not a whole number of %s", ErrInvalidBulk, item.Quantity, product.Unit)
+			}
+
+			if _, err = moveStock(ctx, p.storage, item.ProductID, item.Quantity, models.StockMovementAdjustment, adjustment.Reason); err != nil {
+				return err
+			}
+
+			// stock taken away leaves its lots too, expired ones first
+			if item.Quantity < 0 {
+				if _, err = takeLots(ctx, p.storage, item.ProductID, -item.Quantity, true, models.StockMovementAdjustment, ""); err != nil {
+					return err
+				}
+			}
+
+			result.Updated++
+		}
+
+		return nil
+	})
+	if err != nil {
+		p.log.Error("error in service layer while adjusting stock", logger.Error(err))
+
+		return models.BulkResult{}, err
+	}
+
+	return result, nil
+}
+
+func validateBulkPriceUpdate(update models.BulkPriceUpdate) error {
+	filter := update.Filter
+	if len(filter.CategoryIDs) == 0 && filter.BranchID == "" && len(filter.ProductIDs) == 0 {
+		return fmt.Errorf("%w: filter should select products by category, branch or id", ErrInvalidBulk)
+	}
+
+	if update.Amount < 0 || update.Percent < 0 {
+		return fmt.Errorf("%w: amount and percent should not be negative", ErrInvalidBulk)
+	}
+
+	switch update.Operation {
+	case models.BulkPriceSet:
+		if update.Percent != 0 {
+			return fmt.Errorf("%w: price is set by amount", ErrInvalidBulk)
+		}
+
+		if update.Amount == 0 {
+			return fmt.Errorf("%w: price should be set to a positive amount", ErrInvalidBulk)
+		}
+	case models.BulkPriceIncrease, models.BulkPriceDecrease:
+		if (update.Amount == 0) == (update.Percent == 0) {
+			return fmt.Errorf("%w: either amount or percent should be given", ErrInvalidBulk)
+		}
+
+		if update.Operation == models.BulkPriceDecrease && update.Percent >= 100 {
+			return fmt.Errorf("%w: price should be decreased by less than 100 percent", ErrInvalidBulk)
+		}
+	default:
+		return fmt.Errorf("%w: unknown operation %s", ErrInvalidBulk, update.Operation)
+	}
+
+	return nil
+}
+
+// checkedBulkPrice returns the price of the product after the update, a price is never updated to zero or less.
+func checkedBulkPrice(price models.ProductPrice, update models.BulkPriceUpdate) (money.Amount, error) {
+	newPrice := bulkPrice(price.Price, update)
+	if newPrice <= 0 {
+		return 0, fmt.Errorf("%w for product %s", ErrPriceNotPositive, price.ProductID)
+	}
+
+	return newPrice, nil
+}
+
+// bulkPrice returns the price after the update, percents are rounded half away from zero.
+func bulkPrice(price money.Amount, update models.BulkPriceUpdate) money.Amount {
+	change := update.Amount
+	if update.Percent != 0 {
+		change = price.Percent(update.Percent)
+	}
+
+	switch update.Operation {
+	case models.BulkPriceIncrease:
+		return price + change
+	case models.BulkPriceDecrease:
+		return price - change
+	}
+
+	return update.Amount
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+	"test/api/models"
+	"test/pkg/money"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestBulkPrice(t *testing.T) {
+	byCategory := models.ProductFilter{CategoryIDs: []string{"0b59dd69-b7b3-43c7-95c1-19a1fd9e0677"}}
+
+	assert.Equal(t, bulkPrice(1000, models.BulkPriceUpdate{Filter: byCategory, Operation: models.BulkPriceSet, Amount: 1200}), money.Amount(1200))
+	assert.Equal(t, bulkPrice(1000, models.BulkPriceUpdate{Filter: byCategory, Operation: models.BulkPriceIncrease, Amount: 150}), money.Amount(1150))
+	assert.Equal(t, bulkPrice(1005, models.BulkPriceUpdate{Filter: byCategory, Operation: models.BulkPriceIncrease, Percent: 10}), money.Amount(1106))
+	assert.Equal(t, bulkPrice(1000, models.BulkPriceUpdate{Filter: byCategory, Operation: models.BulkPriceDecrease, Percent: 15}), money.Amount(850))
+	assert.Equal(t, bulkPrice(100, models.BulkPriceUpdate{Filter: byCategory, Operation: models.BulkPriceDecrease, Amount: 150}), money.Amount(-50))
+}
+
+func TestValidateBulkPriceUpdate(t *testing.T) {
+	byBranch := models.ProductFilter{BranchID: "aa541fcc-bf74-11ee-ae0b-166244b65504"}
+
+	for _, update := range []models.BulkPriceUpdate{
+		{Operation: models.BulkPriceSet, Amount: 100},
+		{Filter: byBranch, Operation: models.BulkPriceSet},
+		{Filter: byBranch, Operation: models.BulkPriceIncrease, Amount: 100, Percent: 5},
+		{Filter: byBranch, Operation: models.BulkPriceIncrease},
+		{Filter: byBranch, Operation: models.BulkPriceDecrease, Percent: 120},
+		{Filter: byBranch, Operation: models.BulkPriceDecrease, Percent: 100},
+		{Filter: byBranch, Operation: "double"},
+	} {
+		assert.Equal(t, errors.Is(validateBulkPriceUpdate(update), ErrInvalidBulk), true)
+	}
+
+	assert.Equal(t, validateBulkPriceUpdate(models.BulkPriceUpdate{Filter: byBranch, Operation: models.BulkPriceDecrease, Percent: 10}), nil)
+}
+
+func TestCheckedBulkPrice(t *testing.T) {
+	byBranch := models.ProductFilter{BranchID: "aa541fcc-bf74-11ee-ae0b-166244b65504"}
+	price := models.ProductPrice{ProductID: "e3c2a7c6-7d35-4a43-9a1c-2b1d0b4c1f10", Price: 100}
+
+	for _, update := range []models.BulkPriceUpdate{
+		{Filter: byBranch, Operation: models.BulkPriceDecrease, Amount: 100},
+		{Filter: byBranch, Operation: models.BulkPriceDecrease, Amount: 150},
+	} {
+		_, err := checkedBulkPrice(price, update)
+		assert.Equal(t, errors.Is(err, ErrPriceNotPositive), true)
+	}
+
+	newPrice, err := checkedBulkPrice(price, models.BulkPriceUpdate{Filter: byBranch, Operation: models.BulkPriceDecrease, Amount: 99})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, newPrice, money.Amount(1))
+}
@@ -3,27 +3,47 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 	"test/api/models"
+	"test/config"
 	"test/pkg/logger"
 	"test/storage"
 
 	"github.com/jackc/pgx/v5"
 )
 
+var ErrCategoryCycle = errors.New("category cannot be moved under itself or its subcategory")
+
 type categoryService struct {
+	cfg     config.Config
 	storage storage.IStorage
 	log     logger.ILogger
 }
 
-func NewCategoryService(storage storage.IStorage, log logger.ILogger) categoryService {
+func NewCategoryService(cfg config.Config, storage storage.IStorage, log logger.ILogger) categoryService {
 	return categoryService{
+		cfg:     cfg,
 		storage: storage,
 		log:     log,
 	}
 }
 
 func (c categoryService) Create(ctx context.Context, createCategory models.CreateCategory) (models.Category, error) {
-	pKey, err := c.storage.Category().Create(ctx, createCategory)
+	if err := validateTaxRate(createCategory.TaxRate); err != nil {
+		return models.Category{}, err
+	}
+
+	if createCategory.ParentID != "" {
+		if _, err := c.storage.Category().GetByID(ctx, models.PrimaryKey{ID: createCategory.ParentID}); err != nil {
+			c.log.Error("error in service layer while getting parent category", logger.Error(err))
+
+			return models.Category{}, err
+		}
+	}
+
+	pKey, err := audited(ctx, c.storage, models.EntityCategory, models.AuditCreate, "", loader(c.storage.Category().GetByID), func(ctx context.Context) (string, error) {
+		return c.storage.Category().Create(ctx, createCategory)
+	})
 	if err != nil {
 		c.log.Error("ERROR in service layer while creating category", logger.Error(err))
 
@@ -65,8 +85,19 @@ func (c categoryService) GetList(ctx context.Context, request models.GetListRequ
 }
 
 func (c categoryService) Update(ctx context.Context, category models.UpdateCategory) (models.Category, error) {
-	id, err := c.storage.Category().Update(ctx, category)
+	if err := validateTaxRate(category.TaxRate); err != nil {
+		return models.Category{}, err
+	}
+
+	if err := c.checkParent(ctx, category.ID, category.ParentID); err != nil {
+		return models.Category{}, err
+	}
+
+	id, err := audited(ctx, c.storage, models.EntityCategory, models.AuditUpdate, category.ID, loader(c.storage.Category().GetByID), func(ctx context.Context) (string, error) {
+		return c.storage.Category().Update(ctx, category)
+	})
 	if err != nil {
+		err = versionConflict(ctx, err, loader(c.storage.Category().GetByID), category.ID)
 		c.log.Error("error in service layer while updating category", logger.Error(err))
 
 		return models.Category{}, err
@@ -82,8 +113,114 @@ func (c categoryService) Update(ctx context.Context, category models.UpdateCateg
 	return updatedCategory, nil
 }
 
+// Patch changes only the fields of the category present in the merge patch.
+func (c categoryService) Patch(ctx context.Context, patch models.Patch) (models.Category, error) {
+	category := models.UpdateCategory{}
+	fields, err := decodePatch(patch, &category, "name")
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	category.ID, category.Version = patch.ID, patch.Version
+
+	if err = validateTaxRate(category.TaxRate); err != nil {
+		return models.Category{}, err
+	}
+
+	if slices.Contains(fields, "parent_id") {
+		if err = c.checkParent(ctx, category.ID, category.ParentID); err != nil {
+			return models.Category{}, err
+		}
+	}
+
+	id, err := audited(ctx, c.storage, models.EntityCategory, models.AuditUpdate, category.ID, loader(c.storage.Category().GetByID), func(ctx context.Context) (string, error) {
+		return c.storage.Category().Patch(ctx, category, fields)
+	})
+	if err != nil {
+		err = versionConflict(ctx, err, loader(c.storage.Category().GetByID), category.ID)
+		c.log.Error("error in service layer while patching category", logger.Error(err))
+
+		return models.Category{}, err
+	}
+
+	patchedCategory, err := c.storage.Category().GetByID(ctx, models.PrimaryKey{ID: id})
+	if err != nil {
+		c.log.Error("error in service layer while getting by id", logger.Error(err))
+
+		return models.Category{}, err
+	}
+
+	return patchedCategory, nil
+}
+
+// checkParent makes sure the parent exists and is not the category itself or one of its subcategories.
+func (c categoryService) checkParent(ctx context.Context, id, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+
+	if _, err := c.storage.Category().GetByID(ctx, models.PrimaryKey{ID: parentID}); err != nil {
+		c.log.Error("error in service layer while getting parent category", logger.Error(err))
+
+		return err
+	}
+
+	descendantIDs, err := c.storage.Category().GetDescendantIDs(ctx, id)
+	if err != nil {
+		c.log.Error("error in service layer while getting category descendants", logger.Error(err))
+
+		return err
+	}
+
+	for _, descendantID := range descendantIDs {
+		if descendantID == parentID {
+			return ErrCategoryCycle
+		}
+	}
+
+	return nil
+}
+
 func (c categoryService) Delete(ctx context.Context, key models.PrimaryKey) error {
-	err := c.storage.Category().Delete(ctx, key)
+	return deleteChecked(ctx, c.cfg, c.storage, c.log, models.EntityCategory, key.ID, loader(c.storage.Category().GetByID), func(ctx context.Context) error {
+		return c.storage.Category().Delete(ctx, key)
+	})
+}
+
+// GetTree returns root categories with their subcategories nested.
+func (c categoryService) GetTree(ctx context.Context) (models.CategoryTree, error) {
+	categories, err := c.storage.Category().GetAll(ctx)
+	if err != nil {
+		c.log.Error("error in service layer while getting all categories", logger.Error(err))
+
+		return models.CategoryTree{}, err
+	}
+
+	ids := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		ids[category.ID] = true
+	}
+
+	// categories under a deleted parent are shown as roots
+	children := map[string][]models.Category{}
+	for _, category := range categories {
+		parentID := category.ParentID
+		if !ids[parentID] {
+			parentID = ""
+		}
+		children[parentID] = append(children[parentID], category)
+	}
+
+	return models.CategoryTree{
+		Categories: buildCategoryTree(children, ""),
+	}, nil
+}
+
+func buildCategoryTree(children map[string][]models.Category, parentID string) []models.Category {
+	categories := children[parentID]
+	for i := range categories {
+		categories[i].Children = buildCategoryTree(children, categories[i].ID)
+	}
 
-	return err
+	return categories
 }
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"test/api/models"
+	"test/config"
+	"test/pkg/check"
+	"test/pkg/logger"
+	"test/storage"
+	"time"
+)
+
+type currencyRateService struct {
+	cfg     config.Config
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewCurrencyRateService(cfg config.Config, storage storage.IStorage, log logger.ILogger) currencyRateService {
+	return currencyRateService{
+		cfg:     cfg,
+		storage: storage,
+		log:     log,
+	}
+}
+
+func (c currencyRateService) Set(ctx context.Context, rate models.CreateCurrencyRate) (models.CurrencyRate, error) {
+	if err := check.ValidateCurrency(rate.Currency); err != nil {
+		return models.CurrencyRate{}, err
+	}
+
+	if rate.Currency == c.cfg.BaseCurrency {
+		return models.CurrencyRate{}, errors.New("rate of base currency is always 1")
+	}
+
+	if rate.Rate <= 0 {
+		return models.CurrencyRate{}, errors.New("rate should be positive")
+	}
+
+	if rate.RateDate == "" {
+		rate.RateDate = time.Now().Format(time.DateOnly)
+	} else if _, err := time.Parse(time.DateOnly, rate.RateDate); err != nil {
+		c.log.Error("error in service layer while parsing rate_date", logger.Error(err))
+
+		return models.CurrencyRate{}, err
+	}
+
+	id, err := audited(ctx, c.storage, models.EntityCurrencyRate, models.AuditCreate, "", loader(c.storage.CurrencyRate().GetByID), func(ctx context.Context) (string, error) {
+		return c.storage.CurrencyRate().Upsert(ctx, rate)
+	})
+	if err != nil {
+		c.log.Error("error in service layer while setting currency rate", logger.Error(err))
+
+		return models.CurrencyRate{}, err
+	}
+
+	currencyRate, err := c.storage.CurrencyRate().GetByID(ctx, models.PrimaryKey{ID: id})
+	if err != nil {
+		c.log.Error("error in service layer while getting currency rate by id", logger.Error(err))
+
+		return models.CurrencyRate{}, err
+	}
+
+	return currencyRate, nil
+}
+
+func (c currencyRateService) GetList(ctx context.Context, request models.GetListRequest) (models.CurrencyRatesResponse, error) {
+	rates, err := c.storage.CurrencyRate().GetList(ctx, request)
+	if err != nil {
+		c.log.Error("error in service layer while getting currency rates", logger.Error(err))
+
+		return models.CurrencyRatesResponse{}, err
+	}
+
+	return rates, nil
+}
+
+func (c currencyRateService) Delete(ctx context.Context, key models.PrimaryKey) error {
+	_, err := audited(ctx, c.storage, models.EntityCurrencyRate, models.AuditDelete, key.ID, loader(c.storage.CurrencyRate().GetByID), func(ctx context.Context) (string, error) {
+		return key.ID, c.storage.CurrencyRate().Delete(ctx, key)
+	})
+
+	return err
+}
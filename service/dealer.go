@@ -4,6 +4,7 @@ import (
 	"context"
 	"test/api/models"
 	"test/pkg/logger"
+	"test/pkg/money"
 	"test/storage"
 )
 
@@ -21,11 +22,11 @@ func NewDealerService(storage storage.IStorage, log logger.ILogger) dealerServic
 
 func (d dealerService) Delivery(ctx context.Context, sell models.ProductSell) error {
 	var (
-		totalSum = 0
+		totalSum = money.Amount(0)
 	)
 
 	for productID, quantity := range sell.NotEnoughProducts {
-		totalSum += quantity * sell.NotEnoughProductPrices[productID]
+		totalSum += sell.NotEnoughProductPrices[productID].MulQuantity(quantity)
 	}
 
 	budget, err := d.storage.Store().GetStoreBudget(ctx, sell.ProductsBranchID)
@@ -35,7 +36,7 @@ func (d dealerService) Delivery(ctx context.Context, sell models.ProductSell) er
 		return err
 	}
 
-	if budget < float32(totalSum) {
+	if budget < totalSum {
 		d.log.Error("not enough budget", logger.Error(err))
 
 		return err
@@ -49,7 +50,7 @@ func (d dealerService) Delivery(ctx context.Context, sell models.ProductSell) er
 		return err
 	}
 
-	if err = d.storage.Store().WithdrawalDeliveredSum(ctx, float32(totalSum), sell.ProductsBranchID); err != nil {
+	if err = d.storage.Store().WithdrawalDeliveredSum(ctx, totalSum, sell.ProductsBranchID); err != nil {
 		d.log.Error("error in service layer while remove delivered sum", logger.Error(err))
 		return err
 	}
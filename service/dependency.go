@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+var ErrHasDependents = errors.New("entity has dependents")
+
+// DependentsError refuses a delete while live rows reference the row, it lists them by entity.
+type DependentsError struct {
+	Entity     string            `json:"entity"`
+	ID         string            `json:"id"`
+	Dependents models.Dependents `json:"dependents"`
+}
+
+func (e DependentsError) Error() string {
+	return fmt.Sprintf("%s %s has dependents: %v", e.Entity, e.ID, e.Dependents)
+}
+
+func (e DependentsError) Is(target error) bool {
+	return target == ErrHasDependents
+}
+
+// deleteChecked deletes the row of the entity with delete unless live rows depend on it.
+// Entities configured to cascade delete their dependents along instead of refusing.
+// The delete is audited, load reads the row for the audit log.
+func deleteChecked(ctx context.Context, cfg config.Config, storage storage.IStorage, log logger.ILogger,
+	entity, id string, load func(context.Context, string) (interface{}, error), delete func(context.Context) error) error {
+	_, err := audited(ctx, storage, entity, models.AuditDelete, id, load, func(ctx context.Context) (string, error) {
+		for _, cascade := range cfg.DeleteCascade {
+			if cascade != entity {
+				continue
+			}
+
+			deleted, err := storage.Dependency().DeleteCascade(ctx, entity, id)
+			if err != nil {
+				log.Error("error in service layer while cascading delete", logger.Error(err))
+
+				return "", err
+			}
+
+			// every dependent deleted along gets its own audit log entry
+			dependents := models.Dependents{}
+			for _, row := range deleted {
+				if err = recordAudit(ctx, storage, row.Entity, models.AuditDelete, row.ID, row.Row, nil); err != nil {
+					return "", err
+				}
+
+				dependents[row.Entity]++
+			}
+
+			if len(dependents) > 0 {
+				log.Info("dependents deleted along", logger.String("entity", entity), logger.String("id", id), logger.Any("dependents", dependents))
+			}
+
+			return id, nil
+		}
+
+		dependents, err := storage.Dependency().Dependents(ctx, entity, id)
+		if err != nil {
+			log.Error("error in service layer while counting dependents", logger.Error(err))
+
+			return "", err
+		}
+
+		if len(dependents) > 0 {
+			return "", DependentsError{Entity: entity, ID: id, Dependents: dependents}
+		}
+
+		return id, delete(ctx)
+	})
+
+	return err
+}
@@ -2,9 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
 	"test/api/models"
 	"test/pkg/logger"
 	"test/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrIncomePosted = errors.New("income is already posted")
+	ErrEmptyIncome  = errors.New("income has no products")
 )
 
 type incomeService struct {
@@ -20,7 +28,15 @@ func NewIncomeService(storage storage.IStorage, log logger.ILogger) incomeServic
 }
 
 func (i incomeService) Create(ctx context.Context) (models.Income, error) {
-	income, err := i.storage.Income().Create(ctx)
+	income := models.Income{}
+	err := i.storage.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		if income, err = i.storage.Income().Create(ctx); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, i.storage, models.EntityIncome, models.AuditCreate, income.ID, nil, income)
+	})
 	if err != nil {
 		i.log.Error("error while creating income", logger.Error(err))
 
@@ -51,6 +67,87 @@ func (i incomeService) GetList(ctx context.Context, request models.GetListReques
 }
 
 func (i incomeService) Delete(ctx context.Context, key models.PrimaryKey) error {
-	err := i.storage.Income().Delete(ctx, key)
+	_, err := audited(ctx, i.storage, models.EntityIncome, models.AuditDelete, key.ID, loader(i.storage.Income().GetByID), func(ctx context.Context) (string, error) {
+		return key.ID, i.storage.Income().Delete(ctx, key)
+	})
 	return err
 }
+
+// Post receives the goods of the income: every line becomes a lot of its product, numbered after the income
+// when it has no lot number, its cost goes into the average cost of the product and its quantity is added to stock.
+// Open backorders of the received products are fulfilled from the new stock. An income is posted once.
+func (i incomeService) Post(ctx context.Context, key models.PrimaryKey) (models.Income, error) {
+	err := i.storage.WithTx(ctx, func(ctx context.Context) error {
+		income, err := i.storage.Income().GetByID(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if err = i.storage.Income().Post(ctx, key); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrIncomePosted
+			}
+
+			return err
+		}
+
+		incomeProducts, err := i.storage.IncomeProduct().GetByIncome(ctx, key.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(incomeProducts) == 0 {
+			return ErrEmptyIncome
+		}
+
+		reason := "income " + income.ExternalID
+		received := map[string]bool{}
+		for _, incomeProduct := range incomeProducts {
+			lotNumber := incomeProduct.LotNumber
+			if lotNumber == "" {
+				lotNumber = income.ExternalID
+			}
+
+			if _, err = i.storage.Lot().Create(ctx, models.CreateLot{
+				ProductID:       incomeProduct.ProductID,
+				IncomeProductID: incomeProduct.ID,
+				LotNumber:       lotNumber,
+				ExpiryDate:      incomeProduct.ExpiryDate,
+				Cost:            incomeProduct.Price,
+				Quantity:        incomeProduct.Quantity,
+			}); err != nil {
+				return err
+			}
+
+			if err = i.storage.Product().UpdateAverageCost(ctx, incomeProduct.ProductID, incomeProduct.Quantity, incomeProduct.Price); err != nil {
+				return err
+			}
+
+			if _, err = moveStock(ctx, i.storage, incomeProduct.ProductID, incomeProduct.Quantity, models.StockMovementIncome, reason); err != nil {
+				return err
+			}
+
+			received[incomeProduct.ProductID] = true
+		}
+
+		for productID := range received {
+			if _, err = fulfillBackorders(ctx, i.storage, productID); err != nil {
+				return err
+			}
+		}
+
+		postedIncome, err := i.storage.Income().GetByID(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, i.storage, models.EntityIncome, models.AuditUpdate, key.ID, income, postedIncome)
+	})
+	if err != nil {
+		i.log.Error("error in service layer while posting income", logger.Error(err))
+
+		return models.Income{}, err
+	}
+
+	return i.Get(ctx, key)
+}
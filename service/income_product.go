@@ -2,11 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"test/api/models"
 	"test/pkg/logger"
 	"test/storage"
+	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
+var ErrInvalidExpiryDate = errors.New("invalid expiry date")
+
 type incomeProductService struct {
 	storage storage.IStorage
 	log     logger.ILogger
@@ -20,7 +27,40 @@ func NewIncomeProductService(storage storage.IStorage, log logger.ILogger) incom
 }
 
 func (i incomeProductService) CreateMultiple(ctx context.Context, request models.CreateIncomeProducts) error {
-	if err := i.storage.IncomeProduct().CreateMultiple(ctx, request); err != nil {
+	if err := i.convertUnits(ctx, request.IncomeProducts); err != nil {
+		return err
+	}
+
+	// lines are audited per income they are added to
+	lines := map[string][]models.CreateIncomeProduct{}
+	for _, incomeProduct := range request.IncomeProducts {
+		if err := validateExpiryDate(incomeProduct.ExpiryDate); err != nil {
+			return err
+		}
+
+		lines[incomeProduct.IncomeID] = append(lines[incomeProduct.IncomeID], incomeProduct)
+	}
+
+	for incomeID := range lines {
+		if err := i.checkNotPosted(ctx, incomeID); err != nil {
+			return err
+		}
+	}
+
+	if err := i.storage.WithTx(ctx, func(ctx context.Context) error {
+		if err := i.storage.IncomeProduct().CreateMultiple(ctx, request); err != nil {
+			return err
+		}
+
+		for incomeID, incomeProducts := range lines {
+			after := models.CreateIncomeProducts{IncomeProducts: incomeProducts}
+			if err := recordAudit(ctx, i.storage, models.EntityIncomeProduct, models.AuditCreate, incomeID, nil, after); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
 		i.log.Error("error while creating multiple income products", logger.Error(err))
 
 		return err
@@ -40,7 +80,83 @@ func (i incomeProductService) GetList(ctx context.Context, request models.GetLis
 }
 
 func (i incomeProductService) UpdateMultiple(ctx context.Context, response models.UpdateIncomeProducts) error {
-	if err := i.storage.IncomeProduct().UpdateMultiple(ctx, response); err != nil {
+	// updated lines are converted and checked the way new lines are
+	lines := make([]models.CreateIncomeProduct, 0, len(response.IncomeProducts))
+	for _, incomeProduct := range response.IncomeProducts {
+		lines = append(lines, models.CreateIncomeProduct{
+			ProductID: incomeProduct.ProductID,
+			Quantity:  incomeProduct.Quantity,
+			Unit:      incomeProduct.Unit,
+			Price:     incomeProduct.Price,
+		})
+	}
+
+	if err := i.convertUnits(ctx, lines); err != nil {
+		return err
+	}
+
+	for index, line := range lines {
+		response.IncomeProducts[index].Quantity, response.IncomeProducts[index].Price = line.Quantity, line.Price
+		response.IncomeProducts[index].Unit = line.Unit
+	}
+
+	ids := make([]string, 0, len(response.IncomeProducts))
+	for _, incomeProduct := range response.IncomeProducts {
+		if err := validateExpiryDate(incomeProduct.ExpiryDate); err != nil {
+			return err
+		}
+
+		ids = append(ids, incomeProduct.ID)
+	}
+
+	stored, err := i.storage.IncomeProduct().GetByIDs(ctx, ids)
+	if err != nil {
+		i.log.Error("error in service layer while getting income products by ids", logger.Error(err))
+
+		return err
+	}
+
+	storedIncomes := make(map[string]string, len(stored))
+	for _, incomeProduct := range stored {
+		storedIncomes[incomeProduct.ID] = incomeProduct.IncomeID
+	}
+
+	// neither the income a line is in nor the one it is moved to may be posted
+	for _, incomeProduct := range response.IncomeProducts {
+		incomeID, ok := storedIncomes[incomeProduct.ID]
+		if !ok {
+			return fmt.Errorf("income product %s: %w", incomeProduct.ID, pgx.ErrNoRows)
+		}
+
+		if err = i.checkNotPosted(ctx, incomeID); err != nil {
+			return err
+		}
+
+		if incomeProduct.IncomeID != incomeID {
+			if err = i.checkNotPosted(ctx, incomeProduct.IncomeID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = i.storage.WithTx(ctx, func(ctx context.Context) error {
+		if err := i.storage.IncomeProduct().UpdateMultiple(ctx, response); err != nil {
+			// the lines were checked, an income got posted meanwhile
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrIncomePosted
+			}
+
+			return err
+		}
+
+		for _, incomeProduct := range response.IncomeProducts {
+			if err := recordAudit(ctx, i.storage, models.EntityIncomeProduct, models.AuditUpdate, incomeProduct.ID, nil, incomeProduct); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
 		i.log.Error("error in service layer while updating", logger.Error(err))
 
 		return err
@@ -49,7 +165,142 @@ func (i incomeProductService) UpdateMultiple(ctx context.Context, response model
 	return nil
 }
 
-func (i incomeProductService) DeleteMultiple(ctx context.Context, response models.DeleteIncomeProducts) error {
-	err := i.storage.IncomeProduct().DeleteMultiple(ctx, response)
-	return err
+// DeleteMultiple deletes the lines, lines of posted incomes are kept and told as refused.
+func (i incomeProductService) DeleteMultiple(ctx context.Context, response models.DeleteIncomeProducts) (models.DeletedIncomeProducts, error) {
+	ids := make([]string, 0, len(response.IDs))
+	for _, key := range response.IDs {
+		ids = append(ids, key.ID)
+	}
+
+	result := models.DeletedIncomeProducts{}
+	err := i.storage.WithTx(ctx, func(ctx context.Context) error {
+		lines, err := i.storage.IncomeProduct().GetByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		result.Deleted, err = i.storage.IncomeProduct().DeleteMultiple(ctx, response)
+		if err != nil {
+			return err
+		}
+
+		deleted := make(map[string]bool, len(result.Deleted))
+		for _, id := range result.Deleted {
+			deleted[id] = true
+		}
+
+		result.Refused = []string{}
+		for _, line := range lines {
+			if !deleted[line.ID] {
+				result.Refused = append(result.Refused, line.ID)
+
+				continue
+			}
+
+			if err = recordAudit(ctx, i.storage, models.EntityIncomeProduct, models.AuditDelete, line.ID, line, nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		i.log.Error("error in service layer while deleting income products", logger.Error(err))
+
+		return models.DeletedIncomeProducts{}, err
+	}
+
+	return result, nil
+}
+
+// convertUnits turns quantities and prices given in a packaging of the product (a box of 12) into the product unit.
+func (i incomeProductService) convertUnits(ctx context.Context, incomeProducts []models.CreateIncomeProduct) error {
+	if len(incomeProducts) == 0 {
+		return nil
+	}
+
+	productIDs := make([]string, 0, len(incomeProducts))
+	for _, incomeProduct := range incomeProducts {
+		productIDs = append(productIDs, incomeProduct.ProductID)
+	}
+
+	products, err := i.storage.Product().GetListByIDs(ctx, productIDs, "")
+	if err != nil {
+		i.log.Error("error in service layer while getting products by ids", logger.Error(err))
+
+		return err
+	}
+
+	productUnits := make(map[string]string, len(products.Products))
+	for _, product := range products.Products {
+		productUnits[product.ID] = product.Unit
+	}
+
+	factors, err := i.storage.Unit().GetFactors(ctx, productIDs)
+	if err != nil {
+		i.log.Error("error in service layer while getting product unit factors", logger.Error(err))
+
+		return err
+	}
+
+	units, err := unitsByCode(ctx, i.storage)
+	if err != nil {
+		i.log.Error("error in service layer while getting units", logger.Error(err))
+
+		return err
+	}
+
+	for index, incomeProduct := range incomeProducts {
+		productUnit, ok := productUnits[incomeProduct.ProductID]
+		if !ok {
+			return fmt.Errorf("product %s not found", incomeProduct.ProductID)
+		}
+
+		if incomeProduct.Unit != "" && incomeProduct.Unit != productUnit {
+			factor, ok := factors[incomeProduct.ProductID][incomeProduct.Unit]
+			if !ok {
+				return fmt.Errorf("product %s has no unit %s", incomeProduct.ProductID, incomeProduct.Unit)
+			}
+
+			incomeProduct.Quantity = incomeProduct.Quantity.Mul(factor)
+			incomeProduct.Price = incomeProduct.Price.DivQuantity(factor)
+		}
+
+		if err = validateQuantity(units[productUnit], incomeProduct.Quantity); err != nil {
+			return err
+		}
+
+		incomeProduct.Unit = productUnit
+		incomeProducts[index] = incomeProduct
+	}
+
+	return nil
+}
+
+// checkNotPosted refuses changes to the lines of an income that is posted to stock.
+func (i incomeProductService) checkNotPosted(ctx context.Context, incomeID string) error {
+	income, err := i.storage.Income().GetByID(ctx, models.PrimaryKey{ID: incomeID})
+	if err != nil {
+		i.log.Error("error in service layer while getting income by id", logger.Error(err))
+
+		return err
+	}
+
+	if income.PostedAt != "" {
+		return ErrIncomePosted
+	}
+
+	return nil
+}
+
+func validateExpiryDate(date string) error {
+	if date == "" {
+		return nil
+	}
+
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return fmt.Errorf("%w: expiry date %s is not YYYY-MM-DD", ErrInvalidExpiryDate, date)
+	}
+
+	return nil
 }
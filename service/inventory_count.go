@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/pkg/money"
+	"test/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidInventoryCount = errors.New("invalid inventory count")
+	ErrInventoryCountClosed  = errors.New("inventory count is not open")
+)
+
+type inventoryCountService struct {
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewInventoryCountService(storage storage.IStorage, log logger.ILogger) inventoryCountService {
+	return inventoryCountService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+// Create opens a count of the branch with the expected quantities of its products as they are now.
+func (i inventoryCountService) Create(ctx context.Context, count models.CreateInventoryCount) (models.InventoryCount, error) {
+	if _, err := i.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: count.BranchID}); err != nil {
+		i.log.Error("error in service layer while getting branch by id", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+
+	id, err := audited(ctx, i.storage, models.EntityInventoryCount, models.AuditCreate, "", loader(i.storage.InventoryCount().GetByID), func(ctx context.Context) (string, error) {
+		return i.storage.InventoryCount().Create(ctx, count)
+	})
+	if err != nil {
+		i.log.Error("error in service layer while creating inventory count", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+
+	return i.Get(ctx, models.PrimaryKey{ID: id})
+}
+
+// Get returns the count with the variances of its lines.
+func (i inventoryCountService) Get(ctx context.Context, key models.PrimaryKey) (models.InventoryCount, error) {
+	count, err := i.storage.InventoryCount().GetByID(ctx, key)
+	if err != nil {
+		i.log.Error("error in service layer while getting inventory count by id", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+
+	return summarizeInventoryCount(count), nil
+}
+
+func (i inventoryCountService) GetList(ctx context.Context, request models.GetListRequest) (models.InventoryCountsResponse, error) {
+	counts, err := i.storage.InventoryCount().GetList(ctx, request)
+	if err != nil {
+		i.log.Error("error in service layer while getting inventory counts", logger.Error(err))
+
+		return models.InventoryCountsResponse{}, err
+	}
+
+	return counts, nil
+}
+
+// Count adds the quantities a counter counted, a product may be counted in parts and by several counters.
+func (i inventoryCountService) Count(ctx context.Context, request models.CountInventory) (models.InventoryCount, error) {
+	if len(request.Entries) == 0 {
+		return models.InventoryCount{}, fmt.Errorf("%w: no counted products", ErrInvalidInventoryCount)
+	}
+
+	count, err := i.storage.InventoryCount().GetByID(ctx, models.PrimaryKey{ID: request.ID})
+	if err != nil {
+		i.log.Error("error in service layer while getting inventory count by id", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+
+	if count.Status != models.InventoryCountOpen {
+		return models.InventoryCount{}, ErrInventoryCountClosed
+	}
+
+	units, err := unitsByCode(ctx, i.storage)
+	if err != nil {
+		i.log.Error("error in service layer while getting units", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+
+	counted := make(map[string]bool, len(count.Lines))
+	for _, line := range count.Lines {
+		counted[line.ProductID] = true
+	}
+
+	for _, entry := range request.Entries {
+		if !counted[entry.ProductID] {
+			return models.InventoryCount{}, fmt.Errorf("%w: product %s is not in the count", ErrInvalidInventoryCount, entry.ProductID)
+		}
+
+		if entry.Quantity < 0 {
+			return models.InventoryCount{}, fmt.Errorf("%w: counted quantity of product %s is negative", ErrInvalidInventoryCount, entry.ProductID)
+		}
+
+		product, err := i.storage.Product().GetByID(ctx, models.PrimaryKey{ID: entry.ProductID})
+		if err != nil {
+			i.log.Error("error in service layer while getting product by id", logger.Error(err))
+
+			return models.InventoryCount{}, err
+		}
+
+		if !units[product.Unit].Fractional && !entry.Quantity.IsWhole() {
+			return models.InventoryCount{}, fmt.Errorf("%w: quantity %s is not a whole number of %s", ErrInvalidInventoryCount, entry.Quantity, product.Unit)
+		}
+	}
+
+	err = i.storage.WithTx(ctx, func(ctx context.Context) error {
+		if err := i.storage.InventoryCount().AddEntries(ctx, request); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, i.storage, models.EntityInventoryCount, models.AuditUpdate, request.ID,
+			nil, map[string][]models.InventoryCountEntry{"entries": request.Entries})
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.InventoryCount{}, ErrInventoryCountClosed
+		}
+
+		i.log.Error("error in service layer while adding inventory count entries", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+
+	return i.Get(ctx, models.PrimaryKey{ID: request.ID})
+}
+
+// Approve closes the count and brings the stock of every counted product in line with the count,
+// products that were not counted keep their stock.
+func (i inventoryCountService) Approve(ctx context.Context, key models.PrimaryKey, approvedBy string) (models.InventoryCount, error) {
+	err := i.storage.WithTx(ctx, func(ctx context.Context) error {
+		count, err := i.close(ctx, key.ID, models.InventoryCountApproved, approvedBy)
+		if err != nil {
+			return err
+		}
+
+		reason := fmt.Sprintf("inventory count %s", count.ID)
+		for _, line := range summarizeInventoryCount(count).Lines {
+			if line.CountedQuantity == nil || line.Variance == 0 {
+				continue
+			}
+
+			if _, err = moveStock(ctx, i.storage, line.ProductID, line.Variance, models.StockMovementInventory, reason); err != nil {
+				return err
+			}
+
+			// a shortage leaves the lots too, expired ones first
+			if line.Variance < 0 {
+				if _, err = takeLots(ctx, i.storage, line.ProductID, -line.Variance, true, models.StockMovementInventory, count.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		i.log.Error("error in service layer while approving inventory count", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+
+	return i.Get(ctx, key)
+}
+
+// Cancel closes the count without changing the stock.
+func (i inventoryCountService) Cancel(ctx context.Context, key models.PrimaryKey, cancelledBy string) (models.InventoryCount, error) {
+	err := i.storage.WithTx(ctx, func(ctx context.Context) error {
+		_, err := i.close(ctx, key.ID, models.InventoryCountCancelled, cancelledBy)
+
+		return err
+	})
+	if err != nil {
+		i.log.Error("error in service layer while cancelling inventory count", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+
+	return i.Get(ctx, key)
+}
+
+// close moves an open count to the status and returns it as it was before.
+func (i inventoryCountService) close(ctx context.Context, id, status, closedBy string) (models.InventoryCount, error) {
+	count, err := i.storage.InventoryCount().GetByID(ctx, models.PrimaryKey{ID: id})
+	if err != nil {
+		return models.InventoryCount{}, err
+	}
+
+	if err = i.storage.InventoryCount().Close(ctx, id, status, closedBy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.InventoryCount{}, ErrInventoryCountClosed
+		}
+
+		return models.InventoryCount{}, err
+	}
+
+	if err = recordAudit(ctx, i.storage, models.EntityInventoryCount, models.AuditUpdate, id,
+		map[string]string{"status": count.Status}, map[string]string{"status": status}); err != nil {
+		return models.InventoryCount{}, err
+	}
+
+	return count, nil
+}
+
+// summarizeInventoryCount works out the variance of every counted line and its cost,
+// shortages and surpluses are totalled apart and together.
+func summarizeInventoryCount(count models.InventoryCount) models.InventoryCount {
+	count.CountedLines, count.ShortageCost, count.SurplusCost = 0, 0, 0
+
+	for index, line := range count.Lines {
+		if line.CountedQuantity == nil {
+			line.Variance, line.VarianceCost = 0, 0
+			count.Lines[index] = line
+
+			continue
+		}
+
+		line.Variance = *line.CountedQuantity - line.ExpectedQuantity
+		line.VarianceCost = line.Cost.MulQuantity(line.Variance)
+
+		if line.VarianceCost < 0 {
+			count.ShortageCost -= line.VarianceCost
+		} else {
+			count.SurplusCost += line.VarianceCost
+		}
+
+		count.CountedLines++
+		count.Lines[index] = line
+	}
+
+	count.VarianceCost = money.Sum(count.SurplusCost, -count.ShortageCost)
+
+	return count
+}
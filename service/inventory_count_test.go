@@ -0,0 +1,35 @@
+package service
+
+import (
+	"test/api/models"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestSummarizeInventoryCount(t *testing.T) {
+	counted := func(quantity measure.Quantity) *measure.Quantity {
+		return &quantity
+	}
+
+	count := summarizeInventoryCount(models.InventoryCount{
+		Lines: []models.InventoryCountLine{
+			{ProductID: "short", ExpectedQuantity: measure.Whole(10), CountedQuantity: counted(measure.Whole(8)), Cost: 500},
+			{ProductID: "surplus", ExpectedQuantity: 1500, CountedQuantity: counted(1750), Cost: 2000},
+			{ProductID: "exact", ExpectedQuantity: measure.Whole(3), CountedQuantity: counted(measure.Whole(3)), Cost: 100},
+			{ProductID: "uncounted", ExpectedQuantity: measure.Whole(4), Cost: 100},
+		},
+	})
+
+	assert.Equal(t, count.CountedLines, 3)
+	assert.Equal(t, count.Lines[0].Variance, measure.Whole(-2))
+	assert.Equal(t, count.Lines[0].VarianceCost, money.Amount(-1000))
+	assert.Equal(t, count.Lines[1].Variance, measure.Quantity(250))
+	assert.Equal(t, count.Lines[1].VarianceCost, money.Amount(500))
+	assert.Equal(t, count.Lines[3].Variance, measure.Quantity(0))
+	assert.Equal(t, count.ShortageCost, money.Amount(1000))
+	assert.Equal(t, count.SurplusCost, money.Amount(500))
+	assert.Equal(t, count.VarianceCost, money.Amount(-500))
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"test/storage"
+)
+
+var ErrInvalidLotFilter = errors.New("days should not be negative")
+
+type lotService struct {
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewLotService(storage storage.IStorage, log logger.ILogger) lotService {
+	return lotService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+// GetExpiring returns the lots of the branch with stock left that expire within days, expired ones included.
+func (l lotService) GetExpiring(ctx context.Context, branchID string, days int) (models.LotsResponse, error) {
+	if days < 0 {
+		return models.LotsResponse{}, ErrInvalidLotFilter
+	}
+
+	if _, err := l.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: branchID}); err != nil {
+		l.log.Error("error in service layer while getting branch by id", logger.Error(err))
+
+		return models.LotsResponse{}, err
+	}
+
+	lots, err := l.storage.Lot().GetList(ctx, models.LotFilter{BranchID: branchID, ExpiringWithinDays: &days})
+	if err != nil {
+		l.log.Error("error in service layer while getting expiring lots", logger.Error(err))
+
+		return models.LotsResponse{}, err
+	}
+
+	return lots, nil
+}
+
+// GetByProduct returns the lots of the product with stock left.
+func (l lotService) GetByProduct(ctx context.Context, productID string) (models.LotsResponse, error) {
+	if _, err := l.storage.Product().GetByID(ctx, models.PrimaryKey{ID: productID}); err != nil {
+		l.log.Error("error in service layer while getting product by id", logger.Error(err))
+
+		return models.LotsResponse{}, err
+	}
+
+	lots, err := l.storage.Lot().GetList(ctx, models.LotFilter{ProductID: productID})
+	if err != nil {
+		l.log.Error("error in service layer while getting lots of product", logger.Error(err))
+
+		return models.LotsResponse{}, err
+	}
+
+	return lots, nil
+}
+
+// takeLots takes the quantity leaving stock out of the lots of the product, the ones expiring first first,
+// and returns what it took. Expired lots are only taken from with withExpired, sales never take them.
+// It is run inside the transaction that decreases the stock.
+func takeLots(ctx context.Context, store storage.IStorage, productID string, quantity measure.Quantity, withExpired bool, kind, referenceID string) ([]models.TakeLot, error) {
+	lots, err := store.Lot().LockAvailable(ctx, productID, withExpired)
+	if err != nil {
+		return nil, err
+	}
+
+	takes := allocateLots(lots, quantity)
+	for index := range takes {
+		takes[index].Kind, takes[index].ReferenceID = kind, referenceID
+		if err = store.Lot().Take(ctx, takes[index]); err != nil {
+			return nil, err
+		}
+	}
+
+	return takes, nil
+}
+
+// allocateLots splits the quantity over the lots in their order. What the lots do not cover
+// comes from stock received before lots were kept and is not allocated.
+func allocateLots(lots []models.Lot, quantity measure.Quantity) []models.TakeLot {
+	takes := []models.TakeLot{}
+	for _, lot := range lots {
+		if quantity <= 0 {
+			break
+		}
+
+		taken := min(lot.Remaining, quantity)
+		takes = append(takes, models.TakeLot{LotID: lot.ID, Quantity: taken, Cost: lot.Cost})
+		quantity -= taken
+	}
+
+	return takes
+}
+
+// saleCost is the cost of goods of a sold quantity: with fifo the costs of the lots the sale took,
+// with average the average cost of the product. Stock received before lots were kept costs the average cost.
+func saleCost(method string, takes []models.TakeLot, quantity measure.Quantity, averageCost money.Amount) money.Amount {
+	if method == config.CostAverage {
+		return averageCost.MulQuantity(quantity)
+	}
+
+	cost := money.Amount(0)
+	for _, take := range takes {
+		cost += take.Cost.MulQuantity(take.Quantity)
+		quantity -= take.Quantity
+	}
+
+	return cost + averageCost.MulQuantity(quantity)
+}
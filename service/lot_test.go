@@ -0,0 +1,43 @@
+package service
+
+import (
+	"test/api/models"
+	"test/config"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestAllocateLots(t *testing.T) {
+	lots := []models.Lot{
+		{ID: "expiring first", Remaining: measure.Whole(2), Cost: 100},
+		{ID: "expiring later", Remaining: 1500, Cost: 200},
+		{ID: "not expiring", Remaining: measure.Whole(5), Cost: 300},
+	}
+
+	assert.Equal(t, allocateLots(lots, 2500), []models.TakeLot{
+		{LotID: "expiring first", Quantity: measure.Whole(2), Cost: 100},
+		{LotID: "expiring later", Quantity: 500, Cost: 200},
+	})
+
+	assert.Equal(t, allocateLots(lots, measure.Whole(10)), []models.TakeLot{
+		{LotID: "expiring first", Quantity: measure.Whole(2), Cost: 100},
+		{LotID: "expiring later", Quantity: 1500, Cost: 200},
+		{LotID: "not expiring", Quantity: measure.Whole(5), Cost: 300},
+	})
+
+	assert.Equal(t, len(allocateLots(nil, measure.Whole(1))), 0)
+}
+
+func TestSaleCost(t *testing.T) {
+	takes := []models.TakeLot{
+		{LotID: "older", Quantity: measure.Whole(2), Cost: 1000},
+		{LotID: "newer", Quantity: 500, Cost: 1300},
+	}
+
+	assert.Equal(t, saleCost(config.CostFIFO, takes, 2500, 1200), money.Amount(2650))
+	assert.Equal(t, saleCost(config.CostFIFO, takes, measure.Whole(4), 1200), money.Amount(4450))
+	assert.Equal(t, saleCost(config.CostAverage, takes, 2500, 1200), money.Amount(3000))
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+type loyaltyService struct {
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewLoyaltyService(storage storage.IStorage, log logger.ILogger) loyaltyService {
+	return loyaltyService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+func (l loyaltyService) Get(ctx context.Context, request models.GetListRequest) (models.LoyaltyResponse, error) {
+	customer, err := l.storage.User().GetByID(ctx, models.PrimaryKey{ID: request.UserID})
+	if err != nil {
+		l.log.Error("error in service layer while getting customer by id", logger.Error(err))
+
+		return models.LoyaltyResponse{}, err
+	}
+
+	if err = l.storage.Loyalty().Expire(ctx, customer.ID); err != nil {
+		l.log.Error("error in service layer while expiring loyalty points", logger.Error(err))
+
+		return models.LoyaltyResponse{}, err
+	}
+
+	loyalty, err := l.storage.Loyalty().GetHistory(ctx, request)
+	if err != nil {
+		l.log.Error("error in service layer while getting loyalty history", logger.Error(err))
+
+		return models.LoyaltyResponse{}, err
+	}
+
+	loyalty.Balance, err = l.storage.Loyalty().GetBalance(ctx, customer.ID)
+	if err != nil {
+		l.log.Error("error in service layer while getting loyalty balance", logger.Error(err))
+
+		return models.LoyaltyResponse{}, err
+	}
+
+	return loyalty, nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"test/api/models"
+)
+
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// decodePatch reads a merge patch into the update model of the row and returns the fields it changes.
+// The required fields can be changed but not cleared with null.
+func decodePatch(patch models.Patch, update interface{}, required ...string) ([]string, error) {
+	fields, err := patch.Fields()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	nullFields, err := patch.NullFields()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	for _, field := range nullFields {
+		if slices.Contains(required, field) {
+			return nil, fmt.Errorf("%w: %s is required and can not be null", ErrInvalidPatch, field)
+		}
+	}
+
+	if err = patch.Decode(update); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	return fields, nil
+}
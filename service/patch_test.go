@@ -0,0 +1,26 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"test/api/models"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestDecodePatchRequired(t *testing.T) {
+	patch := models.Patch{Version: 1, Document: json.RawMessage(`{"version": 1, "price": null}`)}
+
+	_, err := decodePatch(patch, &models.UpdateProduct{}, "price", "quantity")
+	assert.Equal(t, errors.Is(err, ErrInvalidPatch), true)
+
+	// optional fields are cleared with null
+	patch.Document = json.RawMessage(`{"version": 1, "tax_rate": null, "price": 500}`)
+
+	product := models.UpdateProduct{}
+	fields, err := decodePatch(patch, &product, "price", "quantity")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, fields, []string{"price", "tax_rate"})
+	assert.Equal(t, product.TaxRate == nil, true)
+}
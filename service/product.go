@@ -2,26 +2,90 @@ package service
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"test/api/models"
+	"test/config"
+	"test/pkg/audit"
+	"test/pkg/check"
 	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/money"
 	"test/storage"
+	"time"
 )
 
+// maxSearchLimit caps the number of products returned by Search.
+const maxSearchLimit = 50
+
 type productService struct {
+	cfg     config.Config
 	storage storage.IStorage
 	log     logger.ILogger
 }
 
-func NewProductService(storage storage.IStorage, log logger.ILogger) productService {
+func NewProductService(cfg config.Config, storage storage.IStorage, log logger.ILogger) productService {
 	return productService{
+		cfg:     cfg,
 		storage: storage,
 		log:     log,
 	}
 }
 
 func (p productService) Create(ctx context.Context, product models.CreateProduct) (models.Product, error) {
-	id, err := p.storage.Product().Create(ctx, product)
+	if err := validateTaxRate(product.TaxRate); err != nil {
+		return models.Product{}, err
+	}
+
+	var err error
+	if product.Barcodes, err = normalizeBarcodes(product.Barcodes); err != nil {
+		return models.Product{}, err
+	}
+
+	if product.Unit == "" {
+		product.Unit = defaultUnit
+	}
+
+	if err := p.validateUnit(ctx, product.Unit, product.Quantity); err != nil {
+		return models.Product{}, err
+	}
+
+	if product.ParentID != "" {
+		parent, err := p.storage.Product().GetByID(ctx, models.PrimaryKey{ID: product.ParentID})
+		if err != nil {
+			p.log.Error("error in service layer while getting parent product by id", logger.Error(err))
+
+			return models.Product{}, err
+		}
+
+		// variants are one level deep, a variant cannot have variants of its own
+		if parent.ParentID != "" {
+			return models.Product{}, errors.New("parent product is a variant itself")
+		}
+
+		if len(product.Attributes) == 0 {
+			return models.Product{}, errors.New("variant should have attributes")
+		}
+	}
+
+	id, err := audited(ctx, p.storage, models.EntityProduct, models.AuditCreate, "", loader(p.storage.Product().GetByID), func(ctx context.Context) (string, error) {
+		id, err := p.storage.Product().Create(ctx, product)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = p.storage.ProductPrice().Create(ctx, models.CreateProductPrice{
+			ProductID:     id,
+			Price:         product.Price,
+			OriginalPrice: product.OriginalPrice,
+			CreatedBy:     product.CreatedBy,
+		})
+
+		return id, err
+	})
 	if err != nil {
 		p.log.Error("error in service layer while creating product", logger.Error(err))
 		return models.Product{}, err
@@ -48,7 +112,30 @@ func (p productService) Get(ctx context.Context, key models.PrimaryKey) (models.
 	return product, nil
 }
 
+func (p productService) GetByBarcode(ctx context.Context, barcode string) (models.Product, error) {
+	if err := check.ValidateBarcode(barcode); err != nil {
+		return models.Product{}, err
+	}
+
+	product, err := p.storage.Product().GetByBarcode(ctx, check.NormalizeBarcode(barcode))
+	if err != nil {
+		p.log.Error("error in service layer while getting by barcode", logger.Error(err))
+
+		return models.Product{}, err
+	}
+
+	return product, nil
+}
+
 func (p productService) GetList(ctx context.Context, request models.GetListRequest) (models.ProductResponse, error) {
+	if err := validateProductListRequest(request); err != nil {
+		return models.ProductResponse{}, err
+	}
+
+	if request.Stock == models.StockLow && request.LowStockThreshold == 0 {
+		request.LowStockThreshold = measure.Quantity(math.Round(p.cfg.LowStockThreshold * measure.Scale))
+	}
+
 	products, err := p.storage.Product().GetList(ctx, request)
 	if err != nil {
 		p.log.Error("error in service layer while getting list", logger.Error(err))
@@ -59,9 +146,77 @@ func (p productService) GetList(ctx context.Context, request models.GetListReque
 	return products, nil
 }
 
+// Search returns products best matching the words typed so far, for autocomplete at the cash register.
+func (p productService) Search(ctx context.Context, request models.GetListRequest) (models.ProductResponse, error) {
+	request.Search = strings.TrimSpace(request.Search)
+	if request.Search == "" {
+		return models.ProductResponse{}, errors.New("search query is required")
+	}
+
+	if request.Limit <= 0 || request.Limit > maxSearchLimit {
+		request.Limit = maxSearchLimit
+	}
+
+	products, err := p.storage.Product().FullTextSearch(ctx, request)
+	if err != nil {
+		p.log.Error("error in service layer while searching products", logger.Error(err))
+
+		return models.ProductResponse{}, err
+	}
+
+	return products, nil
+}
+
 func (p productService) Update(ctx context.Context, product models.UpdateProduct) (models.Product, error) {
-	id, err := p.storage.Product().Update(ctx, product)
+	if err := validateTaxRate(product.TaxRate); err != nil {
+		return models.Product{}, err
+	}
+
+	var err error
+	if product.Barcodes, err = normalizeBarcodes(product.Barcodes); err != nil {
+		return models.Product{}, err
+	}
+
+	oldProduct, err := p.storage.Product().GetByID(ctx, models.PrimaryKey{ID: product.ID})
 	if err != nil {
+		p.log.Error("error in service layer while getting by id", logger.Error(err))
+
+		return models.Product{}, err
+	}
+
+	if oldProduct.ParentID != "" && len(product.Attributes) == 0 {
+		return models.Product{}, errors.New("variant should have attributes")
+	}
+
+	if product.Unit == "" {
+		product.Unit = oldProduct.Unit
+	}
+
+	if err = p.validateUnit(ctx, product.Unit, product.Quantity); err != nil {
+		return models.Product{}, err
+	}
+
+	id, err := audited(ctx, p.storage, models.EntityProduct, models.AuditUpdate, product.ID, loader(p.storage.Product().GetByID), func(ctx context.Context) (string, error) {
+		id, err := p.storage.Product().Update(ctx, product)
+		if err != nil {
+			return id, err
+		}
+
+		if err = setQuantity(ctx, p.storage, id, product.Quantity); err != nil || (oldProduct.Price == product.Price && oldProduct.OriginalPrice == product.OriginalPrice) {
+			return id, err
+		}
+
+		_, err = p.storage.ProductPrice().Create(ctx, models.CreateProductPrice{
+			ProductID:     id,
+			Price:         product.Price,
+			OriginalPrice: product.OriginalPrice,
+			CreatedBy:     product.UpdatedBy,
+		})
+
+		return id, err
+	})
+	if err != nil {
+		err = versionConflict(ctx, err, loader(p.storage.Product().GetByID), product.ID)
 		p.log.Error("error in service layer while update", logger.Error(err))
 
 		return models.Product{}, err
@@ -77,110 +232,406 @@ func (p productService) Update(ctx context.Context, product models.UpdateProduct
 	return updatedProduct, nil
 }
 
-func (p productService) Delete(ctx context.Context, key models.PrimaryKey) error {
-	err := p.storage.Product().Delete(ctx, key)
+// Patch changes only the fields of the product present in the merge patch, a changed price is kept in its history.
+func (p productService) Patch(ctx context.Context, patch models.Patch) (models.Product, error) {
+	product := models.UpdateProduct{}
+	fields, err := decodePatch(patch, &product, "name", "price", "original_price", "quantity")
+	if err != nil {
+		return models.Product{}, err
+	}
 
-	return err
-}
+	product.ID, product.Version = patch.ID, patch.Version
 
-func (p productService) StartSellNew(ctx context.Context, request models.SellRequest) (models.ProductSell, error) {
-	check := models.Check{
-		Products: make([]models.Product, 0),
-		TotalSum: 0,
+	if err = validateTaxRate(product.TaxRate); err != nil {
+		return models.Product{}, err
 	}
 
-	productSell, err := p.storage.Product().Search(ctx, request.Products)
+	oldProduct, err := p.storage.Product().GetByID(ctx, models.PrimaryKey{ID: product.ID})
 	if err != nil {
-		p.log.Error("error in service layer while searching product", logger.Error(err))
+		p.log.Error("error in service layer while getting by id", logger.Error(err))
 
-		return models.ProductSell{}, err
+		return models.Product{}, err
 	}
 
-	basket, err := p.storage.Basket().GetByID(ctx, models.PrimaryKey{ID: request.BasketID})
+	if slices.Contains(fields, "barcodes") {
+		if product.Barcodes, err = normalizeBarcodes(product.Barcodes); err != nil {
+			return models.Product{}, err
+		}
+	}
+
+	if slices.Contains(fields, "attributes") && oldProduct.ParentID != "" && len(product.Attributes) == 0 {
+		return models.Product{}, errors.New("variant should have attributes")
+	}
+
+	// the unit and the quantity are checked together, the one not in the patch is kept
+	if slices.Contains(fields, "unit") || slices.Contains(fields, "quantity") {
+		unit, quantity := oldProduct.Unit, oldProduct.Quantity
+		if product.Unit != "" {
+			unit = product.Unit
+		}
+		if slices.Contains(fields, "quantity") {
+			quantity = product.Quantity
+		}
+
+		if err = p.validateUnit(ctx, unit, quantity); err != nil {
+			return models.Product{}, err
+		}
+	}
+
+	price, originalPrice := oldProduct.Price, oldProduct.OriginalPrice
+	if slices.Contains(fields, "price") {
+		price = product.Price
+	}
+	if slices.Contains(fields, "original_price") {
+		originalPrice = product.OriginalPrice
+	}
+
+	id, err := audited(ctx, p.storage, models.EntityProduct, models.AuditUpdate, product.ID, loader(p.storage.Product().GetByID), func(ctx context.Context) (string, error) {
+		id, err := p.storage.Product().Patch(ctx, product, fields)
+		if err != nil {
+			return id, err
+		}
+
+		if slices.Contains(fields, "quantity") {
+			if err = setQuantity(ctx, p.storage, id, product.Quantity); err != nil {
+				return id, err
+			}
+		}
+
+		if oldProduct.Price == price && oldProduct.OriginalPrice == originalPrice {
+			return id, nil
+		}
+
+		_, err = p.storage.ProductPrice().Create(ctx, models.CreateProductPrice{
+			ProductID:     id,
+			Price:         price,
+			OriginalPrice: originalPrice,
+			CreatedBy:     audit.MetaFrom(ctx).ActorID,
+		})
+
+		return id, err
+	})
 	if err != nil {
-		p.log.Error("error in service layer while getting basket by id", logger.Error(err))
+		err = versionConflict(ctx, err, loader(p.storage.Product().GetByID), product.ID)
+		p.log.Error("error in service layer while patching product", logger.Error(err))
 
-		return models.ProductSell{}, err
+		return models.Product{}, err
 	}
 
-	customer, err := p.storage.User().GetByID(ctx, models.PrimaryKey{ID: basket.CustomerID})
+	patchedProduct, err := p.storage.Product().GetByID(ctx, models.PrimaryKey{ID: id})
 	if err != nil {
-		p.log.Error("error in service layer while getting user by id", logger.Error(err))
+		p.log.Error("error in service layer while getting by id", logger.Error(err))
 
-		return models.ProductSell{}, err
+		return models.Product{}, err
 	}
 
-	totalSum, profit := 0, float32(0.0)
-	basketProducts := map[string]int{}
+	return patchedProduct, nil
+}
 
-	for productID, price := range productSell.SelectedProducts.Products {
-		customerQuantity := request.Products[productID]
-		totalSum += price * customerQuantity
+func (p productService) Delete(ctx context.Context, key models.PrimaryKey) error {
+	return deleteChecked(ctx, p.cfg, p.storage, p.log, models.EntityProduct, key.ID, loader(p.storage.Product().GetByID), func(ctx context.Context) error {
+		return p.storage.Product().Delete(ctx, key)
+	})
+}
 
-		//profit logic
-		profit += float32(customerQuantity*price - productSell.ProductPrices[productID])
-		basketProducts[productID] = customerQuantity
+func (p productService) StartSellNew(ctx context.Context, request models.SellRequest) (models.ProductSell, error) {
+	check := models.Check{
+		Products:     make([]models.CheckProduct, 0),
+		TaxInclusive: p.cfg.PricesIncludeTax,
 	}
 
-	if customer.Cash < uint(totalSum) {
-		p.log.Error("error in service layer while not enghuf customer cash", logger.Error(err))
+	basket, err := p.storage.Basket().GetByID(ctx, models.PrimaryKey{ID: request.BasketID})
+	if err != nil {
+		p.log.Error("error in service layer while getting basket by id", logger.Error(err))
 
 		return models.ProductSell{}, err
 	}
 
-	if err = p.storage.User().UpdateCustomerCash(ctx, customer.ID, totalSum); err != nil {
-		p.log.Error("error in service layer while updating customer cash", logger.Error(err))
+	customer, err := p.storage.User().GetByID(ctx, models.PrimaryKey{ID: basket.CustomerID})
+	if err != nil {
+		p.log.Error("error in service layer while getting user by id", logger.Error(err))
 
 		return models.ProductSell{}, err
 	}
 
-	if err = p.storage.Product().TakeProducts(ctx, basketProducts); err != nil {
-		p.log.Error("error in service layer while taking product", logger.Error(err))
+	// prices are taken from the selling branch, customer's branch by default
+	branchID := request.BranchID
+	if branchID == "" {
+		branchID = customer.BranchID
+	}
+
+	branch, err := p.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: branchID})
+	if err != nil {
+		p.log.Error("error in service layer while getting branch by id", logger.Error(err))
 
 		return models.ProductSell{}, err
 	}
 
-	if err = p.storage.BasketProduct().AddProducts(ctx, basket.ID, basketProducts); err != nil {
-		p.log.Error("error in service later while adding products to basket", logger.Error(err))
+	// customer cash is kept in the currency of the customer's branch
+	if branchID != customer.BranchID {
+		customerBranch, err := p.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: customer.BranchID})
+		if err != nil {
+			p.log.Error("error in service layer while getting customer branch by id", logger.Error(err))
 
-		return models.ProductSell{}, err
+			return models.ProductSell{}, err
+		}
+
+		if customerBranch.Currency != branch.Currency {
+			return models.ProductSell{}, money.ErrCurrencyMismatch
+		}
 	}
 
-	if err = p.storage.Store().AddProfit(ctx, profit, customer.BranchID); err != nil {
-		p.log.Error("error in service layer while adding amount of profit", logger.Error(err))
+	check.Currency = branch.Currency
 
+	if err = p.resolveBarcodes(ctx, &request); err != nil {
 		return models.ProductSell{}, err
 	}
 
-	// dealer
+	productSell, err := p.storage.Product().Search(ctx, request.Products, branchID)
+	if err != nil {
+		p.log.Error("error in service layer while searching product", logger.Error(err))
+
+		return models.ProductSell{}, err
+	}
 
-	//check
 	productIDs := []string{}
+	basketProducts := map[string]measure.Quantity{}
 	for productID := range productSell.SelectedProducts.Products {
 		productIDs = append(productIDs, productID)
+		basketProducts[productID] = request.Products[productID]
 	}
 
-	productsResp, err := p.storage.Product().GetListByIDs(ctx, productIDs)
+	productsResp, err := p.storage.Product().GetListByIDs(ctx, productIDs, branchID)
 	if err != nil {
 		p.log.Error("error in service layer while getting products by ids", logger.Error(err))
 
 		return models.ProductSell{}, err
 	}
 
-	js, _ := json.Marshal(productsResp.Products)
+	units, err := unitsByCode(ctx, p.storage)
+	if err != nil {
+		p.log.Error("error in service layer while getting units", logger.Error(err))
+
+		return models.ProductSell{}, err
+	}
 
-	json.Unmarshal(js, &check.Products)
+	for _, product := range productsResp.Products {
+		if err = validateQuantity(units[product.Unit], request.Products[product.ID]); err != nil {
+			return models.ProductSell{}, err
+		}
 
-	totalSum = 0
-	for i, checkProduct := range check.Products {
-		quantity := request.Products[checkProduct.ID]
+		// every line of the check is summed in the currency of the selling branch
+		if product.Currency != check.Currency {
+			return models.ProductSell{}, fmt.Errorf("%w: product %s is priced in %s, the branch sells in %s",
+				money.ErrCurrencyMismatch, product.ID, product.Currency, check.Currency)
+		}
+	}
 
-		check.Products[i].Quantity = quantity
+	// products without enough stock are backordered for the customer instead of delivered by the dealer,
+	// at the price of the selling branch paid when the backorder is collected
+	backorders := []models.CreateBackorder{}
+	if request.Backorder && len(productSell.NotEnoughProducts) > 0 {
+		backorderIDs := make([]string, 0, len(productSell.NotEnoughProducts))
+		for productID := range productSell.NotEnoughProducts {
+			backorderIDs = append(backorderIDs, productID)
+		}
+
+		backorderProducts, err := p.storage.Product().GetListByIDs(ctx, backorderIDs, branchID)
+		if err != nil {
+			p.log.Error("error in service layer while getting backordered products by ids", logger.Error(err))
+
+			return models.ProductSell{}, err
+		}
+
+		for _, product := range backorderProducts.Products {
+			if err = validateQuantity(units[product.Unit], productSell.NotEnoughProducts[product.ID]); err != nil {
+				return models.ProductSell{}, err
+			}
+
+			if product.Currency != check.Currency {
+				return models.ProductSell{}, fmt.Errorf("%w: product %s is priced in %s, the branch sells in %s",
+					money.ErrCurrencyMismatch, product.ID, product.Currency, check.Currency)
+			}
+
+			taxRate, ok := productSell.TaxRates[product.ID]
+			if !ok {
+				taxRate = p.cfg.DefaultTaxRate
+			}
+
+			backorders = append(backorders, models.CreateBackorder{
+				CustomerID: customer.ID,
+				ProductID:  product.ID,
+				BranchID:   branchID,
+				Quantity:   productSell.NotEnoughProducts[product.ID],
+				Price:      product.Price,
+				TaxRate:    taxRate,
+			})
+		}
+	}
 
-		totalSum += quantity * checkProduct.Price
+	//check
+	for _, product := range productsResp.Products {
+		quantity := request.Products[product.ID]
+
+		taxRate, ok := productSell.TaxRates[product.ID]
+		if !ok {
+			taxRate = p.cfg.DefaultTaxRate
+		}
+
+		netSum, taxSum, grossSum := calculateTax(product.Price.MulQuantity(quantity), taxRate, check.TaxInclusive)
+
+		check.Products = append(check.Products, models.CheckProduct{
+			ID:       product.ID,
+			Name:     product.Name,
+			Price:    product.Price,
+			Quantity: quantity,
+			Unit:     product.Unit,
+			TaxRate:  taxRate,
+			NetSum:   netSum,
+			TaxSum:   taxSum,
+			GrossSum: grossSum,
+		})
+
+		check.NetSum += netSum
+		check.TaxSum += taxSum
+		check.TotalSum += grossSum
 	}
 
-	check.TotalSum = totalSum
+	// loyalty points are spent as payment, one point per minor unit of the sum
+	pointsUsed := 0
+	if request.LoyaltyPoints > 0 {
+		if err = p.storage.Loyalty().Expire(ctx, customer.ID); err != nil {
+			p.log.Error("error in service layer while expiring loyalty points", logger.Error(err))
+
+			return models.ProductSell{}, err
+		}
+
+		balance, err := p.storage.Loyalty().GetBalance(ctx, customer.ID)
+		if err != nil {
+			p.log.Error("error in service layer while getting loyalty balance", logger.Error(err))
+
+			return models.ProductSell{}, err
+		}
+
+		if request.LoyaltyPoints > balance {
+			p.log.Error("error in service layer not enough loyalty points", logger.Int("balance", balance))
+
+			return models.ProductSell{}, errors.New("not enough loyalty points")
+		}
+
+		pointsUsed = request.LoyaltyPoints
+		if money.Amount(pointsUsed) > check.TotalSum {
+			pointsUsed = int(check.TotalSum)
+		}
+	}
+
+	paidSum := check.TotalSum - money.Amount(pointsUsed)
+
+	if customer.Cash < paidSum {
+		p.log.Error("error in service layer while not enghuf customer cash", logger.Any("paid_sum", paidSum))
+
+		return models.ProductSell{}, errors.New("not enough customer cash")
+	}
+
+	// the sale is written at once, with its audit log entry on the basket
+	pointsEarned := int(paidSum.Percent(p.cfg.LoyaltyPercent))
+	if err = p.storage.WithTx(ctx, func(ctx context.Context) error {
+		if err = p.storage.User().UpdateCustomerCash(ctx, customer.ID, paidSum); err != nil {
+			p.log.Error("error in service layer while updating customer cash", logger.Error(err))
+
+			return err
+		}
+
+		if err = p.storage.Product().TakeProducts(ctx, basketProducts); err != nil {
+			p.log.Error("error in service layer while taking product", logger.Error(err))
+
+			return err
+		}
+
+		// sold goods leave the lots expiring first, expired lots are not sold, and every line
+		// is costed with the configured method; tax is not a part of profit
+		profit := check.NetSum - money.Amount(pointsUsed)
+		for index, product := range check.Products {
+			takes, err := takeLots(ctx, p.storage, product.ID, product.Quantity, false, models.StockMovementSale, basket.ID)
+			if err != nil {
+				p.log.Error("error in service layer while taking product lots", logger.Error(err))
+
+				return err
+			}
+
+			check.Products[index].Cost = saleCost(p.cfg.CostMethod, takes, product.Quantity, productSell.ProductCosts[product.ID])
+			profit -= check.Products[index].Cost
+		}
+
+		if err = p.storage.BasketProduct().AddProducts(ctx, basket.ID, check.Products); err != nil {
+			p.log.Error("error in service later while adding products to basket", logger.Error(err))
+
+			return err
+		}
+
+		if err = p.storage.Basket().UpdateSums(ctx, models.UpdateBasketSums{
+			ID:           basket.ID,
+			NetSum:       check.NetSum,
+			TaxSum:       check.TaxSum,
+			TotalSum:     check.TotalSum,
+			TaxInclusive: check.TaxInclusive,
+			Currency:     check.Currency,
+		}); err != nil {
+			p.log.Error("error in service layer while updating basket sums", logger.Error(err))
+
+			return err
+		}
+
+		// the profit belongs to the selling branch
+		if err = p.storage.Store().AddProfit(ctx, profit, branchID); err != nil {
+			p.log.Error("error in service layer while adding amount of profit", logger.Error(err))
+
+			return err
+		}
+
+		if len(backorders) > 0 {
+			if check.Backorders, err = createBackorders(ctx, p.storage, basket.ID, backorders); err != nil {
+				p.log.Error("error in service layer while creating backorders", logger.Error(err))
+
+				return err
+			}
+		}
+
+		if pointsUsed > 0 {
+			if err = p.storage.Loyalty().Redeem(ctx, models.RedeemLoyaltyPoints{
+				UserID:   customer.ID,
+				BasketID: basket.ID,
+				Points:   pointsUsed,
+			}); err != nil {
+				p.log.Error("error in service layer while redeeming loyalty points", logger.Error(err))
+
+				return err
+			}
+		}
+
+		if pointsEarned > 0 {
+			if err = p.storage.Loyalty().Earn(ctx, models.EarnLoyaltyPoints{
+				UserID:   customer.ID,
+				BasketID: basket.ID,
+				Points:   pointsEarned,
+				TTLDays:  p.cfg.LoyaltyPointsTTLDays,
+			}); err != nil {
+				p.log.Error("error in service layer while earning loyalty points", logger.Error(err))
+
+				return err
+			}
+		}
+
+		return recordAudit(ctx, p.storage, models.EntityBasket, models.AuditUpdate, basket.ID, nil, check)
+	}); err != nil {
+		return models.ProductSell{}, err
+	}
+
+	// dealer
+
+	check.PaidSum = paidSum
+	check.LoyaltyPointsUsed = pointsUsed
+	check.LoyaltyPointsEarned = pointsEarned
 
 	productSell.Check = check
 
@@ -188,3 +639,110 @@ func (p productService) StartSellNew(ctx context.Context, request models.SellReq
 
 	return productSell, nil
 }
+
+func validateProductListRequest(request models.GetListRequest) error {
+	switch request.Stock {
+	case "", models.StockIn, models.StockOut, models.StockLow:
+	default:
+		return fmt.Errorf("unknown stock filter %s", request.Stock)
+	}
+
+	switch request.SortBy {
+	case "", "name", "price", "quantity", "created_at":
+	default:
+		return fmt.Errorf("cannot sort products by %s", request.SortBy)
+	}
+
+	switch request.SortOrder {
+	case "", "asc", "desc":
+	default:
+		return fmt.Errorf("unknown sort order %s", request.SortOrder)
+	}
+
+	if request.MaxPrice > 0 && request.MinPrice > request.MaxPrice {
+		return errors.New("min_price is greater than max_price")
+	}
+
+	for _, date := range []string{request.CreatedFrom, request.CreatedTo} {
+		if date == "" {
+			continue
+		}
+
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return fmt.Errorf("date %s should be in %s format", date, time.DateOnly)
+		}
+	}
+
+	return nil
+}
+
+// validateUnit checks that the unit exists and allows the quantity.
+func (p productService) validateUnit(ctx context.Context, code string, quantity measure.Quantity) error {
+	unit, err := p.storage.Unit().GetByCode(ctx, code)
+	if err != nil {
+		p.log.Error("error in service layer while getting unit by code", logger.Error(err))
+
+		return fmt.Errorf("unknown unit %s", code)
+	}
+
+	return validateQuantity(unit, quantity)
+}
+
+// resolveBarcodes moves quantities of products sold by barcode into request.Products.
+func (p productService) resolveBarcodes(ctx context.Context, request *models.SellRequest) error {
+	if len(request.Barcodes) == 0 {
+		return nil
+	}
+
+	barcodes := make([]string, 0, len(request.Barcodes))
+	for barcode := range request.Barcodes {
+		barcodes = append(barcodes, check.NormalizeBarcode(barcode))
+	}
+
+	productIDs, err := p.storage.Product().GetIDsByBarcodes(ctx, barcodes)
+	if err != nil {
+		p.log.Error("error in service layer while getting products by barcodes", logger.Error(err))
+
+		return err
+	}
+
+	if request.Products == nil {
+		request.Products = make(map[string]measure.Quantity, len(request.Barcodes))
+	}
+
+	for barcode, quantity := range request.Barcodes {
+		productID, ok := productIDs[check.NormalizeBarcode(barcode)]
+		if !ok {
+			return fmt.Errorf("product with barcode %s not found", barcode)
+		}
+
+		request.Products[productID] += quantity
+	}
+
+	return nil
+}
+
+// normalizeBarcodes validates the barcodes and returns them normalized, nil stays nil.
+func normalizeBarcodes(barcodes []string) ([]string, error) {
+	if barcodes == nil {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(barcodes))
+	seen := make(map[string]bool, len(barcodes))
+	for _, barcode := range barcodes {
+		if err := check.ValidateBarcode(barcode); err != nil {
+			return nil, fmt.Errorf("barcode %s: %w", barcode, err)
+		}
+
+		code := check.NormalizeBarcode(barcode)
+		if seen[code] {
+			return nil, fmt.Errorf("barcode %s is repeated", barcode)
+		}
+		seen[code] = true
+
+		normalized = append(normalized, code)
+	}
+
+	return normalized, nil
+}
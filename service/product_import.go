@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"test/api/models"
+	"test/pkg/audit"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// exportPageSize is the number of products read at once while exporting.
+const exportPageSize = 500
+
+// productColumns are the columns of import and export files, in the order they are exported.
+var productColumns = []string{"name", "sku", "category", "price", "original_price", "quantity", "unit", "branch"}
+
+// requiredProductColumns must be in the header of an import file, the rest may be left out.
+var requiredProductColumns = []string{"name", "category", "price", "branch"}
+
+var (
+	ErrInvalidImport = errors.New("invalid import file")
+
+	// errImportRolledBack rolls back an import that is a dry run or has invalid rows.
+	errImportRolledBack = errors.New("import is rolled back")
+)
+
+// Import creates products from the rows of an import file, the first row naming the columns.
+// Products are only created when every row is valid and it is not a dry run, the result
+// reports the errors of all rows.
+func (p productService) Import(ctx context.Context, records [][]string, dryRun bool) (models.ProductImportResult, error) {
+	if len(records) == 0 {
+		return models.ProductImportResult{}, fmt.Errorf("%w: file is empty", ErrInvalidImport)
+	}
+
+	columns, err := importColumns(records[0])
+	if err != nil {
+		return models.ProductImportResult{}, err
+	}
+
+	units, err := unitsByCode(ctx, p.storage)
+	if err != nil {
+		p.log.Error("error in service layer while getting units", logger.Error(err))
+
+		return models.ProductImportResult{}, err
+	}
+
+	result := models.ProductImportResult{
+		DryRun: dryRun,
+		Errors: []models.ProductImportError{},
+	}
+
+	rows := make([]models.ProductImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if isBlank(record) {
+			continue
+		}
+
+		result.Rows++
+
+		row, err := importRow(i+2, record, columns, units)
+		if err != nil {
+			result.Errors = append(result.Errors, models.ProductImportError{Line: row.Line, Error: err.Error()})
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	err = p.storage.WithTx(ctx, func(ctx context.Context) error {
+		rowErrors, err := p.storage.Product().StageImport(ctx, rows)
+		if err != nil {
+			return err
+		}
+
+		result.Errors = append(result.Errors, rowErrors...)
+		result.Valid = result.Rows - invalidLines(result.Errors)
+
+		if dryRun || len(result.Errors) > 0 {
+			return errImportRolledBack
+		}
+
+		ids, err := p.storage.Product().InsertImported(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			product, err := p.storage.Product().GetByID(ctx, models.PrimaryKey{ID: id})
+			if err != nil {
+				return err
+			}
+
+			if _, err = p.storage.ProductPrice().Create(ctx, models.CreateProductPrice{
+				ProductID:     id,
+				Price:         product.Price,
+				OriginalPrice: product.OriginalPrice,
+				CreatedBy:     audit.MetaFrom(ctx).ActorID,
+			}); err != nil {
+				return err
+			}
+
+			if err = recordAudit(ctx, p.storage, models.EntityProduct, models.AuditCreate, id, nil, product); err != nil {
+				return err
+			}
+		}
+
+		result.Imported = len(ids)
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		p.log.Error("error in service layer while importing products", logger.Error(err))
+
+		return models.ProductImportResult{}, err
+	}
+
+	return result, nil
+}
+
+// Export returns the products matching the filters of the request as rows of an export file,
+// in the columns Import reads.
+func (p productService) Export(ctx context.Context, request models.GetListRequest) ([][]string, error) {
+	categories, err := p.storage.Category().GetAll(ctx)
+	if err != nil {
+		p.log.Error("error in service layer while getting all categories", logger.Error(err))
+
+		return nil, err
+	}
+
+	categoryNames := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	branchNames := map[string]string{}
+
+	request.Page, request.Limit, request.SkipCount, request.GroupVariants = 1, exportPageSize, true, false
+
+	records := [][]string{productColumns}
+	for {
+		products, err := p.GetList(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range products.Products {
+			branchName, ok := branchNames[product.BranchID]
+			if !ok {
+				branch, err := p.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: product.BranchID})
+				if err != nil {
+					p.log.Error("error in service layer while getting branch by id", logger.Error(err))
+
+					return nil, err
+				}
+
+				branchName = branch.Name
+				branchNames[product.BranchID] = branchName
+			}
+
+			records = append(records, []string{
+				product.Name,
+				product.SKU,
+				categoryNames[product.CategoryID],
+				strconv.FormatInt(int64(product.Price), 10),
+				strconv.FormatInt(int64(product.OriginalPrice), 10),
+				product.Quantity.String(),
+				product.Unit,
+				branchName,
+			})
+		}
+
+		if products.NextCursor == "" {
+			return records, nil
+		}
+
+		request.Cursor = products.NextCursor
+	}
+}
+
+// importColumns maps the columns named by the header of an import file to their position,
+// "Original price" and "original_price" name the same column.
+func importColumns(header []string) (map[string]int, error) {
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		if name == "category_name" || name == "branch_name" {
+			name = strings.TrimSuffix(name, "_name")
+		}
+
+		columns[name] = i
+	}
+
+	for _, column := range requiredProductColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%w: column %s is missing", ErrInvalidImport, column)
+		}
+	}
+
+	return columns, nil
+}
+
+// importRow reads a product from a line of an import file, the row keeps its line when it is invalid.
+func importRow(line int, record []string, columns map[string]int, units map[string]models.Unit) (models.ProductImportRow, error) {
+	value := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	row := models.ProductImportRow{
+		Line:     line,
+		ID:       uuid.NewString(),
+		Name:     value("name"),
+		SKU:      value("sku"),
+		Category: value("category"),
+		Unit:     value("unit"),
+		Branch:   value("branch"),
+	}
+
+	switch {
+	case row.Name == "":
+		return row, errors.New("name is required")
+	case len(row.Name) > 255:
+		return row, errors.New("name is longer than 255 characters")
+	case len(row.SKU) > 64:
+		return row, errors.New("sku is longer than 64 characters")
+	case row.Category == "":
+		return row, errors.New("category is required")
+	case row.Branch == "":
+		return row, errors.New("branch is required")
+	}
+
+	var err error
+	if row.Price, err = importAmount(value("price")); err != nil {
+		return row, fmt.Errorf("price %w", err)
+	}
+
+	if row.OriginalPrice, err = importAmount(value("original_price")); err != nil {
+		return row, fmt.Errorf("original_price %w", err)
+	}
+
+	if quantity := value("quantity"); quantity != "" {
+		if row.Quantity, err = measure.Parse(quantity); err != nil {
+			return row, fmt.Errorf("quantity %s is not a number", quantity)
+		}
+	}
+
+	if row.Unit == "" {
+		row.Unit = defaultUnit
+	}
+
+	unit, ok := units[row.Unit]
+	if !ok {
+		return row, fmt.Errorf("unknown unit %s", row.Unit)
+	}
+
+	return row, validateQuantity(unit, row.Quantity)
+}
+
+// importAmount reads an amount in minor units, an empty cell is zero.
+func importAmount(value string) (money.Amount, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	amount, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a whole number of minor units", value)
+	}
+
+	if amount < 0 {
+		return 0, fmt.Errorf("%s should not be negative", value)
+	}
+
+	return money.Amount(amount), nil
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// invalidLines counts the lines with errors, a line may have several.
+func invalidLines(rowErrors []models.ProductImportError) int {
+	lines := map[int]bool{}
+	for _, rowError := range rowErrors {
+		lines[rowError.Line] = true
+	}
+
+	return len(lines)
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"test/api/models"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+var importUnits = map[string]models.Unit{
+	"piece": {Code: "piece"},
+	"kg":    {Code: "kg", Fractional: true},
+}
+
+func TestImportColumns(t *testing.T) {
+	columns, err := importColumns([]string{"Name", "SKU", "Category name", "Price", "Original price", "Branch"})
+	if err != nil {
+		t.Fatalf("error while reading header: %v", err)
+	}
+
+	assert.Equal(t, columns["category"], 2)
+	assert.Equal(t, columns["original_price"], 4)
+
+	_, err = importColumns([]string{"name", "price"})
+	assert.Equal(t, errors.Is(err, ErrInvalidImport), true)
+}
+
+func TestImportRow(t *testing.T) {
+	columns, err := importColumns([]string{"name", "category", "price", "quantity", "unit", "branch"})
+	if err != nil {
+		t.Fatalf("error while reading header: %v", err)
+	}
+
+	row, err := importRow(2, []string{" apples ", "fruits", "12000", "2.5", "kg", "Chilonzor"}, columns, importUnits)
+	if err != nil {
+		t.Fatalf("error while reading row: %v", err)
+	}
+
+	assert.Equal(t, row.Name, "apples")
+	assert.Equal(t, row.Price, money.Amount(12000))
+	assert.Equal(t, row.Quantity, measure.Quantity(2500))
+
+	// a piece is not cut in halves
+	row, err = importRow(3, []string{"bread", "bakery", "4000", "1.5", "", "Chilonzor"}, columns, importUnits)
+	assert.NotEqual(t, err, nil)
+	assert.Equal(t, row.Line, 3)
+
+	_, err = importRow(4, []string{"milk", "dairy", "12.50", "1", "", "Chilonzor"}, columns, importUnits)
+	assert.NotEqual(t, err, nil)
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"test/api/models"
+	"test/pkg/audit"
+	"test/pkg/logger"
+	"test/pkg/money"
+	"test/storage"
+	"time"
+)
+
+var ErrInvalidProductPrice = errors.New("invalid product price")
+
+type productPriceService struct {
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewProductPriceService(storage storage.IStorage, log logger.ILogger) productPriceService {
+	return productPriceService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+func (p productPriceService) Schedule(ctx context.Context, price models.CreateProductPrice) (models.ProductPrice, error) {
+	if err := validateProductPrice(price); err != nil {
+		return models.ProductPrice{}, err
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, price.StartsAt)
+	if err != nil {
+		p.log.Error("error in service layer while parsing starts_at", logger.Error(err))
+
+		return models.ProductPrice{}, err
+	}
+
+	if !startsAt.After(time.Now()) {
+		return models.ProductPrice{}, fmt.Errorf("%w: starts_at should be in the future", ErrInvalidProductPrice)
+	}
+
+	if _, err = p.storage.Product().GetByID(ctx, models.PrimaryKey{ID: price.ProductID}); err != nil {
+		p.log.Error("error in service layer while getting product by id", logger.Error(err))
+
+		return models.ProductPrice{}, err
+	}
+
+	id, err := audited(ctx, p.storage, models.EntityProductPrice, models.AuditCreate, "", loader(p.storage.ProductPrice().GetByID), func(ctx context.Context) (string, error) {
+		return p.storage.ProductPrice().Create(ctx, price)
+	})
+	if err != nil {
+		p.log.Error("error in service layer while scheduling product price", logger.Error(err))
+
+		return models.ProductPrice{}, err
+	}
+
+	scheduledPrice, err := p.storage.ProductPrice().GetByID(ctx, models.PrimaryKey{ID: id})
+	if err != nil {
+		p.log.Error("error in service layer while getting product price by id", logger.Error(err))
+
+		return models.ProductPrice{}, err
+	}
+
+	return scheduledPrice, nil
+}
+
+func (p productPriceService) GetHistory(ctx context.Context, request models.GetListRequest) (models.ProductPricesResponse, error) {
+	prices, err := p.storage.ProductPrice().GetList(ctx, request)
+	if err != nil {
+		p.log.Error("error in service layer while getting product price history", logger.Error(err))
+
+		return models.ProductPricesResponse{}, err
+	}
+
+	return prices, nil
+}
+
+// ActivateScheduled applies the due scheduled prices to their products in one transaction,
+// every change is kept in the audit log on behalf of whoever scheduled the price.
+func (p productPriceService) ActivateScheduled(ctx context.Context) error {
+	activated := 0
+	err := p.storage.WithTx(ctx, func(ctx context.Context) error {
+		prices, err := p.storage.ProductPrice().ActivateScheduled(ctx)
+		if err != nil || len(prices) == 0 {
+			return err
+		}
+
+		productIDs := make([]string, len(prices))
+		for index, price := range prices {
+			productIDs[index] = price.ProductID
+		}
+
+		current, err := p.storage.Product().LockPrices(ctx, models.ProductFilter{ProductIDs: productIDs})
+		if err != nil {
+			return err
+		}
+
+		currentByProduct := make(map[string]models.ProductPrice, len(current))
+		for _, price := range current {
+			currentByProduct[price.ProductID] = price
+		}
+
+		changed := make([]models.ProductPrice, 0, len(prices))
+		for _, price := range prices {
+			before, ok := currentByProduct[price.ProductID]
+			if !ok || (before.Price == price.Price && before.OriginalPrice == price.OriginalPrice) {
+				continue
+			}
+
+			actorCtx := audit.WithMeta(ctx, audit.Meta{ActorID: price.CreatedBy})
+			if err = recordAudit(actorCtx, p.storage, models.EntityProduct, models.AuditUpdate, price.ProductID,
+				map[string]money.Amount{"price": before.Price, "original_price": before.OriginalPrice},
+				map[string]money.Amount{"price": price.Price, "original_price": price.OriginalPrice}); err != nil {
+				return err
+			}
+
+			changed = append(changed, price)
+		}
+
+		if len(changed) == 0 {
+			return nil
+		}
+
+		activated = len(changed)
+
+		return p.storage.Product().SetPrices(ctx, changed)
+	})
+	if err != nil {
+		p.log.Error("error in service layer while activating scheduled prices", logger.Error(err))
+
+		return err
+	}
+
+	if activated > 0 {
+		p.log.Info("scheduled prices activated", logger.Any("products", activated))
+	}
+
+	return nil
+}
+
+// RunScheduler activates due scheduled prices every interval until ctx is done, a non-positive interval turns it off.
+func (p productPriceService) RunScheduler(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		p.log.Info("price scheduler is off")
+
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.ActivateScheduled(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// validateProductPrice requires a positive price, the original price may be zero when the cost is unknown.
+func validateProductPrice(price models.CreateProductPrice) error {
+	if price.Price <= 0 {
+		return fmt.Errorf("%w: price should be positive", ErrInvalidProductPrice)
+	}
+
+	if price.OriginalPrice < 0 {
+		return fmt.Errorf("%w: original_price should not be negative", ErrInvalidProductPrice)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"errors"
+	"test/api/models"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestValidateProductPrice(t *testing.T) {
+	for _, price := range []models.CreateProductPrice{
+		{Price: 0, OriginalPrice: 100},
+		{Price: -500, OriginalPrice: 100},
+		{Price: 500, OriginalPrice: -1},
+	} {
+		assert.Equal(t, errors.Is(validateProductPrice(price), ErrInvalidProductPrice), true)
+	}
+
+	assert.Equal(t, validateProductPrice(models.CreateProductPrice{Price: 500}), nil)
+}
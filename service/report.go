@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/money"
+	"test/storage"
+	"time"
+)
+
+type reportService struct {
+	cfg     config.Config
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewReportService(cfg config.Config, storage storage.IStorage, log logger.ILogger) reportService {
+	return reportService{
+		cfg:     cfg,
+		storage: storage,
+		log:     log,
+	}
+}
+
+// ConsolidatedProfit converts profit and losses of written off goods of every branch into the base currency
+// using the latest rate set on or before date (YYYY-MM-DD, today by default).
+func (r reportService) ConsolidatedProfit(ctx context.Context, date string) (models.ProfitReport, error) {
+	if date == "" {
+		date = time.Now().Format(time.DateOnly)
+	} else if _, err := time.Parse(time.DateOnly, date); err != nil {
+		r.log.Error("error in service layer while parsing report date", logger.Error(err))
+
+		return models.ProfitReport{}, err
+	}
+
+	profits, err := r.storage.Store().GetProfits(ctx)
+	if err != nil {
+		r.log.Error("error in service layer while getting store profits", logger.Error(err))
+
+		return models.ProfitReport{}, err
+	}
+
+	report := models.ProfitReport{
+		BaseCurrency: r.cfg.BaseCurrency,
+		Date:         date,
+		Branches:     make([]models.BranchProfit, 0, len(profits)),
+	}
+
+	rates := map[string]float64{r.cfg.BaseCurrency: 1}
+	for _, profit := range profits {
+		rate, ok := rates[profit.Currency]
+		if !ok {
+			currencyRate, err := r.storage.CurrencyRate().GetRate(ctx, profit.Currency, date)
+			if err != nil {
+				r.log.Error("error in service layer while getting currency rate", logger.Error(err))
+
+				return models.ProfitReport{}, fmt.Errorf("no %s rate on %s: %w", profit.Currency, date, err)
+			}
+
+			rate = currencyRate.Rate
+			rates[profit.Currency] = rate
+		}
+
+		profit.Rate = rate
+		profit.BaseProfit = money.New(profit.Profit, profit.Currency).Convert(rate, r.cfg.BaseCurrency).Amount
+		profit.BaseLoss = money.New(profit.Loss, profit.Currency).Convert(rate, r.cfg.BaseCurrency).Amount
+
+		report.TotalProfit += profit.BaseProfit
+		report.TotalLoss += profit.BaseLoss
+		report.Branches = append(report.Branches, profit)
+	}
+
+	return report, nil
+}
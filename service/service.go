@@ -1,6 +1,7 @@
 package service
 
 import (
+	"test/config"
 	"test/pkg/logger"
 	"test/storage"
 )
@@ -15,32 +16,71 @@ type IServiceManager interface {
 	Dealer() dealerService
 	Income() incomeService
 	IncomeProduct() incomeProductService
+	Loyalty() loyaltyService
+	ProductPrice() productPriceService
+	BranchProductPrice() branchProductPriceService
+	CurrencyRate() currencyRateService
+	Report() reportService
+	Unit() unitService
+	Trash() trashService
+	Audit() auditService
+	StockMovement() stockMovementService
+	InventoryCount() inventoryCountService
+	WriteOff() writeOffService
+	Lot() lotService
+	Backorder() backorderService
 }
 
 type Service struct {
-	userService          userService
-	categoryService      categoryService
-	basketService        basketService
-	basketProductService basketProductService
-	productService       productService
-	branchService        branchService
-	dealerService        dealerService
-	incomeService        incomeService
-	incomeProductService incomeProductService
-}
-
-func New(storage storage.IStorage, log logger.ILogger) Service {
+	userService               userService
+	categoryService           categoryService
+	basketService             basketService
+	basketProductService      basketProductService
+	productService            productService
+	branchService             branchService
+	dealerService             dealerService
+	incomeService             incomeService
+	incomeProductService      incomeProductService
+	loyaltyService            loyaltyService
+	productPriceService       productPriceService
+	branchProductPriceService branchProductPriceService
+	currencyRateService       currencyRateService
+	reportService             reportService
+	unitService               unitService
+	trashService              trashService
+	auditService              auditService
+	stockMovementService      stockMovementService
+	inventoryCountService     inventoryCountService
+	writeOffService           writeOffService
+	lotService                lotService
+	backorderService          backorderService
+}
+
+func New(cfg config.Config, storage storage.IStorage, log logger.ILogger) Service {
 	services := Service{}
 
 	services.userService = NewUserService(storage, log)
-	services.categoryService = NewCategoryService(storage, log)
+	services.categoryService = NewCategoryService(cfg, storage, log)
 	services.basketService = NewBasketService(storage, log)
 	services.basketProductService = NewBasketProductService(storage, log)
-	services.productService = NewProductService(storage, log)
-	services.branchService = NewBranchService(storage, log)
+	services.productService = NewProductService(cfg, storage, log)
+	services.branchService = NewBranchService(cfg, storage, log)
 	services.dealerService = NewDealerService(storage, log)
 	services.incomeService = NewIncomeService(storage, log)
 	services.incomeProductService = NewIncomeProductService(storage, log)
+	services.loyaltyService = NewLoyaltyService(storage, log)
+	services.productPriceService = NewProductPriceService(storage, log)
+	services.branchProductPriceService = NewBranchProductPriceService(storage, log)
+	services.currencyRateService = NewCurrencyRateService(cfg, storage, log)
+	services.reportService = NewReportService(cfg, storage, log)
+	services.unitService = NewUnitService(storage, log)
+	services.trashService = NewTrashService(cfg, storage, log)
+	services.auditService = NewAuditService(storage, log)
+	services.stockMovementService = NewStockMovementService(storage, log)
+	services.inventoryCountService = NewInventoryCountService(storage, log)
+	services.writeOffService = NewWriteOffService(cfg, storage, log)
+	services.lotService = NewLotService(storage, log)
+	services.backorderService = NewBackorderService(cfg, storage, log)
 
 	return services
 }
@@ -80,3 +120,55 @@ func (s Service) Income() incomeService {
 func (s Service) IncomeProduct() incomeProductService {
 	return s.incomeProductService
 }
+
+func (s Service) Loyalty() loyaltyService {
+	return s.loyaltyService
+}
+
+func (s Service) ProductPrice() productPriceService {
+	return s.productPriceService
+}
+
+func (s Service) BranchProductPrice() branchProductPriceService {
+	return s.branchProductPriceService
+}
+
+func (s Service) CurrencyRate() currencyRateService {
+	return s.currencyRateService
+}
+
+func (s Service) Report() reportService {
+	return s.reportService
+}
+
+func (s Service) Unit() unitService {
+	return s.unitService
+}
+
+func (s Service) Trash() trashService {
+	return s.trashService
+}
+
+func (s Service) Audit() auditService {
+	return s.auditService
+}
+
+func (s Service) StockMovement() stockMovementService {
+	return s.stockMovementService
+}
+
+func (s Service) InventoryCount() inventoryCountService {
+	return s.inventoryCountService
+}
+
+func (s Service) WriteOff() writeOffService {
+	return s.writeOffService
+}
+
+func (s Service) Lot() lotService {
+	return s.lotService
+}
+
+func (s Service) Backorder() backorderService {
+	return s.backorderService
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"test/api/models"
+	"test/pkg/audit"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/storage"
+)
+
+type stockMovementService struct {
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewStockMovementService(storage storage.IStorage, log logger.ILogger) stockMovementService {
+	return stockMovementService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+// GetHistory returns the stock movements of the product, newest first.
+func (s stockMovementService) GetHistory(ctx context.Context, request models.GetListRequest) (models.StockMovementsResponse, error) {
+	if _, err := s.storage.Product().GetByID(ctx, models.PrimaryKey{ID: request.ProductID}); err != nil {
+		s.log.Error("error in service layer while getting product by id", logger.Error(err))
+
+		return models.StockMovementsResponse{}, err
+	}
+
+	movements, err := s.storage.StockMovement().GetList(ctx, request)
+	if err != nil {
+		s.log.Error("error in service layer while getting stock movements", logger.Error(err))
+
+		return models.StockMovementsResponse{}, err
+	}
+
+	return movements, nil
+}
+
+// moveStock changes the stock of the product by the quantity, keeping the change in the stock movements
+// and in the audit log. It is run inside the transaction of the document the change belongs to.
+func moveStock(ctx context.Context, store storage.IStorage, productID string, quantity measure.Quantity, kind, reason string) (measure.Quantity, error) {
+	balance, err := store.Product().AddQuantity(ctx, productID, quantity)
+	if err != nil {
+		return 0, err
+	}
+
+	if balance < 0 {
+		return 0, fmt.Errorf("%w for product %s", ErrNegativeStock, productID)
+	}
+
+	if _, err = store.StockMovement().Create(ctx, models.CreateStockMovement{
+		ProductID: productID,
+		Kind:      kind,
+		Quantity:  quantity,
+		Balance:   balance,
+		Reason:    reason,
+		CreatedBy: audit.MetaFrom(ctx).ActorID,
+	}); err != nil {
+		return 0, err
+	}
+
+	if err = recordAudit(ctx, store, models.EntityProduct, models.AuditUpdate, productID,
+		map[string]measure.Quantity{"quantity": balance - quantity}, map[string]measure.Quantity{"quantity": balance}); err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+// setQuantity moves the stock of the product to the quantity set on the product itself, so the change
+// is kept in the movements and taken from the lots. The row of the product should be locked by the caller.
+func setQuantity(ctx context.Context, store storage.IStorage, productID string, quantity measure.Quantity) error {
+	product, err := store.Product().GetByID(ctx, models.PrimaryKey{ID: productID})
+	if err != nil {
+		return err
+	}
+
+	difference := quantity - product.Quantity
+	if difference == 0 {
+		return nil
+	}
+
+	if _, err = moveStock(ctx, store, productID, difference, models.StockMovementAdjustment, "product update"); err != nil {
+		return err
+	}
+
+	// stock taken away leaves its lots too, expired ones first
+	if difference < 0 {
+		_, err = takeLots(ctx, store, productID, -difference, true, models.StockMovementAdjustment, "")
+	}
+
+	return err
+}
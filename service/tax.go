@@ -0,0 +1,33 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"test/pkg/money"
+)
+
+var ErrInvalidTaxRate = errors.New("invalid tax rate")
+
+// validateTaxRate checks a tax rate of a category or a product is a percent, a missing rate is inherited.
+func validateTaxRate(rate *float64) error {
+	if rate != nil && (*rate < 0 || *rate > 100) {
+		return fmt.Errorf("%w: %v should be between 0 and 100", ErrInvalidTaxRate, *rate)
+	}
+
+	return nil
+}
+
+// calculateTax splits the sum of a check line into net, tax and gross parts.
+// Inclusive sums already contain the tax, exclusive sums get it added on top.
+func calculateTax(sum money.Amount, rate float64, inclusive bool) (net, tax, gross money.Amount) {
+	if inclusive {
+		tax = money.Amount(math.Round(float64(sum) * rate / (100 + rate)))
+
+		return sum - tax, tax, sum
+	}
+
+	tax = sum.Percent(rate)
+
+	return sum, tax, sum + tax
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"test/pkg/money"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestCalculateTax_LargeBasket(t *testing.T) {
+	var (
+		price                 = money.Amount(123457)
+		netSum, taxSum, gross money.Amount
+		lines                 = 5000
+	)
+
+	for i := 0; i < lines; i++ {
+		net, tax, total := calculateTax(price.Mul(7), 12, true)
+
+		assert.Equal(t, net+tax, total)
+
+		netSum += net
+		taxSum += tax
+		gross += total
+	}
+
+	// every line is rounded on its own, the receipt sums never drift from the lines
+	assert.Equal(t, gross, price.Mul(7*lines))
+	assert.Equal(t, netSum+taxSum, gross)
+	assert.Equal(t, taxSum, money.Amount(92593)*money.Amount(lines))
+}
+
+func TestCalculateTax_Exclusive(t *testing.T) {
+	net, tax, gross := calculateTax(money.Amount(1005), 12, false)
+
+	assert.Equal(t, net, money.Amount(1005))
+	assert.Equal(t, tax, money.Amount(121))
+	assert.Equal(t, gross, money.Amount(1126))
+}
+
+func TestValidateTaxRate(t *testing.T) {
+	for _, rate := range []float64{-100, -1, 100.5} {
+		assert.Equal(t, errors.Is(validateTaxRate(&rate), ErrInvalidTaxRate), true)
+	}
+
+	for _, rate := range []float64{0, 12, 100} {
+		assert.Equal(t, validateTaxRate(&rate), nil)
+	}
+
+	assert.Equal(t, validateTaxRate(nil), nil)
+}
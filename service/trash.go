@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/storage"
+	"time"
+)
+
+var ErrUnknownEntity = errors.New("unknown entity")
+
+type trashService struct {
+	cfg     config.Config
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewTrashService(cfg config.Config, storage storage.IStorage, log logger.ILogger) trashService {
+	return trashService{
+		cfg:     cfg,
+		storage: storage,
+		log:     log,
+	}
+}
+
+// GetList lists soft-deleted rows of the entity, the response has the type of the entity's own list.
+func (t trashService) GetList(ctx context.Context, entity string, request models.GetListRequest) (interface{}, error) {
+	var (
+		list interface{}
+		err  error
+	)
+
+	request.Deleted = true
+
+	switch entity {
+	case models.EntityUser:
+		list, err = t.storage.User().GetList(ctx, request)
+	case models.EntityCategory:
+		list, err = t.storage.Category().GetList(ctx, request)
+	case models.EntityProduct:
+		list, err = t.storage.Product().GetList(ctx, request)
+	case models.EntityBasket:
+		list, err = t.storage.Basket().GetList(ctx, request)
+	case models.EntityBasketProduct:
+		list, err = t.storage.BasketProduct().GetList(ctx, request)
+	case models.EntityBranch:
+		list, err = t.storage.Branch().GetList(ctx, request)
+	case models.EntityIncome:
+		list, err = t.storage.Income().GetList(ctx, request)
+	case models.EntityIncomeProduct:
+		list, err = t.storage.IncomeProduct().GetList(ctx, request)
+	default:
+		return nil, ErrUnknownEntity
+	}
+
+	if err != nil {
+		t.log.Error("error in service layer while getting trash list", logger.Error(err))
+
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (t trashService) Restore(ctx context.Context, entity, id string) error {
+	if err := t.storage.WithTx(ctx, func(ctx context.Context) error {
+		if err := t.storage.Trash().Restore(ctx, entity, id); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, t.storage, entity, models.AuditRestore, id, nil, nil)
+	}); err != nil {
+		t.log.Error("error in service layer while restoring deleted row", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// Purge removes rows that stayed in the trash longer than the retention period.
+func (t trashService) Purge(ctx context.Context) error {
+	deletedBefore := time.Now().AddDate(0, 0, -t.cfg.TrashRetentionDays).Unix()
+
+	purged, err := t.storage.Trash().Purge(ctx, deletedBefore)
+	if err != nil {
+		t.log.Error("error in service layer while purging trash", logger.Error(err))
+
+		return err
+	}
+
+	if purged > 0 {
+		t.log.Info("trash purged", logger.Any("rows", purged))
+	}
+
+	return nil
+}
+
+// RunPurger purges the trash every interval until ctx is done, a non-positive interval turns it off.
+func (t trashService) RunPurger(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		t.log.Info("trash purger is off")
+
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		t.Purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/storage"
+)
+
+// defaultUnit is the unit of products created without one.
+const defaultUnit = "piece"
+
+type unitService struct {
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewUnitService(storage storage.IStorage, log logger.ILogger) unitService {
+	return unitService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+func (u unitService) GetList(ctx context.Context) (models.UnitsResponse, error) {
+	units, err := u.storage.Unit().GetList(ctx)
+	if err != nil {
+		u.log.Error("error in service layer while getting units", logger.Error(err))
+
+		return models.UnitsResponse{}, err
+	}
+
+	return units, nil
+}
+
+func (u unitService) SetProductUnits(ctx context.Context, request models.SetProductUnits) (models.ProductUnitsResponse, error) {
+	product, err := u.storage.Product().GetByID(ctx, models.PrimaryKey{ID: request.ProductID})
+	if err != nil {
+		u.log.Error("error in service layer while getting product by id", logger.Error(err))
+
+		return models.ProductUnitsResponse{}, err
+	}
+
+	seen := make(map[string]bool, len(request.Units))
+	for _, unit := range request.Units {
+		if unit.Unit == "" {
+			return models.ProductUnitsResponse{}, errors.New("unit name is required")
+		}
+
+		if unit.Unit == product.Unit {
+			return models.ProductUnitsResponse{}, fmt.Errorf("%s is the unit of the product itself", unit.Unit)
+		}
+
+		if seen[unit.Unit] {
+			return models.ProductUnitsResponse{}, fmt.Errorf("unit %s is given twice", unit.Unit)
+		}
+		seen[unit.Unit] = true
+
+		if unit.Factor <= 0 {
+			return models.ProductUnitsResponse{}, errors.New("unit factor should be positive")
+		}
+	}
+
+	load := func(ctx context.Context, productID string) (interface{}, error) {
+		return u.storage.Unit().GetProductUnits(ctx, productID)
+	}
+
+	if _, err = audited(ctx, u.storage, models.EntityProductUnit, models.AuditUpdate, request.ProductID, load, func(ctx context.Context) (string, error) {
+		return request.ProductID, u.storage.Unit().SetProductUnits(ctx, request)
+	}); err != nil {
+		u.log.Error("error in service layer while setting product units", logger.Error(err))
+
+		return models.ProductUnitsResponse{}, err
+	}
+
+	return u.GetProductUnits(ctx, request.ProductID)
+}
+
+func (u unitService) GetProductUnits(ctx context.Context, productID string) (models.ProductUnitsResponse, error) {
+	productUnits, err := u.storage.Unit().GetProductUnits(ctx, productID)
+	if err != nil {
+		u.log.Error("error in service layer while getting product units", logger.Error(err))
+
+		return models.ProductUnitsResponse{}, err
+	}
+
+	return productUnits, nil
+}
+
+// validateQuantity refuses fractional quantities of products counted in whole units.
+func validateQuantity(unit models.Unit, quantity measure.Quantity) error {
+	if quantity < 0 {
+		return errors.New("quantity should not be negative")
+	}
+
+	if !unit.Fractional && !quantity.IsWhole() {
+		return fmt.Errorf("quantity %s is not a whole number of %s", quantity, unit.Code)
+	}
+
+	return nil
+}
+
+// unitsByCode returns all units of measure keyed by their code.
+func unitsByCode(ctx context.Context, storage storage.IStorage) (map[string]models.Unit, error) {
+	units, err := storage.Unit().GetList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byCode := make(map[string]models.Unit, len(units.Units))
+	for _, unit := range units.Units {
+		byCode[unit.Code] = unit
+	}
+
+	return byCode, nil
+}
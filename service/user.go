@@ -26,7 +26,9 @@ func NewUserService(storage storage.IStorage, log logger.ILogger) userService {
 
 func (u userService) Create(ctx context.Context, createUser models.CreateUser) (models.User, error) {
 	u.log.Info("User create service layer", logger.Any("createUser", createUser))
-	pKey, err := u.storage.User().Create(ctx, createUser)
+	pKey, err := audited(ctx, u.storage, models.EntityUser, models.AuditCreate, "", loader(u.storage.User().GetByID), func(ctx context.Context) (string, error) {
+		return u.storage.User().Create(ctx, createUser)
+	})
 	if err != nil {
 		u.log.Error("error while creating user", logger.Error(err))
 		return models.User{}, err
@@ -64,8 +66,11 @@ func (u userService) GetUsers(ctx context.Context, request models.GetListRequest
 }
 
 func (u userService) Update(ctx context.Context, updateUser models.UpdateUser) (models.User, error) {
-	pKey, err := u.storage.User().Update(ctx, updateUser)
+	pKey, err := audited(ctx, u.storage, models.EntityUser, models.AuditUpdate, updateUser.ID, loader(u.storage.User().GetByID), func(ctx context.Context) (string, error) {
+		return u.storage.User().Update(ctx, updateUser)
+	})
 	if err != nil {
+		err = versionConflict(ctx, err, loader(u.storage.User().GetByID), updateUser.ID)
 		fmt.Println("ERROR in service layer while updating updateUser", err.Error())
 		return models.User{}, err
 	}
@@ -81,8 +86,40 @@ func (u userService) Update(ctx context.Context, updateUser models.UpdateUser) (
 	return user, nil
 }
 
+// Patch changes only the fields of the customer present in the merge patch.
+func (u userService) Patch(ctx context.Context, patch models.Patch) (models.User, error) {
+	updateUser := models.UpdateUser{}
+	fields, err := decodePatch(patch, &updateUser, "full_name", "phone", "cash")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	updateUser.ID, updateUser.Version = patch.ID, patch.Version
+
+	pKey, err := audited(ctx, u.storage, models.EntityUser, models.AuditUpdate, updateUser.ID, loader(u.storage.User().GetByID), func(ctx context.Context) (string, error) {
+		return u.storage.User().Patch(ctx, updateUser, fields)
+	})
+	if err != nil {
+		err = versionConflict(ctx, err, loader(u.storage.User().GetByID), updateUser.ID)
+		fmt.Println("ERROR in service layer while patching user", err.Error())
+		return models.User{}, err
+	}
+
+	user, err := u.storage.User().GetByID(ctx, models.PrimaryKey{
+		ID: pKey,
+	})
+	if err != nil {
+		fmt.Println("ERROR in service layer while getting user after patch", err.Error())
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
 func (u userService) Delete(ctx context.Context, key models.PrimaryKey) error {
-	err := u.storage.User().Delete(ctx, key)
+	_, err := audited(ctx, u.storage, models.EntityUser, models.AuditDelete, key.ID, loader(u.storage.User().GetByID), func(ctx context.Context) (string, error) {
+		return key.ID, u.storage.User().Delete(ctx, key)
+	})
 	return err
 }
 
@@ -103,7 +140,14 @@ func (u userService) UpdatePassword(ctx context.Context, request models.UpdateUs
 		return err
 	}
 
-	if err = u.storage.User().UpdatePassword(context.Background(), request); err != nil {
+	// the password itself is kept out of the audit log
+	if err = u.storage.WithTx(ctx, func(ctx context.Context) error {
+		if err := u.storage.User().UpdatePassword(ctx, request); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, u.storage, models.EntityUser, models.AuditUpdate, request.ID, nil, map[string]bool{"password_changed": true})
+	}); err != nil {
 		fmt.Println("ERROR in service layer while updating password", err.Error())
 		return err
 	}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrVersionConflict = errors.New("row was changed since its version was read")
+
+// VersionConflictError refuses an update made on a stale version, Current is the row as it is now.
+type VersionConflictError struct {
+	Current interface{} `json:"current"`
+}
+
+func (e VersionConflictError) Error() string {
+	return ErrVersionConflict.Error()
+}
+
+func (e VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+// versionConflict tells an update that lost to a concurrent one from an update of a missing row,
+// repos report both as no rows.
+func versionConflict(ctx context.Context, err error, load func(context.Context, string) (interface{}, error), id string) error {
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	current, loadErr := load(ctx, id)
+	if loadErr != nil {
+		return err
+	}
+
+	return VersionConflictError{Current: current}
+}
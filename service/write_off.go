@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidWriteOff = errors.New("invalid write-off")
+	ErrWriteOffClosed  = errors.New("write-off is not pending")
+	ErrNotManager      = errors.New("only managers can approve write-offs")
+)
+
+var writeOffReasons = []string{models.WriteOffDamage, models.WriteOffExpiry, models.WriteOffTheft, models.WriteOffInternalUse}
+
+type writeOffService struct {
+	cfg     config.Config
+	storage storage.IStorage
+	log     logger.ILogger
+}
+
+func NewWriteOffService(cfg config.Config, storage storage.IStorage, log logger.ILogger) writeOffService {
+	return writeOffService{
+		cfg:     cfg,
+		storage: storage,
+		log:     log,
+	}
+}
+
+// Create records the goods to write off, stock is not changed until a manager approves the write-off.
+func (w writeOffService) Create(ctx context.Context, writeOff models.CreateWriteOff) (models.WriteOff, error) {
+	if err := validateWriteOff(writeOff); err != nil {
+		return models.WriteOff{}, err
+	}
+
+	if _, err := w.storage.Branch().GetByID(ctx, models.PrimaryKey{ID: writeOff.BranchID}); err != nil {
+		w.log.Error("error in service layer while getting branch by id", logger.Error(err))
+
+		return models.WriteOff{}, err
+	}
+
+	units, err := unitsByCode(ctx, w.storage)
+	if err != nil {
+		w.log.Error("error in service layer while getting units", logger.Error(err))
+
+		return models.WriteOff{}, err
+	}
+
+	for _, line := range writeOff.Lines {
+		product, err := w.storage.Product().GetByID(ctx, models.PrimaryKey{ID: line.ProductID})
+		if err != nil {
+			w.log.Error("error in service layer while getting product by id", logger.Error(err))
+
+			return models.WriteOff{}, fmt.Errorf("product %s: %w", line.ProductID, err)
+		}
+
+		if product.BranchID != writeOff.BranchID {
+			return models.WriteOff{}, fmt.Errorf("%w: product %s is not in the branch", ErrInvalidWriteOff, line.ProductID)
+		}
+
+		if !units[product.Unit].Fractional && !line.Quantity.IsWhole() {
+			return models.WriteOff{}, fmt.Errorf("%w: quantity %s is not a whole number of %s", ErrInvalidWriteOff, line.Quantity, product.Unit)
+		}
+	}
+
+	id, err := audited(ctx, w.storage, models.EntityWriteOff, models.AuditCreate, "", loader(w.storage.WriteOff().GetByID), func(ctx context.Context) (string, error) {
+		return w.storage.WriteOff().Create(ctx, writeOff)
+	})
+	if err != nil {
+		w.log.Error("error in service layer while creating write-off", logger.Error(err))
+
+		return models.WriteOff{}, err
+	}
+
+	return w.Get(ctx, models.PrimaryKey{ID: id})
+}
+
+func (w writeOffService) Get(ctx context.Context, key models.PrimaryKey) (models.WriteOff, error) {
+	writeOff, err := w.storage.WriteOff().GetByID(ctx, key)
+	if err != nil {
+		w.log.Error("error in service layer while getting write-off by id", logger.Error(err))
+
+		return models.WriteOff{}, err
+	}
+
+	return totalWriteOff(writeOff), nil
+}
+
+func (w writeOffService) GetList(ctx context.Context, request models.GetListRequest) (models.WriteOffsResponse, error) {
+	writeOffs, err := w.storage.WriteOff().GetList(ctx, request)
+	if err != nil {
+		w.log.Error("error in service layer while getting write-offs", logger.Error(err))
+
+		return models.WriteOffsResponse{}, err
+	}
+
+	return writeOffs, nil
+}
+
+// Approve posts the write-off: the goods leave stock and their cost, by the configured cost method,
+// is added to the losses of the branch.
+func (w writeOffService) Approve(ctx context.Context, key models.PrimaryKey, approvedBy string) (models.WriteOff, error) {
+	err := w.storage.WithTx(ctx, func(ctx context.Context) error {
+		if err := w.close(ctx, key.ID, models.WriteOffPosted, approvedBy); err != nil {
+			return err
+		}
+
+		writeOff, err := w.storage.WriteOff().GetByID(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		reason := fmt.Sprintf("write-off %s: %s", writeOff.ID, writeOff.Reason)
+		for _, line := range writeOff.Lines {
+			if _, err = moveStock(ctx, w.storage, line.ProductID, -line.Quantity, models.StockMovementWriteOff, reason); err != nil {
+				return err
+			}
+
+			// written off goods leave their lots too, expired ones first, and are costed
+			// with the configured method the way sold goods are
+			takes, err := takeLots(ctx, w.storage, line.ProductID, line.Quantity, true, models.StockMovementWriteOff, writeOff.ID)
+			if err != nil {
+				return err
+			}
+
+			cost := saleCost(w.cfg.CostMethod, takes, line.Quantity, line.Cost)
+			if err = w.storage.WriteOff().SetLineCost(ctx, writeOff.ID, line.ProductID, cost.DivQuantity(line.Quantity)); err != nil {
+				return err
+			}
+		}
+
+		if writeOff, err = w.storage.WriteOff().GetByID(ctx, key); err != nil {
+			return err
+		}
+
+		return w.storage.Store().AddLoss(ctx, totalWriteOff(writeOff).TotalCost, writeOff.BranchID)
+	})
+	if err != nil {
+		w.log.Error("error in service layer while approving write-off", logger.Error(err))
+
+		return models.WriteOff{}, err
+	}
+
+	return w.Get(ctx, key)
+}
+
+// Reject closes the write-off without changing the stock.
+func (w writeOffService) Reject(ctx context.Context, key models.PrimaryKey, rejectedBy string) (models.WriteOff, error) {
+	err := w.storage.WithTx(ctx, func(ctx context.Context) error {
+		return w.close(ctx, key.ID, models.WriteOffRejected, rejectedBy)
+	})
+	if err != nil {
+		w.log.Error("error in service layer while rejecting write-off", logger.Error(err))
+
+		return models.WriteOff{}, err
+	}
+
+	return w.Get(ctx, key)
+}
+
+// close moves a pending write-off to the status, only managers may do it.
+func (w writeOffService) close(ctx context.Context, id, status, closedBy string) error {
+	isManager, err := w.storage.User().IsAdmin(ctx, closedBy)
+	if err != nil {
+		return err
+	}
+
+	if !isManager {
+		return ErrNotManager
+	}
+
+	writeOff, err := w.storage.WriteOff().GetByID(ctx, models.PrimaryKey{ID: id})
+	if err != nil {
+		return err
+	}
+
+	if err = w.storage.WriteOff().Close(ctx, id, status, closedBy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrWriteOffClosed
+		}
+
+		return err
+	}
+
+	return recordAudit(ctx, w.storage, models.EntityWriteOff, models.AuditUpdate, id,
+		map[string]string{"status": writeOff.Status}, map[string]string{"status": status})
+}
+
+func validateWriteOff(writeOff models.CreateWriteOff) error {
+	if !slices.Contains(writeOffReasons, writeOff.Reason) {
+		return fmt.Errorf("%w: unknown reason %s", ErrInvalidWriteOff, writeOff.Reason)
+	}
+
+	if len(writeOff.Lines) == 0 {
+		return fmt.Errorf("%w: no products to write off", ErrInvalidWriteOff)
+	}
+
+	products := make(map[string]bool, len(writeOff.Lines))
+	for _, line := range writeOff.Lines {
+		if line.Quantity <= 0 {
+			return fmt.Errorf("%w: quantity of product %s should be positive", ErrInvalidWriteOff, line.ProductID)
+		}
+
+		if products[line.ProductID] {
+			return fmt.Errorf("%w: product %s is written off twice", ErrInvalidWriteOff, line.ProductID)
+		}
+
+		products[line.ProductID] = true
+	}
+
+	return nil
+}
+
+// totalWriteOff works out the cost of every line and of the write-off.
+func totalWriteOff(writeOff models.WriteOff) models.WriteOff {
+	writeOff.TotalCost = 0
+	for index, line := range writeOff.Lines {
+		writeOff.Lines[index].TotalCost = line.Cost.MulQuantity(line.Quantity)
+		writeOff.TotalCost += writeOff.Lines[index].TotalCost
+	}
+
+	return writeOff
+}
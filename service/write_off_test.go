@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"test/api/models"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestValidateWriteOff(t *testing.T) {
+	line := models.CreateWriteOffLine{ProductID: "milk", Quantity: measure.Whole(2)}
+
+	for _, writeOff := range []models.CreateWriteOff{
+		{Reason: "lost", Lines: []models.CreateWriteOffLine{line}},
+		{Reason: models.WriteOffDamage},
+		{Reason: models.WriteOffExpiry, Lines: []models.CreateWriteOffLine{{ProductID: "milk"}}},
+		{Reason: models.WriteOffTheft, Lines: []models.CreateWriteOffLine{line, line}},
+	} {
+		assert.Equal(t, errors.Is(validateWriteOff(writeOff), ErrInvalidWriteOff), true)
+	}
+
+	assert.Equal(t, validateWriteOff(models.CreateWriteOff{Reason: models.WriteOffInternalUse, Lines: []models.CreateWriteOffLine{line}}), nil)
+}
+
+func TestTotalWriteOff(t *testing.T) {
+	writeOff := totalWriteOff(models.WriteOff{
+		Lines: []models.WriteOffLine{
+			{ProductID: "milk", Quantity: measure.Whole(3), Cost: 1200},
+			{ProductID: "cheese", Quantity: 255, Cost: 9999},
+		},
+	})
+
+	assert.Equal(t, writeOff.Lines[0].TotalCost, money.Amount(3600))
+	assert.Equal(t, writeOff.Lines[1].TotalCost, money.Amount(2550))
+	assert.Equal(t, writeOff.TotalCost, money.Amount(6150))
+}
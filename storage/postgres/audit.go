@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type auditRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewAuditRepo(db *pgxpool.Pool, log logger.ILogger) storage.IAuditStorage {
+	return &auditRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+func (a *auditRepo) Create(ctx context.Context, entry models.CreateAuditLog) error {
+	query := `insert into audit_log (id, actor_id, entity, entity_id, action, before, after, request_id, ip)
+			values ($1, nullif($2, ''), $3, $4, $5, $6, $7, nullif($8, ''), nullif($9, ''))`
+
+	if _, err := a.db.Exec(ctx, query,
+		uuid.New(),
+		entry.ActorID,
+		entry.Entity,
+		entry.EntityID,
+		entry.Action,
+		nullJSON(entry.Before),
+		nullJSON(entry.After),
+		entry.RequestID,
+		entry.IP,
+	); err != nil {
+		a.log.Error("error is while inserting audit log", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (a *auditRepo) GetList(ctx context.Context, request models.GetListRequest) (models.AuditLogsResponse, error) {
+	var (
+		auditLogs = []models.AuditLog{}
+		count     = 0
+	)
+
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.AuditLogsResponse{}, err
+	}
+
+	filter, args := auditListFilter(request, nil)
+
+	if !request.SkipCount {
+		if err = a.db.QueryRow(ctx, `select count(1) from audit_log where true`+filter, args...).Scan(&count); err != nil {
+			a.log.Error("error is while scanning count of audit log", logger.Error(err))
+
+			return models.AuditLogsResponse{}, err
+		}
+	}
+
+	where, args := page.where(args)
+	orderLimit, args := page.orderLimit(args)
+
+	query := `select id, coalesce(actor_id, ''), entity, entity_id, action, before, after,
+       				coalesce(request_id, ''), coalesce(ip, ''), created_at
+			from audit_log where true` + filter + where + orderLimit
+
+	rows, err := a.db.Query(ctx, query, args...)
+	if err != nil {
+		a.log.Error("error is while selecting audit log", logger.Error(err))
+
+		return models.AuditLogsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			auditLog  = models.AuditLog{}
+			createdAt = sql.NullString{}
+		)
+
+		if err = rows.Scan(
+			&auditLog.ID,
+			&auditLog.ActorID,
+			&auditLog.Entity,
+			&auditLog.EntityID,
+			&auditLog.Action,
+			&auditLog.Before,
+			&auditLog.After,
+			&auditLog.RequestID,
+			&auditLog.IP,
+			&createdAt,
+		); err != nil {
+			a.log.Error("error is while scanning audit log", logger.Error(err))
+
+			return models.AuditLogsResponse{}, err
+		}
+
+		if createdAt.Valid {
+			auditLog.CreatedAt = createdAt.String
+		}
+
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	auditLogs, cursors := paginate(page, auditLogs, func(auditLog models.AuditLog) (string, string) {
+		return auditLog.CreatedAt, auditLog.ID
+	})
+
+	return models.AuditLogsResponse{
+		AuditLogs: auditLogs,
+		Count:     count,
+		Cursors:   cursors,
+	}, nil
+}
+
+// auditListFilter returns conditions of audit log listing, values are appended to args and referenced as placeholders.
+func auditListFilter(request models.GetListRequest, args []interface{}) (string, []interface{}) {
+	var filter strings.Builder
+
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if request.Entity != "" {
+		filter.WriteString(` and entity = ` + placeholder(request.Entity))
+	}
+
+	if request.EntityID != "" {
+		filter.WriteString(` and entity_id = ` + placeholder(request.EntityID))
+	}
+
+	if request.ActorID != "" {
+		filter.WriteString(` and actor_id = ` + placeholder(request.ActorID))
+	}
+
+	if request.CreatedFrom != "" {
+		filter.WriteString(` and created_at >= ` + placeholder(request.CreatedFrom) + `::date`)
+	}
+
+	// created_to is inclusive, the whole day is taken
+	if request.CreatedTo != "" {
+		filter.WriteString(` and created_at < ` + placeholder(request.CreatedTo) + `::date + 1`)
+	}
+
+	return filter.String(), args
+}
+
+// nullJSON stores a missing side of a change as null rather than an empty document.
+func nullJSON(data []byte) interface{} {
+	if data == nil {
+		return nil
+	}
+
+	return string(data)
+}
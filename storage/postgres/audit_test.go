@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/google/uuid"
+)
+
+func TestAuditRepo_Create(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Errorf("error while connection to db error: %v", err)
+	}
+
+	entityID := uuid.New().String()
+
+	if err = pgStore.Audit().Create(context.Background(), models.CreateAuditLog{
+		ActorID:  "tester",
+		Entity:   models.EntityProduct,
+		EntityID: entityID,
+		Action:   models.AuditUpdate,
+		Before:   []byte(`{"price":10}`),
+		After:    []byte(`{"price":12}`),
+	}); err != nil {
+		t.Errorf("error while creating audit log error: %v", err)
+	}
+
+	auditLogs, err := pgStore.Audit().GetList(context.Background(), models.GetListRequest{
+		Page:     1,
+		Limit:    10,
+		Entity:   models.EntityProduct,
+		EntityID: entityID,
+	})
+	if err != nil {
+		t.Errorf("error while getting audit log error: %v", err)
+	}
+
+	assert.Equal(t, auditLogs.Count, 1)
+	assert.Equal(t, auditLogs.AuditLogs[0].ActorID, "tester")
+	assert.Equal(t, string(auditLogs.AuditLogs[0].After), `{"price": 12}`)
+}
+
+func TestStore_WithTx(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Errorf("error while connection to db error: %v", err)
+	}
+
+	entityID := uuid.New().String()
+	rollback := errors.New("rollback")
+
+	err = pgStore.WithTx(context.Background(), func(ctx context.Context) error {
+		if err := pgStore.Audit().Create(ctx, models.CreateAuditLog{
+			Entity:   models.EntityProduct,
+			EntityID: entityID,
+			Action:   models.AuditCreate,
+		}); err != nil {
+			return err
+		}
+
+		return rollback
+	})
+	assert.Equal(t, err, rollback)
+
+	auditLogs, err := pgStore.Audit().GetList(context.Background(), models.GetListRequest{
+		Page:     1,
+		Limit:    10,
+		EntityID: entityID,
+	})
+	if err != nil {
+		t.Errorf("error while getting audit log error: %v", err)
+	}
+
+	assert.Equal(t, auditLogs.Count, 0)
+}
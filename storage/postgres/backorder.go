@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type backorderRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewBackorderRepo(db *pgxpool.Pool, log logger.ILogger) storage.IBackorderStorage {
+	return &backorderRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+func (b *backorderRepo) Create(ctx context.Context, backorder models.CreateBackorder) (string, error) {
+	id := uuid.New()
+
+	query := `insert into backorders (id, customer_id, product_id, basket_id, branch_id, quantity, price, tax_rate)
+			values ($1, $2, $3, nullif($4, '')::uuid, nullif($5, '')::uuid, $6, $7, $8)`
+	if _, err := b.db.Exec(ctx, query, id, backorder.CustomerID, backorder.ProductID, backorder.BasketID, backorder.BranchID,
+		backorder.Quantity, backorder.Price, backorder.TaxRate); err != nil {
+		b.log.Error("error is while inserting backorder", logger.Error(err))
+
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (b *backorderRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.Backorder, error) {
+	backorder, err := scanBackorder(b.db.QueryRow(ctx, `select `+backorderColumns+` from backorders b join products p on p.id = b.product_id
+			where b.id = $1`, key.ID))
+	if err != nil {
+		b.log.Error("error is while selecting backorder by id", logger.Error(err))
+
+		return models.Backorder{}, err
+	}
+
+	return backorder, nil
+}
+
+// GetList returns the backorders of a customer, a product or in a status, newest first.
+func (b *backorderRepo) GetList(ctx context.Context, request models.GetListRequest) (models.BackordersResponse, error) {
+	var (
+		backorders = []models.Backorder{}
+		count      = 0
+	)
+
+	page, err := newListPage(request, keyset{column: "b.created_at", columnType: "timestamp", tie: "b.id", tieType: "uuid", desc: true})
+	if err != nil {
+		return models.BackordersResponse{}, err
+	}
+
+	filter := ` where (b.customer_id = nullif($1, '')::uuid or $1 = '') and (b.product_id = nullif($2, '')::uuid or $2 = '')
+			and (b.status::text = $3 or $3 = '')`
+	args := []interface{}{request.UserID, request.ProductID, request.Status}
+
+	if !request.SkipCount {
+		if err = b.db.QueryRow(ctx, `select count(1) from backorders b`+filter, args...).Scan(&count); err != nil {
+			b.log.Error("error is while scanning count of backorders", logger.Error(err))
+
+			return models.BackordersResponse{}, err
+		}
+	}
+
+	where, args := page.where(args)
+	orderLimit, args := page.orderLimit(args)
+
+	rows, err := b.db.Query(ctx, `select `+backorderColumns+` from backorders b join products p on p.id = b.product_id`+filter+where+orderLimit, args...)
+	if err != nil {
+		b.log.Error("error is while selecting backorders", logger.Error(err))
+
+		return models.BackordersResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		backorder, err := scanBackorder(rows)
+		if err != nil {
+			b.log.Error("error is while scanning backorder", logger.Error(err))
+
+			return models.BackordersResponse{}, err
+		}
+
+		backorders = append(backorders, backorder)
+	}
+
+	backorders, cursors := paginate(page, backorders, func(backorder models.Backorder) (string, string) {
+		return backorder.CreatedAt, backorder.ID
+	})
+
+	return models.BackordersResponse{
+		Backorders: backorders,
+		Count:      count,
+		Cursors:    cursors,
+	}, nil
+}
+
+// LockOpen locks the open backorders of the product, oldest first, in the order they are to be fulfilled.
+func (b *backorderRepo) LockOpen(ctx context.Context, productID string) ([]models.Backorder, error) {
+	query := `select ` + backorderColumns + ` from backorders b join products p on p.id = b.product_id
+			where b.product_id = $1 and b.status = 'open' order by b.created_at, b.id for update of b`
+
+	return b.list(ctx, "error is while locking open backorders", query, productID)
+}
+
+// Fulfill marks an open backorder fulfilled keeping the average cost of the product it holds,
+// it returns no rows when the backorder is not open.
+func (b *backorderRepo) Fulfill(ctx context.Context, id string) error {
+	query := `update backorders b set status = 'fulfilled', fulfilled_at = now(), updated_at = now(),
+			average_cost = coalesce(p.average_cost, p.original_price) from products p
+			where p.id = b.product_id and b.id = $1 and b.status = 'open'`
+
+	return b.close(ctx, "error is while fulfilling backorder", query, id)
+}
+
+// Cancel cancels an open backorder, it returns no rows when the backorder is not open.
+func (b *backorderRepo) Cancel(ctx context.Context, id string) error {
+	query := `update backorders set status = 'cancelled', updated_at = now() where id = $1 and status = 'open'`
+
+	return b.close(ctx, "error is while cancelling backorder", query, id)
+}
+
+// Collect marks a fulfilled backorder collected in the sale basket, it returns no rows when the backorder is not held.
+func (b *backorderRepo) Collect(ctx context.Context, id, saleBasketID string) error {
+	query := `update backorders set status = 'collected', sale_basket_id = $2, updated_at = now() where id = $1 and status = 'fulfilled'`
+
+	return b.close(ctx, "error is while collecting backorder", query, id, saleBasketID)
+}
+
+// Release marks a fulfilled backorder released, it returns no rows when the backorder is not held.
+func (b *backorderRepo) Release(ctx context.Context, id string) error {
+	query := `update backorders set status = 'released', updated_at = now() where id = $1 and status = 'fulfilled'`
+
+	return b.close(ctx, "error is while releasing backorder", query, id)
+}
+
+// GetUnnotified returns fulfilled backorders the customer was not told about yet, leaving out the ones
+// whose notification failed maxAttempts times.
+func (b *backorderRepo) GetUnnotified(ctx context.Context, limit, maxAttempts int) ([]models.Backorder, error) {
+	query := `select ` + backorderColumns + ` from backorders b join products p on p.id = b.product_id
+			where b.status = 'fulfilled' and b.notified_at is null and b.notify_attempts < $2
+				order by b.fulfilled_at, b.id limit $1`
+
+	return b.list(ctx, "error is while selecting unnotified backorders", query, limit, maxAttempts)
+}
+
+// MarkNotified records an attempt to notify the customer, a delivered notification is not sent again.
+func (b *backorderRepo) MarkNotified(ctx context.Context, id string, delivered bool) error {
+	query := `update backorders set notify_attempts = notify_attempts + 1,
+			notified_at = case when $2 then now() end, updated_at = now() where id = $1`
+	if _, err := b.db.Exec(ctx, query, id, delivered); err != nil {
+		b.log.Error("error is while marking backorder notified", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (b *backorderRepo) close(ctx context.Context, message, query string, args ...interface{}) error {
+	result, err := b.db.Exec(ctx, query, args...)
+	if err != nil {
+		b.log.Error(message, logger.Error(err))
+
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (b *backorderRepo) list(ctx context.Context, message, query string, args ...interface{}) ([]models.Backorder, error) {
+	rows, err := b.db.Query(ctx, query, args...)
+	if err != nil {
+		b.log.Error(message, logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	backorders := []models.Backorder{}
+	for rows.Next() {
+		backorder, err := scanBackorder(rows)
+		if err != nil {
+			b.log.Error("error is while scanning backorder", logger.Error(err))
+
+			return nil, err
+		}
+
+		backorders = append(backorders, backorder)
+	}
+
+	return backorders, rows.Err()
+}
+
+// backorderColumns are read from backorders b joined with products p.
+const backorderColumns = `b.id, b.customer_id, b.product_id, p.name, b.basket_id::text, b.branch_id::text, b.quantity,
+		coalesce(b.price, 0), b.tax_rate, coalesce(b.average_cost, 0), b.status::text, b.sale_basket_id::text,
+		b.fulfilled_at::text, b.notified_at::text, b.created_at::text, b.updated_at::text`
+
+func scanBackorder(row pgx.Row) (models.Backorder, error) {
+	var (
+		backorder                                     = models.Backorder{}
+		basketID, branchID, saleBasketID              = sql.NullString{}, sql.NullString{}, sql.NullString{}
+		fulfilledAt, notifiedAt, createdAt, updatedAt = sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}
+	)
+
+	if err := row.Scan(&backorder.ID, &backorder.CustomerID, &backorder.ProductID, &backorder.ProductName, &basketID, &branchID, &backorder.Quantity,
+		&backorder.Price, &backorder.TaxRate, &backorder.AverageCost, &backorder.Status, &saleBasketID,
+		&fulfilledAt, &notifiedAt, &createdAt, &updatedAt); err != nil {
+		return models.Backorder{}, err
+	}
+
+	backorder.BasketID, backorder.BranchID, backorder.SaleBasketID = basketID.String, branchID.String, saleBasketID.String
+	backorder.FulfilledAt, backorder.NotifiedAt = fulfilledAt.String, notifiedAt.String
+	backorder.CreatedAt, backorder.UpdatedAt = createdAt.String, updatedAt.String
+
+	return backorder, nil
+}
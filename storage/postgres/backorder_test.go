@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestBackorderRepo_Fulfill(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:       "kefir",
+		Price:      6000,
+		CategoryID: "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:   "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	backorderID, err := pgStore.Backorder().Create(context.Background(), models.CreateBackorder{
+		CustomerID: "c5eebf53-a536-4745-b816-2264af15d61f",
+		ProductID:  productID,
+		Quantity:   measure.Whole(3),
+	})
+	if err != nil {
+		t.Fatalf("error while creating backorder: %v", err)
+	}
+
+	open, err := pgStore.Backorder().LockOpen(context.Background(), productID)
+	if err != nil {
+		t.Fatalf("error while locking open backorders: %v", err)
+	}
+
+	assert.Equal(t, len(open), 1)
+	assert.Equal(t, open[0].Quantity, measure.Whole(3))
+
+	if err = pgStore.Backorder().Fulfill(context.Background(), backorderID); err != nil {
+		t.Fatalf("error while fulfilling backorder: %v", err)
+	}
+
+	if err = pgStore.Backorder().Cancel(context.Background(), backorderID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expected cancelling a fulfilled backorder to find no rows, but got: %v", err)
+	}
+
+	unnotified, err := pgStore.Backorder().GetUnnotified(context.Background(), 100, 5)
+	if err != nil {
+		t.Fatalf("error while getting unnotified backorders: %v", err)
+	}
+
+	found := false
+	for _, backorder := range unnotified {
+		found = found || backorder.ID == backorderID
+	}
+
+	assert.Equal(t, found, true)
+
+	if err = pgStore.Backorder().MarkNotified(context.Background(), backorderID, true); err != nil {
+		t.Fatalf("error while marking backorder notified: %v", err)
+	}
+
+	backorder, err := pgStore.Backorder().GetByID(context.Background(), models.PrimaryKey{ID: backorderID})
+	if err != nil {
+		t.Fatalf("error while getting backorder: %v", err)
+	}
+
+	assert.Equal(t, backorder.Status, models.BackorderFulfilled)
+	assert.NotEqual(t, backorder.FulfilledAt, "")
+	assert.NotEqual(t, backorder.NotifiedAt, "")
+}
+
+func TestBackorderRepo_CollectAndRelease(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "butter",
+		Price:         9000,
+		OriginalPrice: 7000,
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	backorderIDs := []string{}
+	for i := 0; i < 2; i++ {
+		backorderID, err := pgStore.Backorder().Create(context.Background(), models.CreateBackorder{
+			CustomerID: "c5eebf53-a536-4745-b816-2264af15d61f",
+			ProductID:  productID,
+			BranchID:   "aa541fcc-bf74-11ee-ae0b-166244b65504",
+			Quantity:   measure.Whole(1),
+			Price:      9500,
+			TaxRate:    12,
+		})
+		if err != nil {
+			t.Fatalf("error while creating backorder: %v", err)
+		}
+
+		backorderIDs = append(backorderIDs, backorderID)
+	}
+
+	// only held backorders are collected or released
+	if err = pgStore.Backorder().Release(context.Background(), backorderIDs[0]); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expected releasing an open backorder to find no rows, but got: %v", err)
+	}
+
+	for _, backorderID := range backorderIDs {
+		if err = pgStore.Backorder().Fulfill(context.Background(), backorderID); err != nil {
+			t.Fatalf("error while fulfilling backorder: %v", err)
+		}
+	}
+
+	basketID, err := pgStore.Basket().Create(context.Background(), models.CreateBasket{CustomerID: "c5eebf53-a536-4745-b816-2264af15d61f"})
+	if err != nil {
+		t.Fatalf("error while creating basket: %v", err)
+	}
+
+	if err = pgStore.Backorder().Collect(context.Background(), backorderIDs[0], basketID); err != nil {
+		t.Fatalf("error while collecting backorder: %v", err)
+	}
+
+	if err = pgStore.Backorder().Release(context.Background(), backorderIDs[0]); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expected releasing a collected backorder to find no rows, but got: %v", err)
+	}
+
+	if err = pgStore.Backorder().Release(context.Background(), backorderIDs[1]); err != nil {
+		t.Fatalf("error while releasing backorder: %v", err)
+	}
+
+	collected, err := pgStore.Backorder().GetByID(context.Background(), models.PrimaryKey{ID: backorderIDs[0]})
+	if err != nil {
+		t.Fatalf("error while getting backorder: %v", err)
+	}
+
+	assert.Equal(t, collected.Status, models.BackorderCollected)
+	assert.Equal(t, collected.SaleBasketID, basketID)
+	assert.Equal(t, collected.Price, money.Amount(9500))
+	assert.Equal(t, collected.AverageCost, money.Amount(7000))
+
+	released, err := pgStore.Backorder().GetByID(context.Background(), models.PrimaryKey{ID: backorderIDs[1]})
+	if err != nil {
+		t.Fatalf("error while getting backorder: %v", err)
+	}
+
+	assert.Equal(t, released.Status, models.BackorderReleased)
+}
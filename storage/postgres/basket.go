@@ -9,17 +9,18 @@ import (
 	"test/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type basketRepo struct {
-	db  *pgxpool.Pool
+	db  txPool
 	log logger.ILogger
 }
 
 func NewBasketRepo(db *pgxpool.Pool, log logger.ILogger) storage.IBasketStorage {
 	return &basketRepo{
-		db:  db,
+		db:  txPool{db},
 		log: log,
 	}
 }
@@ -46,10 +47,16 @@ func (b *basketRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models
 	var createdAt, updatedAt = sql.NullString{}, sql.NullString{}
 	basket := models.Basket{}
 
-	if err := b.db.QueryRow(ctx, `select id, customer_id, total_sum, created_at, updated_at from baskets where id = $1 and deleted_at = 0 `,
+	if err := b.db.QueryRow(ctx, `select id, customer_id, net_sum, tax_sum, total_sum, tax_inclusive, currency, version, created_at, updated_at
+			from baskets where id = $1 and deleted_at = 0 `,
 		key.ID).Scan(&basket.ID,
 		&basket.CustomerID,
+		&basket.NetSum,
+		&basket.TaxSum,
 		&basket.TotalSum,
+		&basket.TaxInclusive,
+		&basket.Currency,
+		&basket.Version,
 		&createdAt,
 		&updatedAt,
 	); err != nil {
@@ -74,31 +81,39 @@ func (b *basketRepo) GetList(ctx context.Context, req models.GetListRequest) (mo
 		baskets              = []models.Basket{}
 		count                = 0
 		query, countQuery    string
-		page                 = req.Page
-		offset               = (page - 1) * req.Limit
 		search               = req.Search
 		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
 	)
 
-	countQuery = `select count(1) from baskets where deleted_at = 0 `
+	page, err := newListPage(req, createdAtKeyset)
+	if err != nil {
+		return models.BasketResponse{}, err
+	}
+
+	countQuery = `select count(1) from baskets where ` + deletedCondition(req, "deleted_at") + ` `
 
 	if search != "" {
 		countQuery += fmt.Sprintf(` and CAST(total_sum AS TEXT) ilike '%%%s%%'`, search)
 	}
-	if err := b.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		b.log.Error("error is while selecting count", logger.Error(err))
+	if !req.SkipCount {
+		if err = b.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
+			b.log.Error("error is while selecting count", logger.Error(err))
 
-		return models.BasketResponse{}, err
+			return models.BasketResponse{}, err
+		}
 	}
 
-	query = `select id, customer_id, total_sum, created_at, updated_at from baskets where deleted_at = 0`
+	query = `select id, customer_id, net_sum, tax_sum, total_sum, tax_inclusive, currency, version, created_at, updated_at from baskets where ` + deletedCondition(req, "deleted_at")
 
 	if search != "" {
 		query += fmt.Sprintf(` and CAST(total_sum AS TEXT) ilike '%%%s%%'`, search)
 	}
 
-	query += ` order by created_at desc LIMIT $1 OFFSET $2`
-	rows, err := b.db.Query(ctx, query, req.Limit, offset)
+	where, args := page.where(nil)
+	orderLimit, args := page.orderLimit(args)
+	query += where + orderLimit
+
+	rows, err := b.db.Query(ctx, query, args...)
 	if err != nil {
 		b.log.Error("error is while selecting baskets", logger.Error(err))
 
@@ -107,7 +122,18 @@ func (b *basketRepo) GetList(ctx context.Context, req models.GetListRequest) (mo
 
 	for rows.Next() {
 		basket := models.Basket{}
-		if err = rows.Scan(&basket.ID, &basket.CustomerID, &basket.TotalSum, &createdAt, &updatedAt); err != nil {
+		if err = rows.Scan(
+			&basket.ID,
+			&basket.CustomerID,
+			&basket.NetSum,
+			&basket.TaxSum,
+			&basket.TotalSum,
+			&basket.TaxInclusive,
+			&basket.Currency,
+			&basket.Version,
+			&createdAt,
+			&updatedAt,
+		); err != nil {
 			b.log.Error("error is while scanning data", logger.Error(err))
 
 			return models.BasketResponse{}, err
@@ -125,29 +151,36 @@ func (b *basketRepo) GetList(ctx context.Context, req models.GetListRequest) (mo
 
 	}
 
+	baskets, cursors := paginate(page, baskets, func(basket models.Basket) (string, string) {
+		return basket.CreatedAt, basket.ID
+	})
+
 	return models.BasketResponse{
 		Baskets: baskets,
 		Count:   count,
+		Cursors: cursors,
 	}, nil
 }
 
 func (b *basketRepo) Update(ctx context.Context, basket models.UpdateBasket) (string, error) {
 	bas := models.Basket{}
 
-	if rowsAffected, err := b.db.Exec(ctx, `update baskets set customer_id = $1, total_sum = $2, updated_at = now() where id = $3 `,
+	rowsAffected, err := b.db.Exec(ctx, `update baskets set customer_id = $1, total_sum = $2, updated_at = now() where id = $3 and version = $4`,
 		&basket.CustomerID,
 		&basket.TotalSum,
 		&basket.ID,
-	); err != nil {
-		if r := rowsAffected.RowsAffected(); r == 0 {
-			b.log.Error("error is in rows affected", logger.Error(err))
-
-			return "", err
-		}
+		basket.Version,
+	)
+	if err != nil {
 		return "", err
 	}
 
-	if err := b.db.QueryRow(ctx, `select id, customer_id, total_sum from baskets where id = $1`,
+	// the basket is gone or was changed since the version was read
+	if rowsAffected.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
+	if err = b.db.QueryRow(ctx, `select id, customer_id, total_sum from baskets where id = $1`,
 		basket.ID).Scan(&bas.ID, &bas.CustomerID, &bas.TotalSum); err != nil {
 			b.log.Error("error is while selecting", logger.Error(err))
 
@@ -156,6 +189,28 @@ func (b *basketRepo) Update(ctx context.Context, basket models.UpdateBasket) (st
 	return bas.ID, nil
 }
 
+// Patch updates only the fields of the basket present in a merge patch.
+func (b *basketRepo) Patch(ctx context.Context, basket models.UpdateBasket, fields []string) (string, error) {
+	set, args := patchSet(fields, map[string]patchColumn{
+		"customer_id": {column: "customer_id", value: basket.CustomerID},
+		"total_sum":   {column: "total_sum", value: basket.TotalSum},
+	}, basket.ID, basket.Version)
+
+	rowsAffected, err := b.db.Exec(ctx, patchQuery("baskets", set, ""), args...)
+	if err != nil {
+		b.log.Error("error is while patching basket", logger.Error(err))
+
+		return "", err
+	}
+
+	// the basket is gone or was changed since the version was read
+	if rowsAffected.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
+	return basket.ID, nil
+}
+
 func (b *basketRepo) Delete(ctx context.Context, key models.PrimaryKey) error {
 	query := `update baskets set deleted_at = extract(epoch from current_timestamp) where id = $1`
 	if rowsAffected, err := b.db.Exec(ctx, query, key.ID); err != nil {
@@ -166,3 +221,22 @@ func (b *basketRepo) Delete(ctx context.Context, key models.PrimaryKey) error {
 	}
 	return nil
 }
+
+func (b *basketRepo) UpdateSums(ctx context.Context, sums models.UpdateBasketSums) error {
+	query := `update baskets set net_sum = $1, tax_sum = $2, total_sum = $3, tax_inclusive = $4, currency = $5, updated_at = now() where id = $6`
+
+	if _, err := b.db.Exec(ctx, query,
+		sums.NetSum,
+		sums.TaxSum,
+		sums.TotalSum,
+		sums.TaxInclusive,
+		sums.Currency,
+		sums.ID,
+	); err != nil {
+		b.log.Error("error is while updating basket sums", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
@@ -10,17 +10,18 @@ import (
 	"test/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type basketProductRepo struct {
-	db  *pgxpool.Pool
+	db  txPool
 	log logger.ILogger
 }
 
 func NewBasketProductRepo(db *pgxpool.Pool, log logger.ILogger) storage.IBasketProductStorage {
 	return &basketProductRepo{
-		db:  db,
+		db:  txPool{db},
 		log: log,
 	}
 }
@@ -46,13 +47,21 @@ func (b *basketProductRepo) Create(ctx context.Context, product models.CreateBas
 func (b *basketProductRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.BasketProduct, error) {
 	var createdAt, updatedAt = sql.NullString{}, sql.NullString{}
 	product := models.BasketProduct{}
-	query := `select id, basket_id, product_id, quantity, created_at, updated_at from basket_products where id = $1 and deleted_at = 0`
+	query := `select id, basket_id, product_id, quantity, price, tax_rate, net_sum, tax_sum, gross_sum, cost, version, created_at, updated_at
+			from basket_products where id = $1 and deleted_at = 0`
 
 	if err := b.db.QueryRow(ctx, query, key.ID).Scan(
 		&product.ID,
 		&product.BasketID,
 		&product.ProductID,
 		&product.Quantity,
+		&product.Price,
+		&product.TaxRate,
+		&product.NetSum,
+		&product.TaxSum,
+		&product.GrossSum,
+		&product.Cost,
+		&product.Version,
 		&createdAt,
 		&updatedAt,
 	); err != nil {
@@ -77,13 +86,16 @@ func (b *basketProductRepo) GetList(ctx context.Context, request models.GetListR
 		count                = 0
 		basketProducts       = []models.BasketProduct{}
 		query, countQuery    string
-		page                 = request.Page
-		offset               = (page - 1) * request.Limit
 		search               = request.Search
 		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
 	)
 
-	countQuery = `select count(1) from basket_products where deleted_at = 0 `
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.BasketProductResponse{}, err
+	}
+
+	countQuery = `select count(1) from basket_products where ` + deletedCondition(request, "deleted_at") + ` `
 	if search != "" {
 		countQuery += fmt.Sprintf(` and CAST(quantity AS TEXT) = '%s'`, search)
 	}
@@ -92,13 +104,16 @@ func (b *basketProductRepo) GetList(ctx context.Context, request models.GetListR
 		countQuery += fmt.Sprintf(" and basket_id = '%s'", request.BasketID)
 	}
 
-	if err := b.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		b.log.Error("error is while scanning count", logger.Error(err))
+	if !request.SkipCount {
+		if err = b.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
+			b.log.Error("error is while scanning count", logger.Error(err))
 
-		return models.BasketProductResponse{}, err
+			return models.BasketProductResponse{}, err
+		}
 	}
 
-	query = `select id, basket_id, product_id, quantity, created_at, updated_at from basket_products where deleted_at = 0`
+	query = `select id, basket_id, product_id, quantity, price, tax_rate, net_sum, tax_sum, gross_sum, cost, version, created_at, updated_at
+			from basket_products where ` + deletedCondition(request, "deleted_at")
 	if search != "" {
 		query += fmt.Sprintf(` and CAST(quantity AS TEXT) = '%s'`, search)
 	}
@@ -107,9 +122,11 @@ func (b *basketProductRepo) GetList(ctx context.Context, request models.GetListR
 		query += fmt.Sprintf(" and basket_id = '%s'", request.BasketID)
 	}
 
-	query += ` order by created_at desc LIMIT $1 OFFSET $2`
+	where, args := page.where(nil)
+	orderLimit, args := page.orderLimit(args)
+	query += where + orderLimit
 
-	rows, err := b.db.Query(ctx, query, request.Limit, offset)
+	rows, err := b.db.Query(ctx, query, args...)
 	if err != nil {
 		b.log.Error("error is while selecting basket products", logger.Error(err))
 
@@ -123,6 +140,13 @@ func (b *basketProductRepo) GetList(ctx context.Context, request models.GetListR
 			&basketProd.BasketID,
 			&basketProd.ProductID,
 			&basketProd.Quantity,
+			&basketProd.Price,
+			&basketProd.TaxRate,
+			&basketProd.NetSum,
+			&basketProd.TaxSum,
+			&basketProd.GrossSum,
+			&basketProd.Cost,
+			&basketProd.Version,
 			&createdAt,
 			&updatedAt,
 		); err != nil {
@@ -140,28 +164,57 @@ func (b *basketProductRepo) GetList(ctx context.Context, request models.GetListR
 		basketProducts = append(basketProducts, basketProd)
 	}
 
+	basketProducts, cursors := paginate(page, basketProducts, func(basketProduct models.BasketProduct) (string, string) {
+		return basketProduct.CreatedAt, basketProduct.ID
+	})
+
 	return models.BasketProductResponse{
 		BasketProducts: basketProducts,
 		Count:          count,
+		Cursors:        cursors,
 	}, err
 }
 
 func (b *basketProductRepo) Update(ctx context.Context, product models.UpdateBasketProduct) (string, error) {
-	query := `update basket_products set product_id = $1, quantity = $2, updated_at = now() where id = $3 `
-	if rowsAffected, err := b.db.Exec(ctx, query,
+	query := `update basket_products set product_id = $1, quantity = $2, updated_at = now() where id = $3 and version = $4`
+	rowsAffected, err := b.db.Exec(ctx, query,
 		&product.ProductID,
 		&product.Quantity,
-		&product.ID); err != nil {
-		if r := rowsAffected.RowsAffected(); r == 0 {
-			b.log.Error("error is in rows affected", logger.Error(err))
-
-			return "", err
-		}
+		&product.ID,
+		product.Version)
+	if err != nil {
 		b.log.Error("error is while updating basket_products", logger.Error(err))
 
 		return "", err
 	}
 
+	// the basket product is gone or was changed since the version was read
+	if rowsAffected.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
+	return product.ID, nil
+}
+
+// Patch updates only the fields of the basket product present in a merge patch.
+func (b *basketProductRepo) Patch(ctx context.Context, product models.UpdateBasketProduct, fields []string) (string, error) {
+	set, args := patchSet(fields, map[string]patchColumn{
+		"product_id": {column: "product_id", value: product.ProductID},
+		"quantity":   {column: "quantity", value: product.Quantity},
+	}, product.ID, product.Version)
+
+	rowsAffected, err := b.db.Exec(ctx, patchQuery("basket_products", set, ""), args...)
+	if err != nil {
+		b.log.Error("error is while patching basket_products", logger.Error(err))
+
+		return "", err
+	}
+
+	// the basket product is gone or was changed since the version was read
+	if rowsAffected.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
 	return product.ID, nil
 }
 
@@ -181,7 +234,8 @@ func (b *basketProductRepo) Delete(ctx context.Context, key models.PrimaryKey) e
 	return nil
 }
 
-func (b *basketProductRepo) AddProducts(ctx context.Context, basketID string, products map[string]int) error {
+// AddProducts stores sold lines of the basket together with their prices, taxes and costs of goods.
+func (b *basketProductRepo) AddProducts(ctx context.Context, basketID string, products []models.CheckProduct) error {
 	var (
 		insertStatements []string
 	)
@@ -191,9 +245,11 @@ func (b *basketProductRepo) AddProducts(ctx context.Context, basketID string, pr
            %s
 		END $$
 `
-	for productID, quantity := range products {
-		insertStatements = append(insertStatements, fmt.Sprintf(`insert into basket_products (id, basket_id, product_id, quantity)
-                      values ('%s', '%s', '%s', %d) ;`, uuid.New(), basketID, productID, quantity))
+	for _, product := range products {
+		insertStatements = append(insertStatements, fmt.Sprintf(`insert into basket_products 
+					(id, basket_id, product_id, quantity, price, tax_rate, net_sum, tax_sum, gross_sum, cost)
+                      values ('%s', '%s', '%s', %s, %d, %f, %d, %d, %d, %d) ;`, uuid.New(), basketID, product.ID, product.Quantity,
+			product.Price, product.TaxRate, product.NetSum, product.TaxSum, product.GrossSum, product.Cost))
 	}
 
 	finalQuery := fmt.Sprintf(query, strings.Join(insertStatements, "\n"))
@@ -5,6 +5,7 @@ import (
 	"test/api/models"
 	"test/config"
 	"test/pkg/logger"
+	"test/pkg/measure"
 	"testing"
 
 	"github.com/go-playground/assert/v2"
@@ -23,7 +24,7 @@ func TestBasketProductRepo_Create(t *testing.T) {
 	createBasketProduct := models.CreateBasketProduct{
 		BasketID:  "9b2c0728-ab31-4ec0-aa5d-864084491cdb",
 		ProductID: "cc894270-9c85-4ad4-8e87-dcc540a483b3",
-		Quantity:  12,
+		Quantity:  measure.Whole(12),
 	}
 
 	basketProductID, err := pgStore.BasketProduct().Create(context.Background(), createBasketProduct)
@@ -54,7 +55,7 @@ func TestBaketProduct_GetById(t *testing.T) {
 	createBasketProduct := models.CreateBasketProduct{
 		BasketID:  "9b2c0728-ab31-4ec0-aa5d-864084491cdb",
 		ProductID: "cc894270-9c85-4ad4-8e87-dcc540a483b3",
-		Quantity:  12,
+		Quantity:  measure.Whole(12),
 	}
 
 	basketProductID, err := pgStore.BasketProduct().Create(context.Background(), createBasketProduct)
@@ -75,7 +76,7 @@ func TestBaketProduct_GetById(t *testing.T) {
 		}
 
 		if basketProduct.Quantity < 0 {
-			t.Errorf("expected > 0, but got %d", basketProduct.Quantity)
+			t.Errorf("expected > 0, but got %s", basketProduct.Quantity)
 		}
 
 		if basketProduct.BasketID == "" {
@@ -125,7 +126,7 @@ func TestBasketProduct_Update(t *testing.T) {
 	createBasketProduct := models.CreateBasketProduct{
 		BasketID:  "9b2c0728-ab31-4ec0-aa5d-864084491cdb",
 		ProductID: "cc894270-9c85-4ad4-8e87-dcc540a483b3",
-		Quantity:  12,
+		Quantity:  measure.Whole(12),
 	}
 
 	basketProductID, err := pgStore.BasketProduct().Create(context.Background(), createBasketProduct)
@@ -136,8 +137,9 @@ func TestBasketProduct_Update(t *testing.T) {
 
 	updateBasketProduct := models.UpdateBasketProduct{
 		ID:        basketProductID,
+		Version:   1,
 		ProductID: "cc894270-9c85-4ad4-8e87-dcc540a483b3",
-		Quantity:  20,
+		Quantity:  measure.Whole(20),
 	}
 
 	updatedID, err := pgStore.BasketProduct().Update(context.Background(), updateBasketProduct)
@@ -170,7 +172,7 @@ func TestBasketProduct_Delete(t *testing.T) {
 	createBasketProduct := models.CreateBasketProduct{
 		BasketID:  "9b2c0728-ab31-4ec0-aa5d-864084491cdb",
 		ProductID: "cc894270-9c85-4ad4-8e87-dcc540a483b3",
-		Quantity:  12,
+		Quantity:  measure.Whole(12),
 	}
 
 	basketProductID, err := pgStore.BasketProduct().Create(context.Background(), createBasketProduct)
@@ -112,6 +112,7 @@ func TestBaksetRepo_Update(t *testing.T) {
 
 	updateBasket := models.UpdateBasket{
 		ID:         basketid,
+		Version:    1,
 		CustomerID: "c5eebf53-a536-4745-b816-2264af15d61f",
 		TotalSum:   12222,
 	}
@@ -150,3 +151,41 @@ func Test_Delete(t *testing.T) {
 		t.Errorf("Error deleting basket: %v", err)
 	}
 }
+
+func TestBasketRepo_UpdateSums(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	basketID, err := pgStore.Basket().Create(context.Background(), models.CreateBasket{
+		CustomerID: "c5eebf53-a536-4745-b816-2264af15d61f",
+	})
+	if err != nil {
+		t.Fatalf("error while creating basket error: %v", err)
+	}
+
+	sums := models.UpdateBasketSums{
+		ID:           basketID,
+		NetSum:       1000,
+		TaxSum:       120,
+		TotalSum:     1120,
+		TaxInclusive: false,
+	}
+
+	if err = pgStore.Basket().UpdateSums(context.Background(), sums); err != nil {
+		t.Fatalf("error while updating basket sums error: %v", err)
+	}
+
+	basket, err := pgStore.Basket().GetByID(context.Background(), models.PrimaryKey{ID: basketID})
+	if err != nil {
+		t.Errorf("error while getting basket error: %v", err)
+	}
+
+	assert.Equal(t, basket.NetSum, sums.NetSum)
+	assert.Equal(t, basket.TaxSum, sums.TaxSum)
+	assert.Equal(t, basket.TotalSum, sums.TotalSum)
+	assert.Equal(t, basket.TaxInclusive, sums.TaxInclusive)
+}
@@ -9,17 +9,18 @@ import (
 	"test/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type branchRepo struct {
-	db  *pgxpool.Pool
+	db  txPool
 	log logger.ILogger
 }
 
 func NewBranchRepo(db *pgxpool.Pool, log logger.ILogger) storage.IBranchStorage {
 	return branchRepo{
-		db:  db,
+		db:  txPool{db},
 		log: log,
 	}
 }
@@ -27,14 +28,15 @@ func NewBranchRepo(db *pgxpool.Pool, log logger.ILogger) storage.IBranchStorage
 func (b branchRepo) Create(ctx context.Context, branch models.CreateBranch) (string, error) {
 	branchID := uuid.New()
 
-	query := `insert into branches (id, name, address, phone_number) 
-									values($1, $2, $3, $4)`
+	query := `insert into branches (id, name, address, phone_number, currency) 
+									values($1, $2, $3, $4, $5)`
 
 	if rowsAffected, err := b.db.Exec(ctx, query,
 		branchID,
 		branch.Name,
 		branch.Address,
 		branch.PhoneNumber,
+		branch.Currency,
 	); err != nil {
 		if r := rowsAffected.RowsAffected(); r == 0 {
 			b.log.Error("error is rows affected", logger.Error(err))
@@ -63,7 +65,7 @@ func (b branchRepo) Create(ctx context.Context, branch models.CreateBranch) (str
 func (b branchRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.Branch, error) {
 	var createdAt, updatedAt = sql.NullString{}, sql.NullString{}
 	branch := models.Branch{}
-	query := `select  id, name, address, phone_number, created_at, updated_at 
+	query := `select  id, name, address, phone_number, currency, version, created_at, updated_at 
 					from branches where id = $1 and deleted_at = 0
 `
 	if err := b.db.QueryRow(ctx, query, key.ID).Scan(
@@ -71,6 +73,8 @@ func (b branchRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.
 		&branch.Name,
 		&branch.Address,
 		&branch.PhoneNumber,
+		&branch.Currency,
+		&branch.Version,
 		&createdAt,
 		&updatedAt); err != nil {
 		b.log.Error("error is while selecting by id", logger.Error(err))
@@ -94,33 +98,41 @@ func (b branchRepo) GetList(ctx context.Context, request models.GetListRequest)
 		count                = 0
 		branches             = []models.Branch{}
 		query, countQuery    string
-		page                 = request.Page
-		offset               = (page - 1) * request.Limit
 		search               = request.Search
 		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
 	)
 
-	countQuery = `select count(1) from branches where deleted_at = 0 `
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.BranchResponse{}, err
+	}
+
+	countQuery = `select count(1) from branches where ` + deletedCondition(request, "deleted_at") + ` `
 
 	if search != "" {
 		countQuery += fmt.Sprintf(` and name ilike '%s'`, search)
 	}
 
-	if err := b.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		b.log.Error("error is while scanning count", logger.Error(err))
+	if !request.SkipCount {
+		if err = b.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
+			b.log.Error("error is while scanning count", logger.Error(err))
 
-		return models.BranchResponse{}, err
+			return models.BranchResponse{}, err
+		}
 	}
 
-	query = `select id, name, address, phone_number, created_at, updated_at
-							from branches where deleted_at = 0 
+	query = `select id, name, address, phone_number, currency, version, created_at, updated_at
+							from branches where ` + deletedCondition(request, "deleted_at") + ` 
 `
 	if search != "" {
 		query += fmt.Sprintf(` and name ilike '%s' `, search)
 	}
 
-	query += ` order by created_at desc LIMIT $1 OFFSET $2 `
-	rows, err := b.db.Query(ctx, query, request.Limit, offset)
+	where, args := page.where(nil)
+	orderLimit, args := page.orderLimit(args)
+	query += where + orderLimit
+
+	rows, err := b.db.Query(ctx, query, args...)
 	if err != nil {
 		b.log.Error("error is while selecting * from branches", logger.Error(err))
 
@@ -134,6 +146,8 @@ func (b branchRepo) GetList(ctx context.Context, request models.GetListRequest)
 			&branch.Name,
 			&branch.Address,
 			&branch.PhoneNumber,
+			&branch.Currency,
+			&branch.Version,
 			&createdAt,
 			&updatedAt); err != nil {
 			b.log.Error("error is while scanning branch", logger.Error(err))
@@ -150,30 +164,62 @@ func (b branchRepo) GetList(ctx context.Context, request models.GetListRequest)
 		branches = append(branches, branch)
 	}
 
+	branches, cursors := paginate(page, branches, func(branch models.Branch) (string, string) {
+		return branch.CreatedAt, branch.ID
+	})
+
 	return models.BranchResponse{
 		Branches: branches,
 		Count:    count,
+		Cursors:  cursors,
 	}, err
 }
 func (b branchRepo) Update(ctx context.Context, branch models.UpdateBranch) (string, error) {
-	query := `update branches set name = $1, address = $2, phone_number = $3, updated_at = Now() 
-                					where id = $4`
+	query := `update branches set name = $1, address = $2, phone_number = $3, currency = $4, updated_at = Now() 
+                					where id = $5 and version = $6`
 
-	if rowsAffected, err := b.db.Exec(ctx, query,
+	rowsAffected, err := b.db.Exec(ctx, query,
 		&branch.Name,
 		&branch.Address,
 		&branch.PhoneNumber,
-		&branch.ID); err != nil {
-		if r := rowsAffected.RowsAffected(); r == 0 {
-			b.log.Error("error is in rows affected", logger.Error(err))
-
-			return "", err
-		}
+		&branch.Currency,
+		&branch.ID,
+		branch.Version)
+	if err != nil {
 		b.log.Error("error is while updating branch", logger.Error(err))
 
 		return "", err
 	}
 
+	// the branch is gone or was changed since the version was read
+	if rowsAffected.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
+	return branch.ID, nil
+}
+
+// Patch updates only the fields of the branch present in a merge patch.
+func (b branchRepo) Patch(ctx context.Context, branch models.UpdateBranch, fields []string) (string, error) {
+	set, args := patchSet(fields, map[string]patchColumn{
+		"name":         {column: "name", value: branch.Name},
+		"address":      {column: "address", value: branch.Address},
+		"phone_number": {column: "phone_number", value: branch.PhoneNumber},
+		"currency":     {column: "currency", value: branch.Currency},
+	}, branch.ID, branch.Version)
+
+	rowsAffected, err := b.db.Exec(ctx, patchQuery("branches", set, ""), args...)
+	if err != nil {
+		b.log.Error("error is while patching branch", logger.Error(err))
+
+		return "", err
+	}
+
+	// the branch is gone or was changed since the version was read
+	if rowsAffected.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
 	return branch.ID, nil
 }
 func (b branchRepo) Delete(ctx context.Context, key models.PrimaryKey) error {
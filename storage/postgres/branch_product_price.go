@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type branchProductPriceRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewBranchProductPriceRepo(db *pgxpool.Pool, log logger.ILogger) storage.IBranchProductPriceStorage {
+	return &branchProductPriceRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+func (b *branchProductPriceRepo) UpsertMultiple(ctx context.Context, request models.SetBranchProductPrices) error {
+	var (
+		ids        = make([]string, 0, len(request.Prices))
+		productIDs = make([]string, 0, len(request.Prices))
+		prices     = make([]int64, 0, len(request.Prices))
+	)
+
+	for _, price := range request.Prices {
+		ids = append(ids, uuid.New().String())
+		productIDs = append(productIDs, price.ProductID)
+		prices = append(prices, int64(price.Price))
+	}
+
+	query := `insert into branch_product_prices (id, branch_id, product_id, price, currency)
+			select id, $1::uuid, product_id, price, (select currency from branches where id = $1::uuid) from unnest($2::uuid[], $3::uuid[], $4::bigint[]) as t(id, product_id, price)
+		on conflict (branch_id, product_id) do update set price = excluded.price, updated_at = now()`
+
+	if _, err := b.db.Exec(ctx, query, request.BranchID, ids, productIDs, prices); err != nil {
+		b.log.Error("error is while upserting branch product prices", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (b *branchProductPriceRepo) GetList(ctx context.Context, request models.GetListRequest) (models.BranchProductPricesResponse, error) {
+	var (
+		prices               = []models.BranchProductPrice{}
+		count                = 0
+		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
+	)
+
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.BranchProductPricesResponse{}, err
+	}
+
+	if !request.SkipCount {
+		countQuery := `select count(1) from branch_product_prices where branch_id = $1`
+		if err = b.db.QueryRow(ctx, countQuery, request.BranchID).Scan(&count); err != nil {
+			b.log.Error("error is while scanning count of branch product prices", logger.Error(err))
+
+			return models.BranchProductPricesResponse{}, err
+		}
+	}
+
+	where, args := page.where([]interface{}{request.BranchID})
+	orderLimit, args := page.orderLimit(args)
+
+	query := `select id, branch_id, product_id, price, currency, created_at, updated_at
+			from branch_product_prices where branch_id = $1` + where + orderLimit
+
+	rows, err := b.db.Query(ctx, query, args...)
+	if err != nil {
+		b.log.Error("error is while selecting branch product prices", logger.Error(err))
+
+		return models.BranchProductPricesResponse{}, err
+	}
+
+	for rows.Next() {
+		price := models.BranchProductPrice{}
+		if err = rows.Scan(
+			&price.ID,
+			&price.BranchID,
+			&price.ProductID,
+			&price.Price,
+			&price.Currency,
+			&createdAt,
+			&updatedAt,
+		); err != nil {
+			b.log.Error("error is while scanning branch product price", logger.Error(err))
+
+			return models.BranchProductPricesResponse{}, err
+		}
+
+		if createdAt.Valid {
+			price.CreatedAt = createdAt.String
+		}
+
+		if updatedAt.Valid {
+			price.UpdatedAt = updatedAt.String
+		}
+
+		prices = append(prices, price)
+	}
+
+	prices, cursors := paginate(page, prices, func(price models.BranchProductPrice) (string, string) {
+		return price.CreatedAt, price.ID
+	})
+
+	return models.BranchProductPricesResponse{
+		Prices:  prices,
+		Count:   count,
+		Cursors: cursors,
+	}, nil
+}
+
+func (b *branchProductPriceRepo) DeleteMultiple(ctx context.Context, request models.DeleteBranchProductPrices) error {
+	query := `delete from branch_product_prices where branch_id = $1 and product_id = any($2::uuid[])`
+
+	if _, err := b.db.Exec(ctx, query, request.BranchID, request.ProductIDs); err != nil {
+		b.log.Error("error is while deleting branch product prices", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/helper"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestBranchProductPriceRepo_Upsert(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	branchID := "aa541fcc-bf74-11ee-ae0b-166244b65504"
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "apple",
+		Price:         100,
+		OriginalPrice: 80,
+		Quantity:      measure.Whole(34),
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      branchID,
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	for _, price := range []money.Amount{150, 170} {
+		if err = pgStore.BranchProductPrice().UpsertMultiple(context.Background(), models.SetBranchProductPrices{
+			BranchID: branchID,
+			Prices:   []models.SetBranchProductPrice{{ProductID: productID, Price: price}},
+		}); err != nil {
+			t.Fatalf("error while setting branch product price: %v", err)
+		}
+	}
+
+	products, err := pgStore.Product().GetListByIDs(context.Background(), []string{productID}, branchID)
+	if err != nil {
+		t.Fatalf("error while getting products by ids: %v", err)
+	}
+
+	assert.Equal(t, len(products.Products), 1)
+	assert.Equal(t, products.Products[0].Price, money.Amount(170))
+
+	if err = pgStore.BranchProductPrice().DeleteMultiple(context.Background(), models.DeleteBranchProductPrices{
+		BranchID:   branchID,
+		ProductIDs: []string{productID},
+	}); err != nil {
+		t.Fatalf("error while deleting branch product price: %v", err)
+	}
+
+	products, err = pgStore.Product().GetListByIDs(context.Background(), []string{productID}, branchID)
+	if err != nil {
+		t.Fatalf("error while getting products by ids: %v", err)
+	}
+
+	assert.Equal(t, products.Products[0].Price, money.Amount(100))
+}
+
+func TestProductRepo_GetListWithOtherBranchPrices(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	ownerBranchID := "aa541fcc-bf74-11ee-ae0b-166244b65504"
+
+	pricingBranchID, err := pgStore.Branch().Create(context.Background(), models.CreateBranch{
+		Name:        "pricing branch",
+		Address:     helper.GenerateFullName() + " street",
+		PhoneNumber: helper.GeneratePhoneNumber(),
+		Currency:    "UZS",
+	})
+	if err != nil {
+		t.Fatalf("error while creating branch: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:       "pear",
+		Price:      100,
+		Quantity:   measure.Whole(5),
+		CategoryID: "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:   ownerBranchID,
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	if err = pgStore.BranchProductPrice().UpsertMultiple(context.Background(), models.SetBranchProductPrices{
+		BranchID: pricingBranchID,
+		Prices:   []models.SetBranchProductPrice{{ProductID: productID, Price: 130}},
+	}); err != nil {
+		t.Fatalf("error while setting branch product price: %v", err)
+	}
+
+	// products of one branch are listed with the prices of another
+	products, err := pgStore.Product().GetList(context.Background(), models.GetListRequest{
+		Page:          1,
+		Limit:         10,
+		Search:        "pear",
+		BranchID:      ownerBranchID,
+		PriceBranchID: pricingBranchID,
+	})
+	if err != nil {
+		t.Fatalf("error while getting products: %v", err)
+	}
+
+	found := false
+	for _, product := range products.Products {
+		if product.ID == productID {
+			found = true
+			assert.Equal(t, product.Price, money.Amount(130))
+		}
+	}
+
+	assert.Equal(t, found, true)
+}
@@ -129,6 +129,7 @@ func TestBranchRepo_Update(t *testing.T) {
 
 	updateBranch := models.UpdateBranch{
 		ID:          branchID,
+		Version:     1,
 		Name:        "2421421 Name",
 		Address:     "dffcds Address",
 		PhoneNumber: "+9219321",
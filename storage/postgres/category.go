@@ -9,25 +9,26 @@ import (
 	"test/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type categoryRepo struct {
-	db  *pgxpool.Pool
+	db  txPool
 	log logger.ILogger
 }
 
 func NewCategoryRepo(db *pgxpool.Pool, log logger.ILogger) storage.ICategoryStorage {
 	return &categoryRepo{
-		db:  db,
+		db:  txPool{db},
 		log: log,
 	}
 }
 func (c *categoryRepo) Create(ctx context.Context, category models.CreateCategory) (string, error) {
 	id := uuid.New()
-	query := `insert into categories (id, name) values($1, $2)`
+	query := `insert into categories (id, parent_id, name, tax_rate) values($1, nullif($2, '')::uuid, $3, $4)`
 
-	if rowsAffected, err := c.db.Exec(ctx, query, id, category.Name); err != nil {
+	if rowsAffected, err := c.db.Exec(ctx, query, id, category.ParentID, category.Name, category.TaxRate); err != nil {
 		if r := rowsAffected.RowsAffected(); r == 0 {
 			
 			c.log.Error("error is in rows affected", logger.Error(err))
@@ -46,8 +47,9 @@ func (c *categoryRepo) GetByID(ctx context.Context, key models.PrimaryKey) (mode
 	createdAt, updatedAt := sql.NullString{}, sql.NullString{}
 	category := models.Category{}
 
-	query := `select id, name, created_at, updated_at from categories where id = $1 and deleted_at = 0`
-	if err := c.db.QueryRow(ctx, query, key.ID).Scan(&category.ID, &category.Name, &createdAt, &updatedAt); err != nil {
+	query := `select id, coalesce(parent_id::text, ''), name, tax_rate, version, created_at, updated_at, ` +
+		deletedReferences("categories", reference{"parent_id", "categories"}) + ` from categories where id = $1 and deleted_at = 0`
+	if err := c.db.QueryRow(ctx, query, key.ID).Scan(&category.ID, &category.ParentID, &category.Name, &category.TaxRate, &category.Version, &createdAt, &updatedAt, &category.DeletedReferences); err != nil {
 		c.log.Error("error is while getting by id", logger.Error(err))
 
 		return models.Category{}, err
@@ -66,34 +68,41 @@ func (c *categoryRepo) GetList(ctx context.Context, request models.GetListReques
 	var (
 		query, countQuery    string
 		count                = 0
-		page                 = request.Page
-		offset               = (page - 1) * request.Limit
 		search               = request.Search
 		categories           = []models.Category{}
 		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
 	)
 
-	countQuery = `select count(1) from categories where deleted_at = 0`
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.CategoryResponse{}, err
+	}
+
+	countQuery = `select count(1) from categories where ` + deletedCondition(request, "deleted_at")
 
 	if search != "" {
 		countQuery += fmt.Sprintf(` and name ilike '%%%s%%'`, search)
 	}
 
-	if err := c.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		c.log.Error("error is while scanning count", logger.Error(err))
+	if !request.SkipCount {
+		if err = c.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
+			c.log.Error("error is while scanning count", logger.Error(err))
 
-		return models.CategoryResponse{}, err
+			return models.CategoryResponse{}, err
+		}
 	}
 
-	query = `select id, name, created_at, updated_at from categories where deleted_at = 0`
+	query = `select id, coalesce(parent_id::text, ''), name, tax_rate, version, created_at, updated_at from categories where ` + deletedCondition(request, "deleted_at")
 
 	if search != "" {
 		query += fmt.Sprintf(` and name ilike '%%%s%%' `, search)
 	}
 
-	query += ` order by created_at desc LIMIT $1 OFFSET $2`
+	where, args := page.where(nil)
+	orderLimit, args := page.orderLimit(args)
+	query += where + orderLimit
 
-	rows, err := c.db.Query(ctx, query, request.Limit, offset)
+	rows, err := c.db.Query(ctx, query, args...)
 	if err != nil {
 		c.log.Error("error is while selecting categories", logger.Error(err))
 
@@ -102,7 +111,7 @@ func (c *categoryRepo) GetList(ctx context.Context, request models.GetListReques
 
 	for rows.Next() {
 		cat := models.Category{}
-		if err = rows.Scan(&cat.ID, &cat.Name, &createdAt, &updatedAt); err != nil {
+		if err = rows.Scan(&cat.ID, &cat.ParentID, &cat.Name, &cat.TaxRate, &cat.Version, &createdAt, &updatedAt); err != nil {
 			c.log.Error("error is while scanning category", logger.Error(err))
 
 			return models.CategoryResponse{}, err
@@ -115,25 +124,54 @@ func (c *categoryRepo) GetList(ctx context.Context, request models.GetListReques
 		}
 		categories = append(categories, cat)
 	}
+	categories, cursors := paginate(page, categories, func(category models.Category) (string, string) {
+		return category.CreatedAt, category.ID
+	})
+
 	return models.CategoryResponse{
 		Category: categories,
 		Count:    count,
+		Cursors:  cursors,
 	}, err
 }
 
 func (c *categoryRepo) Update(ctx context.Context, category models.UpdateCategory) (string, error) {
-	query := `update categories set name = $1, updated_at = now() where id = $2`
+	query := `update categories set parent_id = nullif($1, '')::uuid, name = $2, tax_rate = $3, updated_at = now() where id = $4 and version = $5`
 
-	if rowsAffected, err := c.db.Exec(ctx, query, &category.Name, &category.ID); err != nil {
-		if r := rowsAffected.RowsAffected(); r == 0 {
-			c.log.Error("error is in rows affected", logger.Error(err))
-
-			return "", err
-		}
+	rowsAffected, err := c.db.Exec(ctx, query, category.ParentID, &category.Name, category.TaxRate, &category.ID, category.Version)
+	if err != nil {
 		c.log.Error("error is while updating category", logger.Error(err))
 
 		return "", err
 	}
+
+	// the category is gone or was changed since the version was read
+	if rowsAffected.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+	return category.ID, nil
+}
+
+// Patch updates only the fields of the category present in a merge patch.
+func (c *categoryRepo) Patch(ctx context.Context, category models.UpdateCategory, fields []string) (string, error) {
+	set, args := patchSet(fields, map[string]patchColumn{
+		"parent_id": {column: "parent_id", expr: "nullif(%s, '')::uuid", value: category.ParentID},
+		"name":      {column: "name", value: category.Name},
+		"tax_rate":  {column: "tax_rate", value: category.TaxRate},
+	}, category.ID, category.Version)
+
+	rowsAffected, err := c.db.Exec(ctx, patchQuery("categories", set, ""), args...)
+	if err != nil {
+		c.log.Error("error is while patching category", logger.Error(err))
+
+		return "", err
+	}
+
+	// the category is gone or was changed since the version was read
+	if rowsAffected.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
 	return category.ID, nil
 }
 
@@ -151,3 +189,71 @@ func (c *categoryRepo) Delete(ctx context.Context, key models.PrimaryKey) error
 	}
 	return nil
 }
+
+// GetAll returns every category ordered by name, the tree is built by the caller.
+func (c *categoryRepo) GetAll(ctx context.Context) ([]models.Category, error) {
+	var (
+		categories           = []models.Category{}
+		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
+	)
+
+	query := `select id, coalesce(parent_id::text, ''), name, tax_rate, version, created_at, updated_at from categories where deleted_at = 0 order by name`
+
+	rows, err := c.db.Query(ctx, query)
+	if err != nil {
+		c.log.Error("error is while selecting all categories", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		cat := models.Category{}
+		if err = rows.Scan(&cat.ID, &cat.ParentID, &cat.Name, &cat.TaxRate, &cat.Version, &createdAt, &updatedAt); err != nil {
+			c.log.Error("error is while scanning category", logger.Error(err))
+
+			return nil, err
+		}
+		if createdAt.Valid {
+			cat.CreatedAt = createdAt.String
+		}
+		if updatedAt.Valid {
+			cat.UpdatedAt = updatedAt.String
+		}
+		categories = append(categories, cat)
+	}
+
+	return categories, nil
+}
+
+// GetDescendantIDs returns ids of the category and of all categories nested under it.
+func (c *categoryRepo) GetDescendantIDs(ctx context.Context, id string) ([]string, error) {
+	ids := []string{}
+
+	rows, err := c.db.Query(ctx, fmt.Sprintf(`select id::text from (%s) as subtree`, fmt.Sprintf(categorySubtreeQuery, "id = $1::uuid")), id)
+	if err != nil {
+		c.log.Error("error is while selecting category descendants", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var descendantID string
+		if err = rows.Scan(&descendantID); err != nil {
+			c.log.Error("error is while scanning category descendant", logger.Error(err))
+
+			return nil, err
+		}
+		ids = append(ids, descendantID)
+	}
+
+	return ids, nil
+}
+
+// categorySubtreeQuery selects ids of the categories matching the condition and of all their descendants.
+const categorySubtreeQuery = `with recursive subtree as (
+		select id from categories where %s and deleted_at = 0
+			union
+		select c.id from categories c join subtree s on c.parent_id = s.id where c.deleted_at = 0
+	) select id from subtree`
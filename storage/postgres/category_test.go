@@ -2,12 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"test/api/models"
 	"test/config"
 	"test/pkg/logger"
 	"testing"
 
 	"github.com/go-playground/assert/v2"
+	"github.com/jackc/pgx/v5"
 )
 
 func TestCategoryRepo_Create(t *testing.T) {
@@ -105,8 +107,9 @@ func TestCategoryRepo_Update(t *testing.T) {
 	}
 
 	updateCategory := models.UpdateCategory{
-		ID:   categoryID,
-		Name: "updatedName",
+		ID:      categoryID,
+		Version: 1,
+		Name:    "updatedName",
 	}
 
 	categoryUpdateID, err := pgStore.Category().Update(context.Background(), updateCategory)
@@ -117,6 +120,48 @@ func TestCategoryRepo_Update(t *testing.T) {
 	assert.Equal(t, categoryID, categoryUpdateID)
 }
 
+func TestCategoryRepo_UpdateStaleVersion(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Errorf("error while connection to db error: %v", err)
+	}
+
+	categoryID, err := pgStore.Category().Create(context.Background(), models.CreateCategory{
+		Name: "versioned",
+	})
+	if err != nil {
+		t.Errorf("error while creating category error: %v", err)
+	}
+
+	_, err = pgStore.Category().Update(context.Background(), models.UpdateCategory{
+		ID:      categoryID,
+		Version: 1,
+		Name:    "first",
+	})
+	if err != nil {
+		t.Errorf("error while updating category error: %v", err)
+	}
+
+	_, err = pgStore.Category().Update(context.Background(), models.UpdateCategory{
+		ID:      categoryID,
+		Version: 1,
+		Name:    "second",
+	})
+	if !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expected update of a stale version to find no rows, but got: %v", err)
+	}
+
+	category, err := pgStore.Category().GetByID(context.Background(), models.PrimaryKey{ID: categoryID})
+	if err != nil {
+		t.Errorf("error while getting category error: %v", err)
+	}
+
+	assert.Equal(t, 2, category.Version)
+	assert.Equal(t, "first", category.Name)
+}
+
 func TestCategoryRepo_Delete(t *testing.T) {
 	cfg := config.Load()
 
@@ -138,3 +183,105 @@ func TestCategoryRepo_Delete(t *testing.T) {
 		t.Errorf("Error deleting category: %v", err)
 	}
 }
+
+func TestCategoryRepo_Subtree(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connection to db error: %v", err)
+	}
+
+	rootID, err := pgStore.Category().Create(context.Background(), models.CreateCategory{Name: "dairy"})
+	if err != nil {
+		t.Fatalf("error while creating category error: %v", err)
+	}
+
+	childID, err := pgStore.Category().Create(context.Background(), models.CreateCategory{Name: "cheese", ParentID: rootID})
+	if err != nil {
+		t.Fatalf("error while creating category error: %v", err)
+	}
+
+	child, err := pgStore.Category().GetByID(context.Background(), models.PrimaryKey{ID: childID})
+	if err != nil {
+		t.Fatalf("error while getting category error: %v", err)
+	}
+
+	assert.Equal(t, child.ParentID, rootID)
+
+	if _, err = pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:       "cheddar",
+		Price:      9000,
+		CategoryID: childID,
+		BranchID:   "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	}); err != nil {
+		t.Fatalf("error while creating product error: %v", err)
+	}
+
+	descendantIDs, err := pgStore.Category().GetDescendantIDs(context.Background(), rootID)
+	if err != nil {
+		t.Fatalf("error while getting category descendants error: %v", err)
+	}
+
+	assert.Equal(t, len(descendantIDs), 2)
+
+	products, err := pgStore.Product().GetList(context.Background(), models.GetListRequest{
+		Page:        1,
+		Limit:       10,
+		CategoryIDs: []string{rootID},
+	})
+	if err != nil {
+		t.Fatalf("error while getting products of category error: %v", err)
+	}
+
+	assert.Equal(t, products.Count, 1)
+}
+
+func TestCategoryRepo_GetListCursor(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connection to db error: %v", err)
+	}
+
+	for _, name := range []string{"cursor 1", "cursor 2", "cursor 3"} {
+		if _, err = pgStore.Category().Create(context.Background(), models.CreateCategory{Name: name}); err != nil {
+			t.Fatalf("error while creating category error: %v", err)
+		}
+	}
+
+	firstPage, err := pgStore.Category().GetList(context.Background(), models.GetListRequest{
+		Page:      1,
+		Limit:     2,
+		SkipCount: true,
+	})
+	if err != nil {
+		t.Fatalf("error while getting first page error: %v", err)
+	}
+
+	assert.Equal(t, len(firstPage.Category), 2)
+	assert.Equal(t, firstPage.Count, 0)
+	assert.NotEqual(t, firstPage.NextCursor, "")
+
+	secondPage, err := pgStore.Category().GetList(context.Background(), models.GetListRequest{
+		Limit:  2,
+		Cursor: firstPage.NextCursor,
+	})
+	if err != nil {
+		t.Fatalf("error while getting second page error: %v", err)
+	}
+
+	assert.NotEqual(t, secondPage.Category[0].ID, firstPage.Category[1].ID)
+
+	previousPage, err := pgStore.Category().GetList(context.Background(), models.GetListRequest{
+		Limit:  2,
+		Cursor: secondPage.PrevCursor,
+	})
+	if err != nil {
+		t.Fatalf("error while getting previous page error: %v", err)
+	}
+
+	assert.Equal(t, previousPage.Category[0].ID, firstPage.Category[0].ID)
+	assert.Equal(t, previousPage.Category[1].ID, firstPage.Category[1].ID)
+}
@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type currencyRateRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewCurrencyRateRepo(db *pgxpool.Pool, log logger.ILogger) storage.ICurrencyRateStorage {
+	return &currencyRateRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+// Upsert keeps one rate per currency and date, setting a rate again overwrites it.
+func (c *currencyRateRepo) Upsert(ctx context.Context, rate models.CreateCurrencyRate) (string, error) {
+	var id string
+
+	query := `insert into currency_rates (id, currency, rate, rate_date) values ($1, $2, $3, $4)
+			on conflict (currency, rate_date) do update set rate = excluded.rate, updated_at = now()
+		returning id`
+
+	if err := c.db.QueryRow(ctx, query, uuid.New(), rate.Currency, rate.Rate, rate.RateDate).Scan(&id); err != nil {
+		c.log.Error("error is while upserting currency rate", logger.Error(err))
+
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (c *currencyRateRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.CurrencyRate, error) {
+	var (
+		rate                 = models.CurrencyRate{}
+		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
+	)
+
+	query := `select id, currency, rate, rate_date::text, created_at, updated_at from currency_rates where id = $1`
+
+	if err := c.db.QueryRow(ctx, query, key.ID).Scan(
+		&rate.ID,
+		&rate.Currency,
+		&rate.Rate,
+		&rate.RateDate,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		c.log.Error("error is while selecting currency rate by id", logger.Error(err))
+
+		return models.CurrencyRate{}, err
+	}
+
+	if createdAt.Valid {
+		rate.CreatedAt = createdAt.String
+	}
+
+	if updatedAt.Valid {
+		rate.UpdatedAt = updatedAt.String
+	}
+
+	return rate, nil
+}
+
+func (c *currencyRateRepo) GetList(ctx context.Context, request models.GetListRequest) (models.CurrencyRatesResponse, error) {
+	var (
+		rates                = []models.CurrencyRate{}
+		count                = 0
+		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
+	)
+
+	// currency and rate date are unique together
+	page, err := newListPage(request, keyset{column: "rate_date", columnType: "date", tie: "currency", tieType: "varchar", desc: true})
+	if err != nil {
+		return models.CurrencyRatesResponse{}, err
+	}
+
+	if !request.SkipCount {
+		countQuery := `select count(1) from currency_rates where ($1 = '' or currency = $1)`
+		if err = c.db.QueryRow(ctx, countQuery, request.Currency).Scan(&count); err != nil {
+			c.log.Error("error is while scanning count of currency rates", logger.Error(err))
+
+			return models.CurrencyRatesResponse{}, err
+		}
+	}
+
+	where, args := page.where([]interface{}{request.Currency})
+	orderLimit, args := page.orderLimit(args)
+
+	query := `select id, currency, rate, rate_date::text, created_at, updated_at from currency_rates
+			where ($1 = '' or currency = $1)` + where + orderLimit
+
+	rows, err := c.db.Query(ctx, query, args...)
+	if err != nil {
+		c.log.Error("error is while selecting currency rates", logger.Error(err))
+
+		return models.CurrencyRatesResponse{}, err
+	}
+
+	for rows.Next() {
+		rate := models.CurrencyRate{}
+		if err = rows.Scan(
+			&rate.ID,
+			&rate.Currency,
+			&rate.Rate,
+			&rate.RateDate,
+			&createdAt,
+			&updatedAt,
+		); err != nil {
+			c.log.Error("error is while scanning currency rate", logger.Error(err))
+
+			return models.CurrencyRatesResponse{}, err
+		}
+
+		if createdAt.Valid {
+			rate.CreatedAt = createdAt.String
+		}
+
+		if updatedAt.Valid {
+			rate.UpdatedAt = updatedAt.String
+		}
+
+		rates = append(rates, rate)
+	}
+
+	rates, cursors := paginate(page, rates, func(rate models.CurrencyRate) (string, string) {
+		return rate.RateDate, rate.Currency
+	})
+
+	return models.CurrencyRatesResponse{
+		CurrencyRates: rates,
+		Count:         count,
+		Cursors:       cursors,
+	}, nil
+}
+
+func (c *currencyRateRepo) Delete(ctx context.Context, key models.PrimaryKey) error {
+	query := `delete from currency_rates where id = $1`
+
+	if _, err := c.db.Exec(ctx, query, key.ID); err != nil {
+		c.log.Error("error is while deleting currency rate", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// GetRate returns the latest rate of currency set on or before date.
+func (c *currencyRateRepo) GetRate(ctx context.Context, currency, date string) (models.CurrencyRate, error) {
+	var (
+		rate                 = models.CurrencyRate{}
+		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
+	)
+
+	query := `select id, currency, rate, rate_date::text, created_at, updated_at from currency_rates
+			where currency = $1 and rate_date <= $2::date order by rate_date desc limit 1`
+
+	if err := c.db.QueryRow(ctx, query, currency, date).Scan(
+		&rate.ID,
+		&rate.Currency,
+		&rate.Rate,
+		&rate.RateDate,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		c.log.Error("error is while selecting currency rate on date", logger.Error(err))
+
+		return models.CurrencyRate{}, err
+	}
+
+	if createdAt.Valid {
+		rate.CreatedAt = createdAt.String
+	}
+
+	if updatedAt.Valid {
+		rate.UpdatedAt = updatedAt.String
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestCurrencyRateRepo_GetRate(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	firstID, err := pgStore.CurrencyRate().Upsert(context.Background(), models.CreateCurrencyRate{
+		Currency: "KZT",
+		Rate:     25,
+		RateDate: "2024-01-10",
+	})
+	if err != nil {
+		t.Fatalf("error while setting currency rate: %v", err)
+	}
+
+	secondID, err := pgStore.CurrencyRate().Upsert(context.Background(), models.CreateCurrencyRate{
+		Currency: "KZT",
+		Rate:     26.5,
+		RateDate: "2024-01-10",
+	})
+	if err != nil {
+		t.Fatalf("error while setting currency rate again: %v", err)
+	}
+
+	assert.Equal(t, firstID, secondID)
+
+	if _, err = pgStore.CurrencyRate().Upsert(context.Background(), models.CreateCurrencyRate{
+		Currency: "KZT",
+		Rate:     27,
+		RateDate: "2024-01-20",
+	}); err != nil {
+		t.Fatalf("error while setting later currency rate: %v", err)
+	}
+
+	rate, err := pgStore.CurrencyRate().GetRate(context.Background(), "KZT", "2024-01-15")
+	if err != nil {
+		t.Fatalf("error while getting currency rate: %v", err)
+	}
+
+	assert.Equal(t, rate.Rate, 26.5)
+	assert.Equal(t, rate.RateDate, "2024-01-10")
+
+	if _, err = pgStore.CurrencyRate().GetRate(context.Background(), "KZT", "2024-01-01"); err == nil {
+		t.Errorf("expected error while getting rate before the first one")
+	}
+}
@@ -3,24 +3,25 @@ package postgres
 import (
 	"context"
 	"test/pkg/logger"
+	"test/pkg/money"
 	"test/storage"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type dealerRepo struct {
-	db  *pgxpool.Pool
+	db  txPool
 	log logger.ILogger
 }
 
 func NewDealerRepo(db *pgxpool.Pool, log logger.ILogger) storage.IDealerStorage {
 	return &dealerRepo{
-		db:  db,
+		db:  txPool{db},
 		log: log,
 	}
 }
 
-func (d *dealerRepo) AddSum(ctx context.Context, totalSum int) error {
+func (d *dealerRepo) AddSum(ctx context.Context, totalSum money.Amount) error {
 	//ozini sum: ga qoshish kerak total sum -> update
 	query := `update dealer set sum = sum + $1 where id = '1cfd84e6-72cb-4135-a802-85d10e4183ea'`
 	if rowsAffected, err := d.db.Exec(ctx, query, &totalSum); err != nil {
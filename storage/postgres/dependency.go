@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// dependency is a table whose column references rows of another entity.
+type dependency struct {
+	entity, table, column string
+}
+
+// dependencies lists, per entity, the live rows that reference it.
+var dependencies = map[string][]dependency{
+	models.EntityCategory: {
+		{entity: models.EntityCategory, table: "categories", column: "parent_id"},
+		{entity: models.EntityProduct, table: "products", column: "category_id"},
+	},
+	models.EntityProduct: {
+		{entity: models.EntityProduct, table: "products", column: "parent_id"},
+	},
+	models.EntityBranch: {
+		{entity: models.EntityUser, table: "users", column: "branch_id"},
+		{entity: models.EntityProduct, table: "products", column: "branch_id"},
+	},
+}
+
+// reference is a foreign key column and the table it points at.
+type reference struct {
+	column, table string
+}
+
+// deletedReferences selects, as a text array, the given reference columns of alias that point at soft-deleted rows.
+func deletedReferences(alias string, references ...reference) string {
+	checks := make([]string, 0, len(references))
+	for _, ref := range references {
+		checks = append(checks, `case when exists (select 1 from `+ref.table+` r where r.id = `+alias+`.`+ref.column+
+			` and r.deleted_at <> 0) then '`+ref.column+`' end`)
+	}
+
+	return `array_remove(array[` + strings.Join(checks, ", ") + `]::text[], null)`
+}
+
+type dependencyRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewDependencyRepo(db *pgxpool.Pool, log logger.ILogger) storage.IDependencyStorage {
+	return &dependencyRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+// Dependents counts live rows directly referencing the row, entities without dependents are left out.
+func (d *dependencyRepo) Dependents(ctx context.Context, entity, id string) (models.Dependents, error) {
+	dependents := models.Dependents{}
+
+	for _, dep := range dependencies[entity] {
+		count := 0
+		if err := d.db.QueryRow(ctx, `select count(1) from `+dep.table+` where `+dep.column+` = $1 and deleted_at = 0`, id).Scan(&count); err != nil {
+			d.log.Error("error is while counting dependents", logger.Error(err))
+
+			return nil, err
+		}
+
+		if count > 0 {
+			dependents[dep.entity] += count
+		}
+	}
+
+	return dependents, nil
+}
+
+// DeleteCascade soft-deletes the row together with every live row depending on it, directly or not.
+// It returns the dependents it deleted with their state before the delete.
+func (d *dependencyRepo) DeleteCascade(ctx context.Context, entity, id string) ([]models.DeletedRow, error) {
+	tx, err := d.db.Begin(ctx)
+	if err != nil {
+		d.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	deleted := []models.DeletedRow{}
+	ok, err := deleteCascade(ctx, tx, entity, id, &deleted)
+	if err != nil {
+		d.log.Error("error is while cascading delete", logger.Error(err))
+
+		return nil, err
+	}
+
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	// the row itself comes last, only its dependents are returned
+	return deleted[:len(deleted)-1], nil
+}
+
+// deleteCascade appends the rows it deletes to deleted, the row itself last after its dependents.
+// It reports false when the row was not live, e.g. it was reached twice while cascading.
+func deleteCascade(ctx context.Context, tx pgx.Tx, entity, id string, deleted *[]models.DeletedRow) (bool, error) {
+	for _, dep := range dependencies[entity] {
+		rows, err := tx.Query(ctx, `select id::text from `+dep.table+` where `+dep.column+` = $1 and deleted_at = 0`, id)
+		if err != nil {
+			return false, err
+		}
+
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return false, err
+		}
+
+		for _, dependentID := range ids {
+			if _, err = deleteCascade(ctx, tx, dep.entity, dependentID, deleted); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	var row []byte
+	err := tx.QueryRow(ctx, `update `+trashTable(entity)+` t set deleted_at = extract(epoch from current_timestamp)
+			from (select * from `+trashTable(entity)+` where id = $1 and deleted_at = 0 for update) old
+				where t.id = old.id returning to_jsonb(old)`, id).Scan(&row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	*deleted = append(*deleted, models.DeletedRow{Entity: entity, ID: id, Row: row})
+
+	return true, nil
+}
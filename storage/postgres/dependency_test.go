@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestDependencyRepo_DeleteCascade(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Errorf("error while connection to db error: %v", err)
+	}
+
+	rootID, err := pgStore.Category().Create(context.Background(), models.CreateCategory{Name: "cascade root"})
+	if err != nil {
+		t.Errorf("error while creating category error: %v", err)
+	}
+
+	childID, err := pgStore.Category().Create(context.Background(), models.CreateCategory{Name: "cascade child", ParentID: rootID})
+	if err != nil {
+		t.Errorf("error while creating category error: %v", err)
+	}
+
+	dependents, err := pgStore.Dependency().Dependents(context.Background(), models.EntityCategory, rootID)
+	if err != nil {
+		t.Errorf("error while counting dependents error: %v", err)
+	}
+
+	assert.Equal(t, dependents, models.Dependents{models.EntityCategory: 1})
+
+	deleted, err := pgStore.Dependency().DeleteCascade(context.Background(), models.EntityCategory, rootID)
+	if err != nil {
+		t.Errorf("error while cascading delete error: %v", err)
+	}
+
+	assert.Equal(t, len(deleted), 1)
+	assert.Equal(t, deleted[0].Entity, models.EntityCategory)
+	assert.Equal(t, deleted[0].ID, childID)
+
+	_, err = pgStore.Category().GetByID(context.Background(), models.PrimaryKey{ID: childID})
+	assert.NotEqual(t, err, nil)
+
+	if err = pgStore.Trash().Restore(context.Background(), models.EntityCategory, childID); err != nil {
+		t.Errorf("error while restoring category error: %v", err)
+	}
+
+	child, err := pgStore.Category().GetByID(context.Background(), models.PrimaryKey{ID: childID})
+	if err != nil {
+		t.Errorf("error while getting category error: %v", err)
+	}
+
+	assert.Equal(t, child.DeletedReferences, []string{"parent_id"})
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"test/api/models"
@@ -15,13 +16,13 @@ import (
 )
 
 type incomeRepo struct {
-	db  *pgxpool.Pool
+	db  txPool
 	log logger.ILogger
 }
 
 func NewIncomeRepo(db *pgxpool.Pool, log logger.ILogger) storage.IIncomeStorage {
 	return &incomeRepo{
-		db:  db,
+		db:  txPool{db},
 		log: log,
 	}
 }
@@ -69,45 +70,57 @@ func (i *incomeRepo) Create(ctx context.Context) (models.Income, error) {
 
 func (i *incomeRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.Income, error) {
 	income := models.Income{}
-	query := `select id, external_id, total_sum from incomes where id = $1 and deleted_at = 0`
+	postedAt := sql.NullString{}
+	query := `select id, external_id, total_sum, posted_at from incomes where id = $1 and deleted_at = 0`
 	if err := i.db.QueryRow(ctx, query, key.ID).Scan(
 		&income.ID,
 		&income.ExternalID,
 		&income.TotalSum,
+		&postedAt,
 	); err != nil {
 		i.log.Error("error is while selecting income by id", logger.Error(err))
 
 		return models.Income{}, err
 	}
+	income.PostedAt = postedAt.String
 	return income, nil
 }
 
 func (i *incomeRepo) GetList(ctx context.Context, request models.GetListRequest) (models.IncomesResponse, error) {
 	var (
-		page              = request.Page
-		offset            = (page - 1) * request.Limit
 		incomes           = []models.Income{}
 		query, countQuery string
 		count             int
 		search            = request.Search
 	)
-	countQuery = `select count(1) from incomes where deleted_at = 0`
+	// external ids are unique and grow with every income
+	page, err := newListPage(request, keyset{column: "external_id", columnType: "varchar", tie: "id", tieType: "uuid", desc: true})
+	if err != nil {
+		return models.IncomesResponse{}, err
+	}
+
+	countQuery = `select count(1) from incomes where ` + deletedCondition(request, "deleted_at")
 	if search != "" {
 		countQuery += fmt.Sprintf(` and external_id = '%s'`, search)
 	}
 
-	if err := i.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		i.log.Error("error is while scanning count", logger.Error(err))
+	if !request.SkipCount {
+		if err = i.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
+			i.log.Error("error is while scanning count", logger.Error(err))
 
-		return models.IncomesResponse{}, err
+			return models.IncomesResponse{}, err
+		}
 	}
 
-	query = `select id, external_id, total_sum from incomes where deleted_at = 0`
+	query = `select id, external_id, total_sum, posted_at from incomes where ` + deletedCondition(request, "deleted_at")
 	if search != "" {
 		query += fmt.Sprintf(` and external_id = '%s'`, search)
 	}
-	query += ` LIMIT $1 OFFSET $2`
-	rows, err := i.db.Query(ctx, query, request.Limit, offset)
+	where, args := page.where(nil)
+	orderLimit, args := page.orderLimit(args)
+	query += where + orderLimit
+
+	rows, err := i.db.Query(ctx, query, args...)
 	if err != nil {
 		i.log.Error("error is while selecting all", logger.Error(err))
 
@@ -116,21 +129,29 @@ func (i *incomeRepo) GetList(ctx context.Context, request models.GetListRequest)
 
 	for rows.Next() {
 		in := models.Income{}
+		postedAt := sql.NullString{}
 		if err = rows.Scan(
 			&in.ID,
 			&in.ExternalID,
 			&in.TotalSum,
+			&postedAt,
 		); err != nil {
 			i.log.Error("error is while scanning all", logger.Error(err))
 
 			return models.IncomesResponse{}, err
 		}
+		in.PostedAt = postedAt.String
 		incomes = append(incomes, in)
 	}
 
+	incomes, cursors := paginate(page, incomes, func(income models.Income) (string, string) {
+		return income.ExternalID, income.ID
+	})
+
 	return models.IncomesResponse{
 		Incomes: incomes,
 		Count:   count,
+		Cursors: cursors,
 	}, err
 }
 
@@ -149,3 +170,19 @@ func (i *incomeRepo) Delete(ctx context.Context, key models.PrimaryKey) error {
 	}
 	return nil
 }
+
+// Post marks the income as posted to stock, it returns no rows when the income is gone or was posted before.
+func (i *incomeRepo) Post(ctx context.Context, key models.PrimaryKey) error {
+	result, err := i.db.Exec(ctx, `update incomes set posted_at = now() where id = $1 and deleted_at = 0 and posted_at is null`, key.ID)
+	if err != nil {
+		i.log.Error("error is while posting income", logger.Error(err))
+
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
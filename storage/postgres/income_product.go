@@ -2,41 +2,48 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"strings"
 	"test/api/models"
 	"test/pkg/logger"
 	"test/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type incomeProductRepo struct {
-	db  *pgxpool.Pool
+	db  txPool
 	log logger.ILogger
 }
 
 func NewIncomeProductRepo(db *pgxpool.Pool, log logger.ILogger) storage.IIncomeProductStorage {
 	return &incomeProductRepo{
-		db:  db,
+		db:  txPool{db},
 		log: log,
 	}
 }
 
 func (i *incomeProductRepo) CreateMultiple(ctx context.Context, request models.CreateIncomeProducts) error {
-	query := `insert into income_products (id, income_id, product_id, quantity, price) values `
+	query := `insert into income_products (id, income_id, product_id, quantity, price, lot_number, expiry_date) values `
 
+	// lot numbers are given by suppliers, so values are passed as parameters
+	args := make([]interface{}, 0, len(request.IncomeProducts)*7)
 	for _, incomeProduct := range request.IncomeProducts {
-		query += fmt.Sprintf(`('%s', '%s', '%s', %d, %d), `, uuid.New().String(),
+		n := len(args)
+		query += fmt.Sprintf(`($%d, $%d, $%d, $%d, $%d, nullif($%d, ''), nullif($%d, '')::date), `, n+1, n+2, n+3, n+4, n+5, n+6, n+7)
+		args = append(args, uuid.New(),
 			incomeProduct.IncomeID,
 			incomeProduct.ProductID,
 			incomeProduct.Quantity,
-			incomeProduct.Price)
+			incomeProduct.Price,
+			incomeProduct.LotNumber,
+			incomeProduct.ExpiryDate)
 	}
 	query = query[:len(query)-2]
 
-	if _, err := i.db.Exec(ctx, query); err != nil {
+	if _, err := i.db.Exec(ctx, query, args...); err != nil {
 		i.log.Error("error while inserting income products", logger.Error(err))
 
 		return err
@@ -47,29 +54,39 @@ func (i *incomeProductRepo) CreateMultiple(ctx context.Context, request models.C
 
 func (i *incomeProductRepo) GetList(ctx context.Context, request models.GetListRequest) (models.IncomeProductsResponse, error) {
 	var (
-		page              = request.Page
-		offset            = (page - 1) * request.Limit
 		count             = 0
 		query, countQuery string
 		incomeProducts    = []models.IncomeProduct{}
+		createdAt         = sql.NullString{}
 	)
 
-	countQuery = `select count(1) from income_products where deleted_at = 0`
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.IncomeProductsResponse{}, err
+	}
+
+	countQuery = `select count(1) from income_products where ` + deletedCondition(request, "deleted_at")
 	if request.Search != "" {
 		countQuery += fmt.Sprintf(` and income_id = '%s'`, request.Search)
 	}
-	if err := i.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		i.log.Error("error is while scanning count from income products", logger.Error(err))
+	if !request.SkipCount {
+		if err = i.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
+			i.log.Error("error is while scanning count from income products", logger.Error(err))
 
-		return models.IncomeProductsResponse{}, err
+			return models.IncomeProductsResponse{}, err
+		}
 	}
 
-	query = `select id, income_id, product_id, quantity, price from income_products where deleted_at = 0 `
+	query = `select id, income_id, product_id, quantity, price, lot_number, expiry_date::text, created_at from income_products where ` + deletedCondition(request, "deleted_at") + ` `
 	if request.Search != "" {
 		query += fmt.Sprintf(` and income_id = '%s'`, request.Search)
 	}
-	query += ` LIMIT $1 OFFSET $2`
-	rows, err := i.db.Query(ctx, query, request.Limit, offset)
+
+	where, args := page.where(nil)
+	orderLimit, args := page.orderLimit(args)
+	query += where + orderLimit
+
+	rows, err := i.db.Query(ctx, query, args...)
 	if err != nil {
 		i.log.Error("error is while selecting all from income products", logger.Error(err))
 
@@ -77,62 +94,133 @@ func (i *incomeProductRepo) GetList(ctx context.Context, request models.GetListR
 	}
 	for rows.Next() {
 		inp := models.IncomeProduct{}
-		if err = rows.Scan(&inp.ID, &inp.IncomeID, &inp.ProductID, &inp.Quantity, &inp.Price); err != nil {
+		lotNumber, expiryDate := sql.NullString{}, sql.NullString{}
+		if err = rows.Scan(&inp.ID, &inp.IncomeID, &inp.ProductID, &inp.Quantity, &inp.Price, &lotNumber, &expiryDate, &createdAt); err != nil {
 			i.log.Error("error is while scanning all from income products", logger.Error(err))
 
 			return models.IncomeProductsResponse{}, err
 		}
+		inp.LotNumber, inp.ExpiryDate = lotNumber.String, expiryDate.String
+		if createdAt.Valid {
+			inp.CreatedAt = createdAt.String
+		}
 		incomeProducts = append(incomeProducts, inp)
 	}
+
+	incomeProducts, cursors := paginate(page, incomeProducts, func(incomeProduct models.IncomeProduct) (string, string) {
+		return incomeProduct.CreatedAt, incomeProduct.ID
+	})
+
 	return models.IncomeProductsResponse{
 		IncomeProducts: incomeProducts,
 		Count:          count,
+		Cursors:        cursors,
 	}, err
 }
 
+// UpdateMultiple updates the lines, a line of a posted income or moved to one is not updated
+// and answers pgx.ErrNoRows.
 func (i *incomeProductRepo) UpdateMultiple(ctx context.Context, response models.UpdateIncomeProducts) error {
-	var (
-		updateStatements []string
-	)
-	query := `DO $$ BEGIN %s END $$`
-	for _, incomeProducts := range response.IncomeProducts {
-		updateStatements = append(updateStatements, fmt.Sprintf(`update income_products set income_id = '%s', product_id = '%s', quantity = %d, price = %d  where id = '%s' ;`,
-			incomeProducts.IncomeID, incomeProducts.ProductID, incomeProducts.Quantity, incomeProducts.Price, incomeProducts.ID))
-	}
+	query := `update income_products set income_id = $1, product_id = $2, quantity = $3, price = $4,
+				lot_number = nullif($5, ''), expiry_date = nullif($6, '')::date, updated_at = now()
+				where id = $7 and deleted_at = 0 and income_id in (select id from incomes where posted_at is null)
+				and $1 in (select id from incomes where posted_at is null)`
 
-	finalQuery := fmt.Sprintf(query, strings.Join(updateStatements, "\n"))
-	if rowsAffected, err := i.db.Exec(ctx, finalQuery); err != nil {
-		if r := rowsAffected.RowsAffected(); r == 0 {
-			i.log.Error("error is while rows affected", logger.Error(err))
+	for _, incomeProduct := range response.IncomeProducts {
+		result, err := i.db.Exec(ctx, query, incomeProduct.IncomeID, incomeProduct.ProductID, incomeProduct.Quantity, incomeProduct.Price,
+			incomeProduct.LotNumber, incomeProduct.ExpiryDate, incomeProduct.ID)
+		if err != nil {
+			i.log.Error("error is while updating income products", logger.Error(err))
 
 			return err
 		}
-		i.log.Error("error is while updating income products", logger.Error(err))
 
-		return err
+		if result.RowsAffected() == 0 {
+			return pgx.ErrNoRows
+		}
 	}
 
 	return nil
 }
 
-func (i *incomeProductRepo) DeleteMultiple(ctx context.Context, response models.DeleteIncomeProducts) error {
-	var deleteStatements []string
+// DeleteMultiple deletes the lines of incomes that are not posted and returns the ids of the deleted ones.
+func (i *incomeProductRepo) DeleteMultiple(ctx context.Context, response models.DeleteIncomeProducts) ([]string, error) {
+	ids := make([]string, 0, len(response.IDs))
+	for _, key := range response.IDs {
+		ids = append(ids, key.ID)
+	}
+
+	query := `update income_products set deleted_at = extract(epoch from current_timestamp)
+			where id = any($1::uuid[]) and deleted_at = 0 and income_id in (select id from incomes where posted_at is null)
+			returning id`
 
-	query := `DO $$ BEGIN %s END $$`
-	for _, value := range response.IDs {
-		deleteStatements = append(deleteStatements, fmt.Sprintf(`update income_products set deleted_at = extract(epoch from current_timestamp) where id = '%s' ;`, value.ID))
+	rows, err := i.db.Query(ctx, query, ids)
+	if err != nil {
+		i.log.Error("error is while deleting income products", logger.Error(err))
+
+		return nil, err
 	}
+	defer rows.Close()
 
-	finalQuery := fmt.Sprintf(query, strings.Join(deleteStatements, "\n"))
-	if rowsAffected, err := i.db.Exec(ctx, finalQuery); err != nil {
-		if r := rowsAffected.RowsAffected(); r == 0 {
-			i.log.Error("error is while rows affected", logger.Error(err))
+	deleted := []string{}
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			i.log.Error("error is while scanning deleted income product id", logger.Error(err))
 
-			return err
+			return nil, err
 		}
-		i.log.Error("error is while deleting income products", logger.Error(err))
 
-		return err
+		deleted = append(deleted, id)
 	}
-	return nil
+
+	return deleted, rows.Err()
+}
+
+// GetByIDs returns the lines with the ids that are not deleted.
+func (i *incomeProductRepo) GetByIDs(ctx context.Context, ids []string) ([]models.IncomeProduct, error) {
+	query := `select id, income_id, product_id, quantity, price, lot_number, expiry_date::text, created_at from income_products
+			where id = any($1::uuid[]) and deleted_at = 0`
+
+	rows, err := i.db.Query(ctx, query, ids)
+	if err != nil {
+		i.log.Error("error is while selecting income products by ids", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanIncomeProducts(rows)
+}
+
+// GetByIncome returns the lines of the income that are not deleted.
+func (i *incomeProductRepo) GetByIncome(ctx context.Context, incomeID string) ([]models.IncomeProduct, error) {
+	query := `select id, income_id, product_id, quantity, price, lot_number, expiry_date::text, created_at from income_products
+			where income_id = $1 and deleted_at = 0 order by created_at, id`
+
+	rows, err := i.db.Query(ctx, query, incomeID)
+	if err != nil {
+		i.log.Error("error is while selecting income products of income", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanIncomeProducts(rows)
+}
+
+func scanIncomeProducts(rows pgx.Rows) ([]models.IncomeProduct, error) {
+	incomeProducts := []models.IncomeProduct{}
+	for rows.Next() {
+		inp := models.IncomeProduct{}
+		lotNumber, expiryDate, createdAt := sql.NullString{}, sql.NullString{}, sql.NullString{}
+		if err := rows.Scan(&inp.ID, &inp.IncomeID, &inp.ProductID, &inp.Quantity, &inp.Price, &lotNumber, &expiryDate, &createdAt); err != nil {
+			return nil, err
+		}
+
+		inp.LotNumber, inp.ExpiryDate, inp.CreatedAt = lotNumber.String, expiryDate.String, createdAt.String
+		incomeProducts = append(incomeProducts, inp)
+	}
+
+	return incomeProducts, rows.Err()
 }
@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type inventoryCountRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewInventoryCountRepo(db *pgxpool.Pool, log logger.ILogger) storage.IInventoryCountStorage {
+	return &inventoryCountRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+// Create opens a count and takes the quantities and costs of the counted products as they are now.
+func (i *inventoryCountRepo) Create(ctx context.Context, count models.CreateInventoryCount) (string, error) {
+	id := uuid.New()
+
+	tx, err := i.db.Begin(ctx)
+	if err != nil {
+		i.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `insert into inventory_counts (id, branch_id, note, created_by) values ($1, $2, nullif($3, ''), nullif($4, ''))`
+	if _, err = tx.Exec(ctx, query, id, count.BranchID, count.Note, count.CreatedBy); err != nil {
+		i.log.Error("error is while inserting inventory count", logger.Error(err))
+
+		return "", err
+	}
+
+	args := []interface{}{id, count.BranchID}
+	linesQuery := `insert into inventory_count_lines (inventory_count_id, product_id, expected_quantity, cost)
+			select $1, p.id, p.quantity, coalesce(p.average_cost, p.original_price) from products p where p.branch_id = $2 and p.deleted_at = 0`
+
+	if len(count.CategoryIDs) > 0 {
+		args = append(args, count.CategoryIDs)
+		linesQuery += fmt.Sprintf(` and p.category_id in (%s)`, fmt.Sprintf(categorySubtreeQuery, "id = any($3::uuid[])"))
+	}
+
+	if _, err = tx.Exec(ctx, linesQuery, args...); err != nil {
+		i.log.Error("error is while inserting inventory count lines", logger.Error(err))
+
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		i.log.Error("error is while committing transaction", logger.Error(err))
+
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// GetByID returns the count with its lines, the counted quantity of a line is the sum of its entries.
+func (i *inventoryCountRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.InventoryCount, error) {
+	count, err := scanInventoryCount(i.db.QueryRow(ctx, `select `+inventoryCountColumns+` from inventory_counts where id = $1`, key.ID))
+	if err != nil {
+		i.log.Error("error is while selecting inventory count by id", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+
+	query := `select l.product_id, p.name, l.expected_quantity,
+				(select sum(e.quantity) from inventory_count_entries e
+					where e.inventory_count_id = l.inventory_count_id and e.product_id = l.product_id),
+				l.cost
+			from inventory_count_lines l join products p on p.id = l.product_id
+				where l.inventory_count_id = $1 order by p.name, l.product_id`
+
+	rows, err := i.db.Query(ctx, query, key.ID)
+	if err != nil {
+		i.log.Error("error is while selecting inventory count lines", logger.Error(err))
+
+		return models.InventoryCount{}, err
+	}
+	defer rows.Close()
+
+	count.Lines = []models.InventoryCountLine{}
+	for rows.Next() {
+		line := models.InventoryCountLine{}
+		if err = rows.Scan(&line.ProductID, &line.ProductName, &line.ExpectedQuantity, &line.CountedQuantity, &line.Cost); err != nil {
+			i.log.Error("error is while scanning inventory count line", logger.Error(err))
+
+			return models.InventoryCount{}, err
+		}
+
+		count.Lines = append(count.Lines, line)
+	}
+
+	return count, rows.Err()
+}
+
+// GetList returns counts without their lines, newest first.
+func (i *inventoryCountRepo) GetList(ctx context.Context, request models.GetListRequest) (models.InventoryCountsResponse, error) {
+	var (
+		counts = []models.InventoryCount{}
+		count  = 0
+	)
+
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.InventoryCountsResponse{}, err
+	}
+
+	filter := ` where (branch_id = nullif($1, '')::uuid or $1 = '') and (status::text = $2 or $2 = '')`
+	args := []interface{}{request.BranchID, request.Status}
+
+	if !request.SkipCount {
+		if err = i.db.QueryRow(ctx, `select count(1) from inventory_counts`+filter, args...).Scan(&count); err != nil {
+			i.log.Error("error is while scanning count of inventory counts", logger.Error(err))
+
+			return models.InventoryCountsResponse{}, err
+		}
+	}
+
+	where, args := page.where(args)
+	orderLimit, args := page.orderLimit(args)
+
+	rows, err := i.db.Query(ctx, `select `+inventoryCountColumns+` from inventory_counts`+filter+where+orderLimit, args...)
+	if err != nil {
+		i.log.Error("error is while selecting inventory counts", logger.Error(err))
+
+		return models.InventoryCountsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		inventoryCount, err := scanInventoryCount(rows)
+		if err != nil {
+			i.log.Error("error is while scanning inventory count", logger.Error(err))
+
+			return models.InventoryCountsResponse{}, err
+		}
+
+		counts = append(counts, inventoryCount)
+	}
+
+	counts, cursors := paginate(page, counts, func(inventoryCount models.InventoryCount) (string, string) {
+		return inventoryCount.CreatedAt, inventoryCount.ID
+	})
+
+	return models.InventoryCountsResponse{
+		InventoryCounts: counts,
+		Count:           count,
+		Cursors:         cursors,
+	}, nil
+}
+
+// AddEntries adds counted quantities to an open count, it returns no rows when the count is closed.
+func (i *inventoryCountRepo) AddEntries(ctx context.Context, request models.CountInventory) error {
+	tx, err := i.db.Begin(ctx)
+	if err != nil {
+		i.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// the open count is locked so that it is not approved while entries are added
+	var status string
+	if err = tx.QueryRow(ctx, `select status::text from inventory_counts where id = $1 for update`, request.ID).Scan(&status); err != nil {
+		i.log.Error("error is while locking inventory count", logger.Error(err))
+
+		return err
+	}
+
+	if status != models.InventoryCountOpen {
+		return pgx.ErrNoRows
+	}
+
+	query := `insert into inventory_count_entries (id, inventory_count_id, product_id, quantity, counted_by)
+			values ($1, $2, $3, $4, nullif($5, ''))`
+
+	for _, entry := range request.Entries {
+		if _, err = tx.Exec(ctx, query, uuid.New(), request.ID, entry.ProductID, entry.Quantity, request.CountedBy); err != nil {
+			i.log.Error("error is while inserting inventory count entry", logger.Error(err))
+
+			return err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `update inventory_counts set updated_at = now() where id = $1`, request.ID); err != nil {
+		i.log.Error("error is while updating inventory count", logger.Error(err))
+
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		i.log.Error("error is while committing transaction", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// Close approves or cancels an open count, it returns no rows when the count is not open.
+func (i *inventoryCountRepo) Close(ctx context.Context, id, status, closedBy string) error {
+	query := `update inventory_counts set status = $2::inventory_count_status_enum, closed_by = nullif($3, ''), closed_at = now(), updated_at = now()
+			where id = $1 and status = 'open'`
+
+	result, err := i.db.Exec(ctx, query, id, status, closedBy)
+	if err != nil {
+		i.log.Error("error is while closing inventory count", logger.Error(err))
+
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+const inventoryCountColumns = `id, branch_id, status::text, note, created_by, closed_by, closed_at, created_at, updated_at`
+
+func scanInventoryCount(row pgx.Row) (models.InventoryCount, error) {
+	var (
+		count                                                     = models.InventoryCount{}
+		note, createdBy, closedBy, closedAt, createdAt, updatedAt = sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}
+	)
+
+	if err := row.Scan(&count.ID, &count.BranchID, &count.Status, &note, &createdBy, &closedBy, &closedAt, &createdAt, &updatedAt); err != nil {
+		return models.InventoryCount{}, err
+	}
+
+	count.Note, count.CreatedBy, count.ClosedBy = note.String, createdBy.String, closedBy.String
+	count.ClosedAt, count.CreatedAt, count.UpdatedAt = closedAt.String, createdAt.String, updatedAt.String
+
+	return count, nil
+}
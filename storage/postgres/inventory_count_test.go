@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestInventoryCountRepo_Count(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	categoryID, err := pgStore.Category().Create(context.Background(), models.CreateCategory{Name: "counted shelf"})
+	if err != nil {
+		t.Fatalf("error while creating category: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:       "kefir",
+		Price:      6000,
+		Quantity:   measure.Whole(12),
+		CategoryID: categoryID,
+		BranchID:   "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	countID, err := pgStore.InventoryCount().Create(context.Background(), models.CreateInventoryCount{
+		BranchID:    "aa541fcc-bf74-11ee-ae0b-166244b65504",
+		CategoryIDs: []string{categoryID},
+	})
+	if err != nil {
+		t.Fatalf("error while creating inventory count: %v", err)
+	}
+
+	for _, entry := range []models.CountInventory{
+		{ID: countID, Entries: []models.InventoryCountEntry{{ProductID: productID, Quantity: measure.Whole(7)}}, CountedBy: "first"},
+		{ID: countID, Entries: []models.InventoryCountEntry{{ProductID: productID, Quantity: measure.Whole(4)}}, CountedBy: "second"},
+	} {
+		if err = pgStore.InventoryCount().AddEntries(context.Background(), entry); err != nil {
+			t.Fatalf("error while adding inventory count entries: %v", err)
+		}
+	}
+
+	count, err := pgStore.InventoryCount().GetByID(context.Background(), models.PrimaryKey{ID: countID})
+	if err != nil {
+		t.Fatalf("error while getting inventory count: %v", err)
+	}
+
+	assert.Equal(t, count.Status, models.InventoryCountOpen)
+	assert.Equal(t, len(count.Lines), 1)
+	assert.Equal(t, count.Lines[0].ExpectedQuantity, measure.Whole(12))
+	assert.Equal(t, *count.Lines[0].CountedQuantity, measure.Whole(11))
+
+	if err = pgStore.InventoryCount().Close(context.Background(), countID, models.InventoryCountCancelled, ""); err != nil {
+		t.Fatalf("error while closing inventory count: %v", err)
+	}
+
+	err = pgStore.InventoryCount().AddEntries(context.Background(), models.CountInventory{
+		ID:      countID,
+		Entries: []models.InventoryCountEntry{{ProductID: productID, Quantity: measure.Whole(1)}},
+	})
+	if !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expected entries of a closed count to find no rows, but got: %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type lotRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewLotRepo(db *pgxpool.Pool, log logger.ILogger) storage.ILotStorage {
+	return &lotRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+func (l *lotRepo) Create(ctx context.Context, lot models.CreateLot) (string, error) {
+	id := uuid.New()
+
+	query := `insert into lots (id, product_id, income_product_id, lot_number, expiry_date, cost, quantity, remaining)
+			values ($1, $2, nullif($3, '')::uuid, $4, nullif($5, '')::date, $6, $7, $7)`
+	if _, err := l.db.Exec(ctx, query, id, lot.ProductID, lot.IncomeProductID, lot.LotNumber, lot.ExpiryDate, lot.Cost, lot.Quantity); err != nil {
+		l.log.Error("error is while inserting lot", logger.Error(err))
+
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// GetList returns the lots with stock left, the ones expiring first first.
+func (l *lotRepo) GetList(ctx context.Context, filter models.LotFilter) (models.LotsResponse, error) {
+	var (
+		conditions = []string{"l.remaining > 0"}
+		args       = []interface{}{}
+	)
+
+	if filter.ProductID != "" {
+		args = append(args, filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("l.product_id = $%d", len(args)))
+	}
+
+	if filter.BranchID != "" {
+		args = append(args, filter.BranchID)
+		conditions = append(conditions, fmt.Sprintf("p.branch_id = $%d", len(args)))
+	}
+
+	if filter.ExpiringWithinDays != nil {
+		args = append(args, *filter.ExpiringWithinDays)
+		conditions = append(conditions, fmt.Sprintf("l.expiry_date <= current_date + $%d::int", len(args)))
+	}
+
+	query := `select ` + lotColumns + ` from lots l join products p on p.id = l.product_id
+			where ` + strings.Join(conditions, " and ") + ` order by l.expiry_date nulls last, l.created_at, l.id`
+
+	rows, err := l.db.Query(ctx, query, args...)
+	if err != nil {
+		l.log.Error("error is while selecting lots", logger.Error(err))
+
+		return models.LotsResponse{}, err
+	}
+	defer rows.Close()
+
+	lots := []models.Lot{}
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			l.log.Error("error is while scanning lot", logger.Error(err))
+
+			return models.LotsResponse{}, err
+		}
+
+		lots = append(lots, lot)
+	}
+
+	return models.LotsResponse{
+		Lots:  lots,
+		Count: len(lots),
+	}, rows.Err()
+}
+
+// LockAvailable locks the lots of the product with stock left in the order they are to be sold,
+// the ones expiring first first. Expired lots are left out unless withExpired is set.
+func (l *lotRepo) LockAvailable(ctx context.Context, productID string, withExpired bool) ([]models.Lot, error) {
+	query := `select ` + lotColumns + ` from lots l join products p on p.id = l.product_id
+			where l.product_id = $1 and l.remaining > 0 and ($2 or l.expiry_date is null or l.expiry_date >= current_date)
+				order by l.expiry_date nulls last, l.created_at, l.id for update of l`
+
+	rows, err := l.db.Query(ctx, query, productID, withExpired)
+	if err != nil {
+		l.log.Error("error is while locking lots", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	lots := []models.Lot{}
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			l.log.Error("error is while scanning lot", logger.Error(err))
+
+			return nil, err
+		}
+
+		lots = append(lots, lot)
+	}
+
+	return lots, rows.Err()
+}
+
+// Take takes the quantity out of the lot and keeps what it was taken for.
+func (l *lotRepo) Take(ctx context.Context, take models.TakeLot) error {
+	if _, err := l.db.Exec(ctx, `update lots set remaining = remaining - $2 where id = $1`, take.LotID, take.Quantity); err != nil {
+		l.log.Error("error is while taking from lot", logger.Error(err))
+
+		return err
+	}
+
+	query := `insert into lot_movements (id, lot_id, kind, quantity, reference_id) values ($1, $2, $3, $4, nullif($5, '')::uuid)`
+	if _, err := l.db.Exec(ctx, query, uuid.New(), take.LotID, take.Kind, take.Quantity, take.ReferenceID); err != nil {
+		l.log.Error("error is while inserting lot movement", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// GetTakes returns what is still taken out of each lot by the kind of movement and its reference,
+// a take given back with a negative quantity is netted out.
+func (l *lotRepo) GetTakes(ctx context.Context, kind, referenceID string) ([]models.TakeLot, error) {
+	query := `select m.lot_id, sum(m.quantity), l.cost from lot_movements m join lots l on l.id = m.lot_id
+			where m.kind = $1 and m.reference_id = $2 group by m.lot_id, l.cost having sum(m.quantity) > 0 order by m.lot_id`
+
+	rows, err := l.db.Query(ctx, query, kind, referenceID)
+	if err != nil {
+		l.log.Error("error is while selecting lot takes", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	takes := []models.TakeLot{}
+	for rows.Next() {
+		take := models.TakeLot{Kind: kind, ReferenceID: referenceID}
+		if err = rows.Scan(&take.LotID, &take.Quantity, &take.Cost); err != nil {
+			l.log.Error("error is while scanning lot take", logger.Error(err))
+
+			return nil, err
+		}
+
+		takes = append(takes, take)
+	}
+
+	return takes, rows.Err()
+}
+
+// ExpiredRemaining is the stock of the product left in expired lots, it cannot be sold.
+func (l *lotRepo) ExpiredRemaining(ctx context.Context, productID string) (measure.Quantity, error) {
+	var remaining measure.Quantity
+	if err := l.db.QueryRow(ctx, `select coalesce(sum(remaining), 0) from lots where product_id = $1 and expiry_date < current_date`,
+		productID).Scan(&remaining); err != nil {
+		l.log.Error("error is while selecting expired remaining of lots", logger.Error(err))
+
+		return 0, err
+	}
+
+	return remaining, nil
+}
+
+// lotColumns are read from lots l joined with products p.
+const lotColumns = `l.id, l.product_id, p.name, p.branch_id, l.income_product_id::text, l.lot_number, l.expiry_date::text,
+		coalesce(l.expiry_date < current_date, false), l.cost, l.quantity, l.remaining, l.created_at`
+
+func scanLot(row pgx.Row) (models.Lot, error) {
+	var (
+		lot                                    = models.Lot{}
+		incomeProductID, expiryDate, createdAt = sql.NullString{}, sql.NullString{}, sql.NullString{}
+	)
+
+	if err := row.Scan(&lot.ID, &lot.ProductID, &lot.ProductName, &lot.BranchID, &incomeProductID, &lot.LotNumber, &expiryDate,
+		&lot.Expired, &lot.Cost, &lot.Quantity, &lot.Remaining, &createdAt); err != nil {
+		return models.Lot{}, err
+	}
+
+	lot.IncomeProductID, lot.ExpiryDate, lot.CreatedAt = incomeProductID.String, expiryDate.String, createdAt.String
+
+	return lot, nil
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/google/uuid"
+)
+
+func TestLotRepo_LockAvailable(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:       "cottage cheese",
+		Price:      8000,
+		Quantity:   measure.Whole(6),
+		CategoryID: "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:   "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	for _, lot := range []models.CreateLot{
+		{ProductID: productID, LotNumber: "expired", ExpiryDate: time.Now().AddDate(0, 0, -1).Format(time.DateOnly), Cost: 4000, Quantity: measure.Whole(1)},
+		{ProductID: productID, LotNumber: "later", ExpiryDate: time.Now().AddDate(0, 0, 20).Format(time.DateOnly), Cost: 5000, Quantity: measure.Whole(3)},
+		{ProductID: productID, LotNumber: "sooner", ExpiryDate: time.Now().AddDate(0, 0, 3).Format(time.DateOnly), Cost: 4500, Quantity: measure.Whole(2)},
+	} {
+		if _, err = pgStore.Lot().Create(context.Background(), lot); err != nil {
+			t.Fatalf("error while creating lot: %v", err)
+		}
+	}
+
+	lots, err := pgStore.Lot().LockAvailable(context.Background(), productID, false)
+	if err != nil {
+		t.Fatalf("error while locking lots: %v", err)
+	}
+
+	assert.Equal(t, len(lots), 2)
+	assert.Equal(t, lots[0].LotNumber, "sooner")
+
+	if err = pgStore.Lot().Take(context.Background(), models.TakeLot{
+		LotID:    lots[0].ID,
+		Quantity: measure.Whole(2),
+		Kind:     models.StockMovementSale,
+	}); err != nil {
+		t.Fatalf("error while taking from lot: %v", err)
+	}
+
+	days := 7
+	expiring, err := pgStore.Lot().GetList(context.Background(), models.LotFilter{ProductID: productID, ExpiringWithinDays: &days})
+	if err != nil {
+		t.Fatalf("error while getting expiring lots: %v", err)
+	}
+
+	assert.Equal(t, expiring.Count, 1)
+	assert.Equal(t, expiring.Lots[0].LotNumber, "expired")
+	assert.Equal(t, expiring.Lots[0].Expired, true)
+}
+
+func TestLotRepo_GetTakes(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:       "ayran",
+		Price:      4000,
+		Quantity:   measure.Whole(4),
+		CategoryID: "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:   "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	lotID, err := pgStore.Lot().Create(context.Background(), models.CreateLot{ProductID: productID, LotNumber: "A1", Cost: 2500, Quantity: measure.Whole(4)})
+	if err != nil {
+		t.Fatalf("error while creating lot: %v", err)
+	}
+
+	referenceID := uuid.NewString()
+	if err = pgStore.Lot().Take(context.Background(), models.TakeLot{
+		LotID:       lotID,
+		Quantity:    measure.Whole(3),
+		Kind:        models.StockMovementBackorder,
+		ReferenceID: referenceID,
+	}); err != nil {
+		t.Fatalf("error while taking from lot: %v", err)
+	}
+
+	takes, err := pgStore.Lot().GetTakes(context.Background(), models.StockMovementBackorder, referenceID)
+	if err != nil {
+		t.Fatalf("error while getting lot takes: %v", err)
+	}
+
+	assert.Equal(t, len(takes), 1)
+	assert.Equal(t, takes[0].Quantity, measure.Whole(3))
+	assert.Equal(t, takes[0].Cost, money.Amount(2500))
+
+	// a take given back nets out
+	takes[0].Quantity = -takes[0].Quantity
+	if err = pgStore.Lot().Take(context.Background(), takes[0]); err != nil {
+		t.Fatalf("error while giving back to lot: %v", err)
+	}
+
+	takes, err = pgStore.Lot().GetTakes(context.Background(), models.StockMovementBackorder, referenceID)
+	if err != nil {
+		t.Fatalf("error while getting lot takes: %v", err)
+	}
+
+	assert.Equal(t, len(takes), 0)
+}
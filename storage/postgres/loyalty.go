@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type loyaltyRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewLoyaltyRepo(db *pgxpool.Pool, log logger.ILogger) storage.ILoyaltyStorage {
+	return &loyaltyRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+func (l *loyaltyRepo) Earn(ctx context.Context, request models.EarnLoyaltyPoints) error {
+	query := `insert into loyalty_transactions (id, user_id, basket_id, type, points, remaining, expires_at)
+			values ($1, $2, nullif($3, '')::uuid, 'earn', $4, $4,
+			        case when $5::int > 0 then now() + make_interval(days => $5::int) end)`
+
+	if _, err := l.db.Exec(ctx, query,
+		uuid.New(),
+		request.UserID,
+		request.BasketID,
+		request.Points,
+		request.TTLDays,
+	); err != nil {
+		l.log.Error("error is while inserting earned loyalty points", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// Redeem spends points from the earned entries that expire first.
+func (l *loyaltyRepo) Redeem(ctx context.Context, request models.RedeemLoyaltyPoints) error {
+	type earned struct {
+		id        string
+		remaining int
+	}
+
+	var (
+		entries = []earned{}
+		left    = request.Points
+	)
+
+	tx, err := l.db.Begin(ctx)
+	if err != nil {
+		l.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `select id, remaining from loyalty_transactions
+			where user_id = $1 and type = 'earn' and remaining > 0 and (expires_at is null or expires_at > now())
+				order by expires_at nulls last, created_at for update`
+
+	rows, err := tx.Query(ctx, query, request.UserID)
+	if err != nil {
+		l.log.Error("error is while selecting earned loyalty points", logger.Error(err))
+
+		return err
+	}
+
+	for rows.Next() {
+		entry := earned{}
+		if err = rows.Scan(&entry.id, &entry.remaining); err != nil {
+			l.log.Error("error is while scanning earned loyalty points", logger.Error(err))
+
+			return err
+		}
+		entries = append(entries, entry)
+	}
+	rows.Close()
+
+	for _, entry := range entries {
+		if left == 0 {
+			break
+		}
+
+		take := entry.remaining
+		if take > left {
+			take = left
+		}
+
+		if _, err = tx.Exec(ctx, `update loyalty_transactions set remaining = remaining - $1 where id = $2`, take, entry.id); err != nil {
+			l.log.Error("error is while updating remaining loyalty points", logger.Error(err))
+
+			return err
+		}
+
+		left -= take
+	}
+
+	if left > 0 {
+		return errors.New("not enough loyalty points")
+	}
+
+	query = `insert into loyalty_transactions (id, user_id, basket_id, type, points)
+			values ($1, $2, nullif($3, '')::uuid, 'redeem', $4)`
+
+	if _, err = tx.Exec(ctx, query, uuid.New(), request.UserID, request.BasketID, -request.Points); err != nil {
+		l.log.Error("error is while inserting redeemed loyalty points", logger.Error(err))
+
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Expire zeroes out earned entries past their expiry date and records the lost points as one expire entry.
+func (l *loyaltyRepo) Expire(ctx context.Context, userID string) error {
+	query := `
+		with expired as (
+			select id, remaining from loyalty_transactions
+				where user_id = $1 and type = 'earn' and remaining > 0 and expires_at <= now()
+		), updated as (
+			update loyalty_transactions set remaining = 0 where id in (select id from expired)
+		)
+		insert into loyalty_transactions (id, user_id, type, points)
+			select $2::uuid, $1::uuid, 'expire', -sum(remaining) from expired having sum(remaining) > 0`
+
+	if _, err := l.db.Exec(ctx, query, userID, uuid.New()); err != nil {
+		l.log.Error("error is while expiring loyalty points", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (l *loyaltyRepo) GetBalance(ctx context.Context, userID string) (int, error) {
+	balance := 0
+	query := `select coalesce(sum(remaining), 0) from loyalty_transactions
+			where user_id = $1 and type = 'earn' and (expires_at is null or expires_at > now())`
+
+	if err := l.db.QueryRow(ctx, query, userID).Scan(&balance); err != nil {
+		l.log.Error("error is while selecting loyalty balance", logger.Error(err))
+
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+func (l *loyaltyRepo) GetHistory(ctx context.Context, request models.GetListRequest) (models.LoyaltyResponse, error) {
+	var (
+		transactions                   = []models.LoyaltyTransaction{}
+		count                          = 0
+		basketID, expiresAt, createdAt = sql.NullString{}, sql.NullString{}, sql.NullString{}
+	)
+
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.LoyaltyResponse{}, err
+	}
+
+	if !request.SkipCount {
+		countQuery := `select count(1) from loyalty_transactions where user_id = $1`
+		if err = l.db.QueryRow(ctx, countQuery, request.UserID).Scan(&count); err != nil {
+			l.log.Error("error is while scanning count of loyalty transactions", logger.Error(err))
+
+			return models.LoyaltyResponse{}, err
+		}
+	}
+
+	where, args := page.where([]interface{}{request.UserID})
+	orderLimit, args := page.orderLimit(args)
+
+	query := `select id, user_id, basket_id, type::text, points, remaining, expires_at, created_at
+			from loyalty_transactions where user_id = $1` + where + orderLimit
+
+	rows, err := l.db.Query(ctx, query, args...)
+	if err != nil {
+		l.log.Error("error is while selecting loyalty transactions", logger.Error(err))
+
+		return models.LoyaltyResponse{}, err
+	}
+
+	for rows.Next() {
+		transaction := models.LoyaltyTransaction{}
+		if err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&basketID,
+			&transaction.Type,
+			&transaction.Points,
+			&transaction.Remaining,
+			&expiresAt,
+			&createdAt,
+		); err != nil {
+			l.log.Error("error is while scanning loyalty transaction", logger.Error(err))
+
+			return models.LoyaltyResponse{}, err
+		}
+
+		if basketID.Valid {
+			transaction.BasketID = basketID.String
+		}
+
+		if expiresAt.Valid {
+			transaction.ExpiresAt = expiresAt.String
+		}
+
+		if createdAt.Valid {
+			transaction.CreatedAt = createdAt.String
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	transactions, cursors := paginate(page, transactions, func(transaction models.LoyaltyTransaction) (string, string) {
+		return transaction.CreatedAt, transaction.ID
+	})
+
+	return models.LoyaltyResponse{
+		UserID:  request.UserID,
+		History: transactions,
+		Count:   count,
+		Cursors: cursors,
+	}, nil
+}
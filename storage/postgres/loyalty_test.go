@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/helper"
+	"test/pkg/logger"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestLoyaltyRepo_EarnAndRedeem(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connection to db error: %v", err)
+	}
+
+	userID, err := pgStore.User().Create(context.Background(), models.CreateUser{
+		FullName: helper.GenerateFullName(),
+		Phone:    helper.GeneratePhoneNumber(),
+		Password: "password",
+		Cash:     10,
+		UserType: "customer",
+		BranchID: "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating user error: %v", err)
+	}
+
+	if err = pgStore.Loyalty().Earn(context.Background(), models.EarnLoyaltyPoints{
+		UserID:  userID,
+		Points:  50,
+		TTLDays: 30,
+	}); err != nil {
+		t.Fatalf("error while earning points error: %v", err)
+	}
+
+	if err = pgStore.Loyalty().Redeem(context.Background(), models.RedeemLoyaltyPoints{
+		UserID: userID,
+		Points: 20,
+	}); err != nil {
+		t.Fatalf("error while redeeming points error: %v", err)
+	}
+
+	balance, err := pgStore.Loyalty().GetBalance(context.Background(), userID)
+	if err != nil {
+		t.Errorf("error while getting balance error: %v", err)
+	}
+
+	assert.Equal(t, balance, 30)
+
+	if err = pgStore.Loyalty().Redeem(context.Background(), models.RedeemLoyaltyPoints{
+		UserID: userID,
+		Points: 100,
+	}); err == nil {
+		t.Errorf("expected error while redeeming more points than balance")
+	}
+
+	history, err := pgStore.Loyalty().GetHistory(context.Background(), models.GetListRequest{
+		Page:   1,
+		Limit:  10,
+		UserID: userID,
+	})
+	if err != nil {
+		t.Errorf("error while getting history error: %v", err)
+	}
+
+	assert.Equal(t, history.Count, 2)
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"fmt"
+	"test/api/models"
+	"test/pkg/cursor"
+)
+
+// keyset is the order of a list: rows are sorted by column and then by the unique tie column,
+// types are used to cast cursor values back in queries.
+type keyset struct {
+	column, columnType string
+	tie, tieType       string
+	desc               bool
+}
+
+// createdAtKeyset orders rows newest first.
+var createdAtKeyset = keyset{column: "created_at", columnType: "timestamp", tie: "id", tieType: "uuid", desc: true}
+
+// listPage is a page of a list taken by cursor, or by offset when the request has no cursor.
+type listPage struct {
+	keyset
+	cursor        cursor.Cursor
+	hasCursor     bool
+	limit, offset int
+}
+
+func newListPage(request models.GetListRequest, order keyset) (listPage, error) {
+	page := listPage{
+		keyset: order,
+		limit:  request.Limit,
+		offset: (request.Page - 1) * request.Limit,
+	}
+
+	if page.offset < 0 {
+		page.offset = 0
+	}
+
+	if request.Cursor != "" {
+		c, err := cursor.Decode(request.Cursor)
+		if err != nil {
+			return listPage{}, err
+		}
+
+		page.cursor, page.hasCursor, page.offset = c, true, 0
+	}
+
+	return page, nil
+}
+
+// where returns the condition selecting rows after the cursor, or before it for backward cursors.
+func (p listPage) where(args []interface{}) (string, []interface{}) {
+	if !p.hasCursor {
+		return "", args
+	}
+
+	operator := ">"
+	if p.desc != p.cursor.Backward {
+		operator = "<"
+	}
+
+	args = append(args, p.cursor.Value, p.cursor.ID)
+
+	return fmt.Sprintf(` and (%s, %s) %s ($%d::%s, $%d::%s)`,
+		p.column, p.tie, operator, len(args)-1, p.columnType, len(args), p.tieType), args
+}
+
+// orderLimit returns order by and limit clauses, one row more than the limit is taken to know if there is a next page.
+func (p listPage) orderLimit(args []interface{}) (string, []interface{}) {
+	direction := "asc"
+	if p.desc != p.cursor.Backward {
+		direction = "desc"
+	}
+
+	order := fmt.Sprintf(` order by %s %s, %s %s`, p.column, direction, p.tie, direction)
+
+	if p.hasCursor {
+		args = append(args, p.limit+1)
+
+		return order + fmt.Sprintf(` LIMIT $%d`, len(args)), args
+	}
+
+	args = append(args, p.limit+1, p.offset)
+
+	return order + fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args)), args
+}
+
+// paginate drops the extra row, restores the order of a backward page and returns cursors of the neighbouring pages.
+// key returns the sort value and the tie value of an item.
+func paginate[T any](p listPage, items []T, key func(T) (string, string)) ([]T, models.Cursors) {
+	hasMore := len(items) > p.limit
+	if hasMore {
+		items = items[:p.limit]
+	}
+
+	if p.cursor.Backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	cursors := models.Cursors{}
+	if len(items) == 0 {
+		return items, cursors
+	}
+
+	firstValue, firstID := key(items[0])
+	lastValue, lastID := key(items[len(items)-1])
+
+	// a backward page was taken from the page after it, a forward page from the page before it
+	hasNext, hasPrev := hasMore, p.hasCursor || p.offset > 0
+	if p.cursor.Backward {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if hasNext {
+		cursors.NextCursor = cursor.Cursor{Value: lastValue, ID: lastID}.Encode()
+	}
+
+	if hasPrev {
+		cursors.PrevCursor = cursor.Cursor{Value: firstValue, ID: firstID, Backward: true}.Encode()
+	}
+
+	return items, cursors
+}
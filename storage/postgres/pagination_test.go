@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"strconv"
+	"test/api/models"
+	"test/pkg/cursor"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestListPage_Paginate(t *testing.T) {
+	key := func(n int) (string, string) { return strconv.Itoa(n), strconv.Itoa(n) }
+
+	page, err := newListPage(models.GetListRequest{Page: 1, Limit: 2}, createdAtKeyset)
+	if err != nil {
+		t.Fatalf("error while creating page: %v", err)
+	}
+
+	items, cursors := paginate(page, []int{9, 8, 7}, key)
+	assert.Equal(t, items, []int{9, 8})
+	assert.Equal(t, cursors.PrevCursor, "")
+
+	next, err := cursor.Decode(cursors.NextCursor)
+	if err != nil {
+		t.Fatalf("error while decoding next cursor: %v", err)
+	}
+
+	assert.Equal(t, next, cursor.Cursor{Value: "8", ID: "8"})
+
+	page, err = newListPage(models.GetListRequest{Page: 1, Limit: 2, Cursor: cursor.Cursor{Value: "7", ID: "7", Backward: true}.Encode()}, createdAtKeyset)
+	if err != nil {
+		t.Fatalf("error while creating page: %v", err)
+	}
+
+	where, args := page.where(nil)
+	assert.Equal(t, where, ` and (created_at, id) > ($1::timestamp, $2::uuid)`)
+	assert.Equal(t, len(args), 2)
+
+	order, _ := page.orderLimit(args)
+	assert.Equal(t, order, ` order by created_at asc, id asc LIMIT $3`)
+
+	// a backward page is selected in reverse order
+	items, cursors = paginate(page, []int{8, 9}, key)
+	assert.Equal(t, items, []int{9, 8})
+	assert.Equal(t, cursors.PrevCursor, "")
+	assert.NotEqual(t, cursors.NextCursor, "")
+
+	if _, err = newListPage(models.GetListRequest{Limit: 2, Cursor: "broken"}, createdAtKeyset); err != cursor.ErrInvalid {
+		t.Errorf("expected invalid cursor error, got: %v", err)
+	}
+}
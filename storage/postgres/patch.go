@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+)
+
+// patchColumn is a column a merge patch may change, expr is the sql its value is set with,
+// %s standing for the parameter.
+type patchColumn struct {
+	column string
+	expr   string
+	value  interface{}
+}
+
+// patchSet builds the assignments of an update changing only the patched fields, their values are
+// numbered after the args the query already has. Fields without a column are left to the caller.
+func patchSet(fields []string, columns map[string]patchColumn, args ...interface{}) ([]string, []interface{}) {
+	set := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		column, ok := columns[field]
+		if !ok {
+			continue
+		}
+
+		args = append(args, column.value)
+
+		expr := "%s"
+		if column.expr != "" {
+			expr = column.expr
+		}
+
+		set = append(set, column.column+" = "+fmt.Sprintf(expr, fmt.Sprintf("$%d", len(args))))
+	}
+
+	return append(set, "updated_at = now()"), args
+}
+
+// patchQuery is the update of the row with the id and version in $1 and $2.
+func patchQuery(table string, set []string, where string) string {
+	return fmt.Sprintf(`update %s set %s where id = $1 and version = $2 %s`, table, strings.Join(set, ", "), where)
+}
@@ -119,3 +119,55 @@ func (s Store) Income() storage.IIncomeStorage {
 func (s Store) IncomeProduct() storage.IIncomeProductStorage {
 	return NewIncomeProductRepo(s.pool, s.log)
 }
+
+func (s Store) Loyalty() storage.ILoyaltyStorage {
+	return NewLoyaltyRepo(s.pool, s.log)
+}
+
+func (s Store) ProductPrice() storage.IProductPriceStorage {
+	return NewProductPriceRepo(s.pool, s.log)
+}
+
+func (s Store) BranchProductPrice() storage.IBranchProductPriceStorage {
+	return NewBranchProductPriceRepo(s.pool, s.log)
+}
+
+func (s Store) CurrencyRate() storage.ICurrencyRateStorage {
+	return NewCurrencyRateRepo(s.pool, s.log)
+}
+
+func (s Store) Unit() storage.IUnitStorage {
+	return NewUnitRepo(s.pool, s.log)
+}
+
+func (s Store) Trash() storage.ITrashStorage {
+	return NewTrashRepo(s.pool, s.log)
+}
+
+func (s Store) Dependency() storage.IDependencyStorage {
+	return NewDependencyRepo(s.pool, s.log)
+}
+
+func (s Store) Audit() storage.IAuditStorage {
+	return NewAuditRepo(s.pool, s.log)
+}
+
+func (s Store) StockMovement() storage.IStockMovementStorage {
+	return NewStockMovementRepo(s.pool, s.log)
+}
+
+func (s Store) InventoryCount() storage.IInventoryCountStorage {
+	return NewInventoryCountRepo(s.pool, s.log)
+}
+
+func (s Store) WriteOff() storage.IWriteOffStorage {
+	return NewWriteOffRepo(s.pool, s.log)
+}
+
+func (s Store) Lot() storage.ILotStorage {
+	return NewLotRepo(s.pool, s.log)
+}
+
+func (s Store) Backorder() storage.IBackorderStorage {
+	return NewBackorderRepo(s.pool, s.log)
+}
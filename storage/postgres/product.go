@@ -4,42 +4,63 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"test/api/models"
 	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/money"
 	"test/storage"
+	"unicode"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lib/pq"
 	_ "github.com/lib/pq"
 )
 
 type productRepo struct {
-	db  *pgxpool.Pool
+	db  txPool
 	log logger.ILogger
 }
 
 func NewProductRepo(db *pgxpool.Pool, log logger.ILogger) storage.IProductStorage {
 	return &productRepo{
-		db:  db,
+		db:  txPool{db},
 		log: log,
 	}
 }
 
 func (p *productRepo) Create(ctx context.Context, product models.CreateProduct) (string, error) {
 	id := uuid.New()
-	query := `insert into products(id, name, price, original_price, quantity, category_id, branch_id) 
-						values($1, $2, $3, $4, $5, $6, $7)`
 
-	if rowsAffected, err := p.db.Exec(ctx, query,
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `insert into products(id, name, sku, price, original_price, quantity, category_id, branch_id, tax_rate, currency, parent_id, attributes, unit) 
+						values($1, $2, nullif($3, ''), $4, $5, $6, $7, $8, $9, (select currency from branches where id = $8),
+						       nullif($10, '')::uuid, coalesce($11::jsonb, '{}'), coalesce(nullif($12, ''), 'piece'))`
+
+	if rowsAffected, err := tx.Exec(ctx, query,
 		id,
 		product.Name,
+		product.SKU,
 		product.Price,
 		product.OriginalPrice,
 		product.Quantity,
 		product.CategoryID,
-		product.BranchID); err != nil {
+		product.BranchID,
+		product.TaxRate,
+		product.ParentID,
+		product.Attributes,
+		product.Unit); err != nil {
 		if r := rowsAffected.RowsAffected(); r == 0 {
 			p.log.Error("rror is in rows affected", logger.Error(err))
 
@@ -50,24 +71,48 @@ func (p *productRepo) Create(ctx context.Context, product models.CreateProduct)
 		return "", err
 	}
 
+	if err = setBarcodes(ctx, tx, id.String(), product.Barcodes); err != nil {
+		p.log.Error("error while inserting product barcodes", logger.Error(err))
+
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.log.Error("error is while committing transaction", logger.Error(err))
+
+		return "", err
+	}
+
 	return id.String(), nil
 }
 
 func (p *productRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.Product, error) {
 	var createdAt, updatedAt = sql.NullString{}, sql.NullString{}
 	product := models.Product{}
-	query := `select id, name, price, original_price, quantity, category_id, branch_id, created_at, updated_at
+	query := `select id, name, coalesce(sku, ''), coalesce(parent_id::text, ''), attributes,
+       				array(select barcode from product_barcodes where product_id = products.id order by created_at),
+       				price, original_price, quantity, unit, category_id, branch_id, tax_rate, currency, version, created_at, updated_at, ` +
+		deletedReferences("products", reference{"category_id", "categories"}, reference{"branch_id", "branches"}, reference{"parent_id", "products"}) + `
 							from products where id = $1 and deleted_at = 0`
 	if err := p.db.QueryRow(ctx, query, key.ID).Scan(
 		&product.ID,
 		&product.Name,
+		&product.SKU,
+		&product.ParentID,
+		&product.Attributes,
+		&product.Barcodes,
 		&product.Price,
 		&product.OriginalPrice,
 		&product.Quantity,
+		&product.Unit,
 		&product.CategoryID,
 		&product.BranchID,
+		&product.TaxRate,
+		&product.Currency,
+		&product.Version,
 		&createdAt,
-		&updatedAt); err != nil {
+		&updatedAt,
+		&product.DeletedReferences); err != nil {
 		p.log.Error("error is while selecting product by id", logger.Error(err))
 		return models.Product{}, err
 	}
@@ -82,61 +127,284 @@ func (p *productRepo) GetByID(ctx context.Context, key models.PrimaryKey) (model
 	return product, nil
 }
 
+// productListColumns are selected by list queries from products p joined with branch overrides bpp.
+const productListColumns = `p.id, p.name, coalesce(p.sku, ''), coalesce(p.parent_id::text, ''), p.attributes,
+       				array(select barcode from product_barcodes where product_id = p.id order by created_at),
+       				coalesce(bpp.price, p.price), p.original_price, p.quantity, p.unit, p.category_id, p.branch_id, p.tax_rate, p.currency, p.version, p.created_at, p.updated_at`
+
 func (p *productRepo) GetList(ctx context.Context, request models.GetListRequest) (models.ProductResponse, error) {
 	var (
-		products             = []models.Product{}
-		page                 = request.Page
-		offset               = (page - 1) * request.Limit
-		search               = request.Search
-		query, countQuery    string
-		count                = 0
-		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
+		count = 0
+		args  = []interface{}{pricingBranchID(request)}
 	)
 
-	countQuery = `select count(1) from products where deleted_at = 0 `
+	page, err := newListPage(request, productListKeyset(request))
+	if err != nil {
+		return models.ProductResponse{}, err
+	}
+
+	filter, args := productListFilter(request, args)
+
+	countQuery := `select count(1) from products p
+						left join branch_product_prices bpp on bpp.product_id = p.id and bpp.branch_id = nullif($1, '')::uuid
+							where ` + deletedCondition(request, "p.deleted_at") + filter
+
+	if !request.SkipCount {
+		if err = p.db.QueryRow(ctx, countQuery, args...).Scan(&count); err != nil {
+			fmt.Println("error is while scanning count", err.Error())
+			return models.ProductResponse{}, err
+		}
+	}
+
+	query := `select ` + productListColumns + `
+								from products p
+									left join branch_product_prices bpp on bpp.product_id = p.id and bpp.branch_id = nullif($1, '')::uuid
+										where ` + deletedCondition(request, "p.deleted_at") + filter
 
-	if search != "" {
-		countQuery += fmt.Sprintf(` and (name ilike '%%%s%%' or 
-			CAST(price AS TEXT) ilike '%s' or CAST(quantity AS TEXT) ilike '%s')`, search, search, search)
+	where, args := page.where(args)
+	orderLimit, args := page.orderLimit(args)
+	query += where + orderLimit
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		p.log.Error("error is while selecting product", logger.Error(err))
+
+		return models.ProductResponse{}, err
 	}
 
-	if err := p.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		fmt.Println("error is while scanning count", err.Error())
+	products, err := scanProducts(rows)
+	if err != nil {
+		p.log.Error("error is while sacaning product", logger.Error(err))
+
 		return models.ProductResponse{}, err
 	}
 
-	query = `select id, name, price, original_price, quantity, category_id, branch_id, created_at, updated_at
-								from products where deleted_at = 0`
+	products, cursors := paginate(page, products, productListKey(request))
 
-	if search != "" {
-		query += fmt.Sprintf(` and (name ilike '%%%s%%' or 
-			CAST(price AS TEXT) ilike '%s' or CAST(quantity AS TEXT) ilike '%s')`, search, search, search)
+	if request.GroupVariants && len(products) > 0 {
+		if err = p.attachVariants(ctx, products, pricingBranchID(request)); err != nil {
+			p.log.Error("error is while selecting product variants", logger.Error(err))
+
+			return models.ProductResponse{}, err
+		}
 	}
 
-	query += ` order by created_at desc LIMIT $1 OFFSET $2`
+	return models.ProductResponse{
+		Products: products,
+		Count:    count,
+		Cursors:  cursors,
+	}, err
+}
 
-	rows, err := p.db.Query(ctx, query, request.Limit, offset)
+// FullTextSearch ranks products matching the words of request.Search by full-text search,
+// and by trigram similarity of the name to catch misspellings, exact SKU and barcode matches come first.
+func (p *productRepo) FullTextSearch(ctx context.Context, request models.GetListRequest) (models.ProductResponse, error) {
+	query := `select ` + productListColumns + `
+								from products p
+									left join branch_product_prices bpp on bpp.product_id = p.id and bpp.branch_id = nullif($5, '')::uuid
+										where p.deleted_at = 0 and ($3 = '' or p.branch_id = nullif($3, '')::uuid) and (
+											($2 <> '' and p.search_vector @@ to_tsquery('simple', $2))
+											or p.name % $1
+											or p.sku = $1
+											or exists(select 1 from product_barcodes b where b.product_id = p.id and b.barcode in ($1, '0' || $1)))
+									order by (p.sku = $1 or exists(select 1 from product_barcodes b where b.product_id = p.id and b.barcode in ($1, '0' || $1))) desc,
+										ts_rank(p.search_vector, to_tsquery('simple', $2)) + similarity(p.name, $1) desc, p.name
+									LIMIT $4`
+
+	rows, err := p.db.Query(ctx, query, request.Search, prefixTsQuery(request.Search), request.BranchID, request.Limit, pricingBranchID(request))
 	if err != nil {
-		p.log.Error("error is while selecting product", logger.Error(err))
+		p.log.Error("error is while searching products", logger.Error(err))
+
+		return models.ProductResponse{}, err
+	}
+
+	products, err := scanProducts(rows)
+	if err != nil {
+		p.log.Error("error is while scanning searched products", logger.Error(err))
 
 		return models.ProductResponse{}, err
 	}
 
+	return models.ProductResponse{
+		Products: products,
+		Count:    len(products),
+	}, nil
+}
+
+// prefixTsQuery turns words of the search into a tsquery matching words starting with each of them, "ched mil" is "ched:* & mil:*".
+// Anything but letters and digits is dropped so that the query is always valid.
+func prefixTsQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+// pricingBranchID is the branch whose price overrides products are listed with.
+func pricingBranchID(request models.GetListRequest) string {
+	if request.PriceBranchID != "" {
+		return request.PriceBranchID
+	}
+
+	return request.BranchID
+}
+
+// productListFilter returns conditions of product listing, values are appended to args and referenced as placeholders.
+func productListFilter(request models.GetListRequest, args []interface{}) (string, []interface{}) {
+	var filter strings.Builder
+
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if request.Search != "" {
+		search := placeholder(request.Search)
+		filter.WriteString(fmt.Sprintf(` and (p.name ilike '%%' || %s || '%%' or p.sku = %s)`, search, search))
+	}
+
+	if request.GroupVariants {
+		filter.WriteString(` and p.parent_id is null`)
+	}
+
+	if request.BranchID != "" {
+		filter.WriteString(` and p.branch_id = ` + placeholder(request.BranchID) + `::uuid`)
+	}
+
+	// a category filter takes products of its subcategories too
+	if len(request.CategoryIDs) > 0 {
+		subtree := fmt.Sprintf(categorySubtreeQuery, "id = any("+placeholder(request.CategoryIDs)+"::uuid[])")
+		filter.WriteString(fmt.Sprintf(` and p.category_id in (%s)`, subtree))
+	}
+
+	if request.MinPrice > 0 {
+		filter.WriteString(` and coalesce(bpp.price, p.price) >= ` + placeholder(request.MinPrice))
+	}
+
+	if request.MaxPrice > 0 {
+		filter.WriteString(` and coalesce(bpp.price, p.price) <= ` + placeholder(request.MaxPrice))
+	}
+
+	switch request.Stock {
+	case models.StockIn:
+		filter.WriteString(` and p.quantity > 0`)
+	case models.StockOut:
+		filter.WriteString(` and p.quantity <= 0`)
+	case models.StockLow:
+		filter.WriteString(` and p.quantity > 0 and p.quantity <= ` + placeholder(request.LowStockThreshold) + `::numeric`)
+	}
+
+	if request.CreatedFrom != "" {
+		filter.WriteString(` and p.created_at >= ` + placeholder(request.CreatedFrom) + `::date`)
+	}
+
+	// created_to is inclusive, the whole day is taken
+	if request.CreatedTo != "" {
+		filter.WriteString(` and p.created_at < ` + placeholder(request.CreatedTo) + `::date + 1`)
+	}
+
+	return filter.String(), args
+}
+
+// productListKeyset returns the order of product listing, newest first by default.
+func productListKeyset(request models.GetListRequest) keyset {
+	order := keyset{column: "p.created_at", columnType: "timestamp", tie: "p.id", tieType: "uuid", desc: request.SortOrder != "asc"}
+
+	switch request.SortBy {
+	case "name":
+		order.column, order.columnType = "p.name", "varchar"
+	case "price":
+		order.column, order.columnType = "coalesce(bpp.price, p.price)", "bigint"
+	case "quantity":
+		order.column, order.columnType = "p.quantity", "numeric"
+	}
+
+	return order
+}
+
+// productListKey returns the sort value of the product in the order of productListKeyset.
+func productListKey(request models.GetListRequest) func(models.Product) (string, string) {
+	return func(product models.Product) (string, string) {
+		switch request.SortBy {
+		case "name":
+			return product.Name, product.ID
+		case "price":
+			return strconv.FormatInt(int64(product.Price), 10), product.ID
+		case "quantity":
+			return product.Quantity.String(), product.ID
+		}
+
+		return product.CreatedAt, product.ID
+	}
+}
+
+// attachVariants fills Variants of every product with its variants, priced for the branch.
+func (p *productRepo) attachVariants(ctx context.Context, products []models.Product, branchID string) error {
+	parentIDs := make([]string, 0, len(products))
+	for _, product := range products {
+		parentIDs = append(parentIDs, product.ID)
+	}
+
+	query := `select ` + productListColumns + `
+								from products p
+									left join branch_product_prices bpp on bpp.product_id = p.id and bpp.branch_id = nullif($2, '')::uuid
+										where p.deleted_at = 0 and p.parent_id = any($1::uuid[]) order by p.created_at`
+
+	rows, err := p.db.Query(ctx, query, parentIDs, branchID)
+	if err != nil {
+		return err
+	}
+
+	variants, err := scanProducts(rows)
+	if err != nil {
+		return err
+	}
+
+	byParent := make(map[string][]models.Product, len(products))
+	for _, variant := range variants {
+		byParent[variant.ParentID] = append(byParent[variant.ParentID], variant)
+	}
+
+	for i := range products {
+		products[i].Variants = byParent[products[i].ID]
+	}
+
+	return nil
+}
+
+func scanProducts(rows pgx.Rows) ([]models.Product, error) {
+	var (
+		products             = []models.Product{}
+		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
+	)
+
 	for rows.Next() {
 		product := models.Product{}
-		if err = rows.Scan(
+		if err := rows.Scan(
 			&product.ID,
 			&product.Name,
+			&product.SKU,
+			&product.ParentID,
+			&product.Attributes,
+			&product.Barcodes,
 			&product.Price,
 			&product.OriginalPrice,
 			&product.Quantity,
+			&product.Unit,
 			&product.CategoryID,
 			&product.BranchID,
+			&product.TaxRate,
+			&product.Currency,
+			&product.Version,
 			&createdAt,
 			&updatedAt); err != nil {
-			p.log.Error("error is while sacaning product", logger.Error(err))
-
-			return models.ProductResponse{}, err
+			return nil, err
 		}
 		if createdAt.Valid {
 			product.CreatedAt = createdAt.String
@@ -147,31 +415,184 @@ func (p *productRepo) GetList(ctx context.Context, request models.GetListRequest
 		}
 		products = append(products, product)
 	}
-	return models.ProductResponse{
-		Products: products,
-		Count:    count,
-	}, err
+
+	return products, rows.Err()
 }
 
 func (p *productRepo) Update(ctx context.Context, product models.UpdateProduct) (string, error) {
-	query := `update products set name = $1, price = $2, original_price = $3, quantity = $4, 
-                    category_id = $5, updated_at = now()  where id = $6`
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.log.Error("error is while beginning transaction", logger.Error(err))
 
-	if _, err := p.db.Exec(ctx, query,
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	// the quantity is moved by the service through the stock movements
+	query := `update products set name = $1, sku = nullif($2, ''), price = $3, original_price = $4, unit = coalesce(nullif($5, ''), unit),
+                    category_id = $6, tax_rate = $7, attributes = coalesce($8::jsonb, '{}'), updated_at = now()  where id = $9 and version = $10`
+
+	result, err := tx.Exec(ctx, query,
 		&product.Name,
+		&product.SKU,
 		&product.Price,
 		&product.OriginalPrice,
-		&product.Quantity,
+		&product.Unit,
 		&product.CategoryID,
-		&product.ID); err != nil {
+		product.TaxRate,
+		product.Attributes,
+		&product.ID,
+		product.Version)
+	if err != nil {
 		p.log.Error("error is while update product", logger.Error(err))
 
 		return "", err
 	}
 
+	// the product is gone or was changed since the version was read
+	if result.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
+	if product.Barcodes != nil {
+		if _, err = tx.Exec(ctx, `delete from product_barcodes where product_id = $1`, product.ID); err != nil {
+			p.log.Error("error is while deleting product barcodes", logger.Error(err))
+
+			return "", err
+		}
+
+		if err = setBarcodes(ctx, tx, product.ID, product.Barcodes); err != nil {
+			p.log.Error("error is while inserting product barcodes", logger.Error(err))
+
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.log.Error("error is while committing transaction", logger.Error(err))
+
+		return "", err
+	}
+
 	return product.ID, nil
 }
 
+// Patch updates only the fields of the product present in a merge patch, barcodes are replaced when present.
+func (p *productRepo) Patch(ctx context.Context, product models.UpdateProduct, fields []string) (string, error) {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	set, args := patchSet(fields, map[string]patchColumn{
+		"name":           {column: "name", value: product.Name},
+		"sku":            {column: "sku", expr: "nullif(%s, '')", value: product.SKU},
+		"price":          {column: "price", value: product.Price},
+		"original_price": {column: "original_price", value: product.OriginalPrice},
+		"unit":           {column: "unit", expr: "coalesce(nullif(%s, ''), unit)", value: product.Unit},
+		"category_id":    {column: "category_id", expr: "nullif(%s, '')::uuid", value: product.CategoryID},
+		"tax_rate":       {column: "tax_rate", value: product.TaxRate},
+		"attributes":     {column: "attributes", expr: "coalesce(%s::jsonb, '{}')", value: product.Attributes},
+	}, product.ID, product.Version)
+
+	result, err := tx.Exec(ctx, patchQuery("products", set, ""), args...)
+	if err != nil {
+		p.log.Error("error is while patching product", logger.Error(err))
+
+		return "", err
+	}
+
+	// the product is gone or was changed since the version was read
+	if result.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
+	if slices.Contains(fields, "barcodes") {
+		if _, err = tx.Exec(ctx, `delete from product_barcodes where product_id = $1`, product.ID); err != nil {
+			p.log.Error("error is while deleting product barcodes", logger.Error(err))
+
+			return "", err
+		}
+
+		if err = setBarcodes(ctx, tx, product.ID, product.Barcodes); err != nil {
+			p.log.Error("error is while inserting product barcodes", logger.Error(err))
+
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.log.Error("error is while committing transaction", logger.Error(err))
+
+		return "", err
+	}
+
+	return product.ID, nil
+}
+
+// GetByBarcode returns the product the barcode belongs to.
+func (p *productRepo) GetByBarcode(ctx context.Context, barcode string) (models.Product, error) {
+	var id string
+
+	query := `select pb.product_id from product_barcodes pb
+					join products p on p.id = pb.product_id where pb.barcode = $1 and p.deleted_at = 0`
+	if err := p.db.QueryRow(ctx, query, barcode).Scan(&id); err != nil {
+		p.log.Error("error is while selecting product by barcode", logger.Error(err))
+
+		return models.Product{}, err
+	}
+
+	return p.GetByID(ctx, models.PrimaryKey{ID: id})
+}
+
+// GetIDsByBarcodes maps every known barcode to the id of its product, unknown barcodes are left out.
+func (p *productRepo) GetIDsByBarcodes(ctx context.Context, barcodes []string) (map[string]string, error) {
+	ids := make(map[string]string, len(barcodes))
+
+	query := `select pb.barcode, pb.product_id from product_barcodes pb
+					join products p on p.id = pb.product_id where pb.barcode = any($1) and p.deleted_at = 0`
+	rows, err := p.db.Query(ctx, query, barcodes)
+	if err != nil {
+		p.log.Error("error is while selecting products by barcodes", logger.Error(err))
+
+		return nil, err
+	}
+
+	for rows.Next() {
+		var barcode, id string
+		if err = rows.Scan(&barcode, &id); err != nil {
+			p.log.Error("error is while scanning product barcode", logger.Error(err))
+
+			return nil, err
+		}
+
+		ids[barcode] = id
+	}
+
+	return ids, nil
+}
+
+func setBarcodes(ctx context.Context, tx pgx.Tx, productID string, barcodes []string) error {
+	if len(barcodes) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(barcodes))
+	for range barcodes {
+		ids = append(ids, uuid.New().String())
+	}
+
+	query := `insert into product_barcodes (id, product_id, barcode)
+				select id, $1::uuid, barcode from unnest($2::uuid[], $3::varchar[]) as t(id, barcode)`
+
+	_, err := tx.Exec(ctx, query, productID, ids, barcodes)
+
+	return err
+}
+
 func (p *productRepo) Delete(ctx context.Context, key models.PrimaryKey) error {
 	query := `update products set deleted_at = extract(epoch from current_timestamp) where id = $1`
 
@@ -188,16 +609,19 @@ func (p *productRepo) Delete(ctx context.Context, key models.PrimaryKey) error {
 	return nil
 }
 
-func (p *productRepo) Search(ctx context.Context, customerProductIDs map[string]int) (models.ProductSell, error) {
+// Search uses the branch price override when the branch has one, falling back to the base price.
+// Stock left in expired lots cannot be sold, selected products come with their average costs.
+func (p *productRepo) Search(ctx context.Context, customerProductIDs map[string]measure.Quantity, branchID string) (models.ProductSell, error) {
 	var (
 		selectedProducts = models.SellRequest{
-			Products: map[string]int{},
+			Products: map[string]measure.Quantity{},
 		}
 		products               = make([]string, len(customerProductIDs))
-		selectedProductPrices  = make(map[string]int, 0)
-		notEnoughProducts      = make(map[string]int)
+		selectedProductCosts   = make(map[string]money.Amount, 0)
+		taxRates               = make(map[string]float64)
+		notEnoughProducts      = make(map[string]measure.Quantity)
 		productsBranchID       string
-		notEnoughProductPrices = make(map[string]int)
+		notEnoughProductPrices = make(map[string]money.Amount)
 	)
 
 	for key := range customerProductIDs {
@@ -205,10 +629,16 @@ func (p *productRepo) Search(ctx context.Context, customerProductIDs map[string]
 	}
 
 	query := `
-				select id, quantity, price, original_price, branch_id from products where id::varchar = ANY($1)
+				select p.id, p.quantity - coalesce((select sum(l.remaining) from lots l
+							where l.product_id = p.id and l.expiry_date < current_date), 0),
+				       coalesce(bpp.price, p.price), p.original_price, coalesce(p.average_cost, p.original_price), p.branch_id,
+				       coalesce(p.tax_rate, c.tax_rate) from products p
+					left join branch_product_prices bpp on bpp.product_id = p.id and bpp.branch_id = nullif($2, '')::uuid
+					left join categories c on c.id = p.category_id
+						where p.id::varchar = ANY($1)
 	`
 
-	rows, err := p.db.Query(ctx, query, pq.Array(products)) // [a, b, c]
+	rows, err := p.db.Query(ctx, query, pq.Array(products), branchID) // [a, b, c]
 	if err != nil {
 		fmt.Println("Error while getting products by product ids", err.Error())
 		return models.ProductSell{}, err
@@ -216,15 +646,20 @@ func (p *productRepo) Search(ctx context.Context, customerProductIDs map[string]
 
 	for rows.Next() {
 		var (
-			quantity, price, originalPrice int
-			productID, branchID            string
+			quantity             measure.Quantity
+			price, originalPrice money.Amount
+			averageCost          money.Amount
+			productID, branchID  string
+			taxRate              *float64
 		)
 		if err = rows.Scan(
 			&productID,
 			&quantity,
 			&price,
 			&originalPrice,
+			&averageCost,
 			&branchID,
+			&taxRate,
 		); err != nil {
 			p.log.Error("Error while scanning rows one by one", logger.Error(err))
 
@@ -233,9 +668,13 @@ func (p *productRepo) Search(ctx context.Context, customerProductIDs map[string]
 
 		productsBranchID = branchID
 
+		if taxRate != nil {
+			taxRates[productID] = *taxRate
+		}
+
 		if customerProductIDs[productID] <= quantity {
-			selectedProducts.Products[productID] = price
-			selectedProductPrices[productID] = originalPrice
+			selectedProducts.Products[productID] = customerProductIDs[productID]
+			selectedProductCosts[productID] = averageCost
 		} else if customerProductIDs[productID] > quantity || quantity == 0 {
 			notEnoughProducts[productID] = customerProductIDs[productID]
 			notEnoughProductPrices[productID] = originalPrice
@@ -244,14 +683,15 @@ func (p *productRepo) Search(ctx context.Context, customerProductIDs map[string]
 
 	return models.ProductSell{
 		SelectedProducts:       selectedProducts,
-		ProductPrices:          selectedProductPrices,
+		ProductCosts:           selectedProductCosts,
+		TaxRates:               taxRates,
 		NotEnoughProducts:      notEnoughProducts,
 		NotEnoughProductPrices: notEnoughProductPrices,
 		ProductsBranchID:       productsBranchID,
 	}, nil
 }
 
-func (p *productRepo) TakeProducts(ctx context.Context, products map[string]int) error {
+func (p *productRepo) TakeProducts(ctx context.Context, products map[string]measure.Quantity) error {
 	var (
 		updateStatements []string
 	)
@@ -264,7 +704,7 @@ func (p *productRepo) TakeProducts(ctx context.Context, products map[string]int)
 
 	for productID, quantity := range products {
 		updateStatements = append(updateStatements, fmt.Sprintf(`update products 
-			set quantity = quantity - %d where id = '%s' ;`, quantity, productID))
+			set quantity = quantity - %s where id = '%s' ;`, quantity, productID))
 	}
 
 	finalQuery := fmt.Sprintf(query, strings.Join(updateStatements, "\n"))
@@ -291,7 +731,7 @@ func (p *productRepo) AddDeliveredProducts(ctx context.Context, products models.
 `
 	if products.NotEnoughProducts != nil {
 		for productID, quantity := range products.NotEnoughProducts {
-			updatedStatements = append(updatedStatements, fmt.Sprintf(`update products set quantity = quantity + %d where id = '%s' ;`,
+			updatedStatements = append(updatedStatements, fmt.Sprintf(`update products set quantity = quantity + %s where id = '%s' ;`,
 				quantity, productID))
 		}
 
@@ -312,15 +752,18 @@ func (p *productRepo) AddDeliveredProducts(ctx context.Context, products models.
 	return nil
 }
 
-func (p *productRepo) GetListByIDs(ctx context.Context, productIDs []string) (models.ProductResponse, error) {
+func (p *productRepo) GetListByIDs(ctx context.Context, productIDs []string, branchID string) (models.ProductResponse, error) {
 	productsResp := models.ProductResponse{
 		Products: make([]models.Product, 0),
 		Count:    0,
 	}
 
-	query := `select id, name, price from products where id::varchar = ANY($1)`
+	// the currency is the one the price is in, the branch's when it overrides the price
+	query := `select p.id, p.name, coalesce(bpp.price, p.price), coalesce(bpp.currency, p.currency), p.unit from products p
+					left join branch_product_prices bpp on bpp.product_id = p.id and bpp.branch_id = nullif($2, '')::uuid
+						where p.id::varchar = ANY($1)`
 
-	rows, err := p.db.Query(ctx, query, pq.Array(productIDs))
+	rows, err := p.db.Query(ctx, query, pq.Array(productIDs), branchID)
 	if err != nil {
 		p.log.Error("Error while getting products by product ids", logger.Error(err))
 
@@ -334,6 +777,8 @@ func (p *productRepo) GetListByIDs(ctx context.Context, productIDs []string) (mo
 			&product.ID,
 			&product.Name,
 			&product.Price,
+			&product.Currency,
+			&product.Unit,
 		); err != nil {
 
 			p.log.Error("Error while scanning rows one by one", logger.Error(err))
@@ -346,3 +791,20 @@ func (p *productRepo) GetListByIDs(ctx context.Context, productIDs []string) (mo
 
 	return productsResp, nil
 }
+
+// UpdateAverageCost takes quantity received at cost into the moving average cost of the product,
+// call it before the quantity is added to stock. Stock that is not positive does not count.
+func (p *productRepo) UpdateAverageCost(ctx context.Context, productID string, quantity measure.Quantity, cost money.Amount) error {
+	query := `update products set average_cost = case when quantity > 0 and quantity + $2 > 0
+				then round((quantity * coalesce(average_cost, original_price) + $2 * $3) / (quantity + $2))
+				else $3 end
+			where id = $1`
+
+	if _, err := p.db.Exec(ctx, query, productID, quantity, cost); err != nil {
+		p.log.Error("error is while updating average cost", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
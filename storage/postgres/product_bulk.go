@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/pkg/measure"
+)
+
+// productFilterWhere returns the conditions selecting the products of a bulk operation.
+func productFilterWhere(filter models.ProductFilter) (string, []interface{}) {
+	var (
+		where strings.Builder
+		args  []interface{}
+	)
+
+	if len(filter.ProductIDs) > 0 {
+		args = append(args, filter.ProductIDs)
+		where.WriteString(fmt.Sprintf(` and p.id = any($%d::uuid[])`, len(args)))
+	}
+
+	if filter.BranchID != "" {
+		args = append(args, filter.BranchID)
+		where.WriteString(fmt.Sprintf(` and p.branch_id = $%d::uuid`, len(args)))
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		args = append(args, filter.CategoryIDs)
+		subtree := fmt.Sprintf(categorySubtreeQuery, fmt.Sprintf("id = any($%d::uuid[])", len(args)))
+		where.WriteString(fmt.Sprintf(` and p.category_id in (%s)`, subtree))
+	}
+
+	return where.String(), args
+}
+
+// LockPrices returns the prices of the filtered products and locks the products until the transaction ends.
+func (p *productRepo) LockPrices(ctx context.Context, filter models.ProductFilter) ([]models.ProductPrice, error) {
+	where, args := productFilterWhere(filter)
+
+	query := `select id, price, original_price, currency from products p where p.deleted_at = 0` + where + ` order by id for update`
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		p.log.Error("error is while locking product prices", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := []models.ProductPrice{}
+	for rows.Next() {
+		price := models.ProductPrice{}
+		if err = rows.Scan(&price.ProductID, &price.Price, &price.OriginalPrice, &price.Currency); err != nil {
+			p.log.Error("error is while scanning product price", logger.Error(err))
+
+			return nil, err
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices, rows.Err()
+}
+
+// SetPrices sets the price and the original price of every product to the given ones.
+func (p *productRepo) SetPrices(ctx context.Context, prices []models.ProductPrice) error {
+	var (
+		ids                     = make([]string, len(prices))
+		amounts, originalPrices = make([]int64, len(prices)), make([]int64, len(prices))
+	)
+
+	for i, price := range prices {
+		ids[i], amounts[i], originalPrices[i] = price.ProductID, int64(price.Price), int64(price.OriginalPrice)
+	}
+
+	query := `update products p set price = n.price, original_price = n.original_price, updated_at = now()
+			from unnest($1::uuid[], $2::bigint[], $3::bigint[]) as n(id, price, original_price)
+				where p.id = n.id`
+
+	if _, err := p.db.Exec(ctx, query, ids, amounts, originalPrices); err != nil {
+		p.log.Error("error is while setting product prices", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// AddQuantity adds to the stock of the product, a negative quantity removes from it, and returns the stock left.
+func (p *productRepo) AddQuantity(ctx context.Context, id string, quantity measure.Quantity) (measure.Quantity, error) {
+	var balance measure.Quantity
+
+	query := `update products set quantity = quantity + $2, updated_at = now() where id = $1 and deleted_at = 0 returning quantity`
+
+	if err := p.db.QueryRow(ctx, query, id, quantity).Scan(&balance); err != nil {
+		p.log.Error("error is while adding product quantity", logger.Error(err))
+
+		return 0, err
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// importedCategory and importedBranch join the staged rows to the category and branch they name,
+// branches are also found by id.
+const (
+	importedCategory = `categories c on lower(c.name) = lower(i.category) and c.deleted_at = 0`
+	importedBranch   = `branches b on (b.id::text = i.branch or lower(b.name) = lower(i.branch)) and b.deleted_at = 0`
+)
+
+// StageImport copies the rows of an import file into the product_import staging table and returns
+// the errors of the rows that cannot be inserted. The table lives until the transaction ends,
+// so call it and InsertImported with the context of one transaction.
+func (p *productRepo) StageImport(ctx context.Context, rows []models.ProductImportRow) ([]models.ProductImportError, error) {
+	if _, err := p.db.Exec(ctx, `create temp table product_import (
+			line int not null,
+			id uuid not null,
+			name text not null,
+			sku text not null,
+			category text not null,
+			price bigint not null,
+			original_price bigint not null,
+			quantity numeric(14, 3) not null,
+			unit text not null,
+			branch text not null
+		) on commit drop`); err != nil {
+		p.log.Error("error is while creating product import table", logger.Error(err))
+
+		return nil, err
+	}
+
+	if _, err := p.db.CopyFrom(ctx, pgx.Identifier{"product_import"},
+		[]string{"line", "id", "name", "sku", "category", "price", "original_price", "quantity", "unit", "branch"},
+		pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+			quantity, err := rows[i].Quantity.NumericValue()
+			if err != nil {
+				return nil, err
+			}
+
+			return []any{rows[i].Line, rows[i].ID, rows[i].Name, rows[i].SKU, rows[i].Category,
+				int64(rows[i].Price), int64(rows[i].OriginalPrice), quantity, rows[i].Unit, rows[i].Branch}, nil
+		})); err != nil {
+		p.log.Error("error is while copying product import rows", logger.Error(err))
+
+		return nil, err
+	}
+
+	query := `
+		select i.line, case when count(c.id) = 0 then 'unknown category ' else 'ambiguous category ' end || i.category
+			from product_import i left join ` + importedCategory + `
+				group by i.line, i.category having count(c.id) <> 1
+		union all
+		select i.line, case when count(b.id) = 0 then 'unknown branch ' else 'ambiguous branch ' end || i.branch
+			from product_import i left join ` + importedBranch + `
+				group by i.line, i.branch having count(b.id) <> 1
+		union all
+		select i.line, 'sku ' || i.sku || ' is already used'
+			from product_import i
+				where i.sku <> '' and exists (select 1 from products where sku = i.sku and deleted_at = 0)
+		union all
+		select i.line, 'sku ' || i.sku || ' is repeated in the file'
+			from product_import i
+				where i.sku <> '' and exists (select 1 from product_import o where o.sku = i.sku and o.line < i.line)
+		order by 1`
+
+	rowErrors := []models.ProductImportError{}
+	result, err := p.db.Query(ctx, query)
+	if err != nil {
+		p.log.Error("error is while validating product import rows", logger.Error(err))
+
+		return nil, err
+	}
+	defer result.Close()
+
+	for result.Next() {
+		rowError := models.ProductImportError{}
+		if err = result.Scan(&rowError.Line, &rowError.Error); err != nil {
+			p.log.Error("error is while scanning product import error", logger.Error(err))
+
+			return nil, err
+		}
+
+		rowErrors = append(rowErrors, rowError)
+	}
+
+	return rowErrors, result.Err()
+}
+
+// InsertImported creates the products staged by StageImport and returns their ids.
+func (p *productRepo) InsertImported(ctx context.Context) ([]string, error) {
+	query := `
+		insert into products(id, name, sku, price, original_price, quantity, category_id, branch_id, currency, unit)
+			select i.id, i.name, nullif(i.sku, ''), i.price, i.original_price, i.quantity, c.id, b.id, b.currency, i.unit
+				from product_import i join ` + importedCategory + ` join ` + importedBranch + `
+					order by i.line
+		returning id`
+
+	rows, err := p.db.Query(ctx, query)
+	if err != nil {
+		p.log.Error("error is while inserting imported products", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			p.log.Error("error is while scanning imported product id", logger.Error(err))
+
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
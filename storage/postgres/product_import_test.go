@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/google/uuid"
+)
+
+func TestProductRepo_StageImport(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	rows := []models.ProductImportRow{
+		{Line: 2, ID: uuid.NewString(), Name: "kefir", SKU: "KEF-IMPORT", Category: "no such category", Price: 9000,
+			Quantity: measure.Whole(5), Unit: "piece", Branch: "aa541fcc-bf74-11ee-ae0b-166244b65504"},
+		{Line: 3, ID: uuid.NewString(), Name: "kefir 2", SKU: "KEF-IMPORT", Category: "no such category", Price: 9000,
+			Quantity: measure.Whole(5), Unit: "piece", Branch: "no such branch"},
+	}
+
+	var rowErrors []models.ProductImportError
+	err = pgStore.WithTx(context.Background(), func(ctx context.Context) error {
+		rowErrors, err = pgStore.Product().StageImport(ctx, rows)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("error while staging import: %v", err)
+	}
+
+	// both categories are unknown, the second row also has an unknown branch and repeats the sku
+	assert.Equal(t, len(rowErrors), 4)
+	assert.Equal(t, rowErrors[0].Line, 2)
+}
@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type productPriceRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewProductPriceRepo(db *pgxpool.Pool, log logger.ILogger) storage.IProductPriceStorage {
+	return &productPriceRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+// Create records a price change, an empty starts_at means the price is already active.
+func (p *productPriceRepo) Create(ctx context.Context, price models.CreateProductPrice) (string, error) {
+	id := uuid.New()
+	query := `insert into product_prices (id, product_id, price, original_price, currency, status, starts_at, created_by)
+			values ($1, $2, $3, $4, (select currency from products where id = $2),
+			        (case when $5::text = '' then 'active' else 'scheduled' end)::product_price_status_enum,
+			        coalesce(nullif($5::text, '')::timestamptz, now()), nullif($6, ''))`
+
+	if _, err := p.db.Exec(ctx, query,
+		id,
+		price.ProductID,
+		price.Price,
+		price.OriginalPrice,
+		price.StartsAt,
+		price.CreatedBy,
+	); err != nil {
+		p.log.Error("error is while inserting product price", logger.Error(err))
+
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (p *productPriceRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.ProductPrice, error) {
+	var (
+		price                          = models.ProductPrice{}
+		startsAt, createdBy, createdAt = sql.NullString{}, sql.NullString{}, sql.NullString{}
+	)
+
+	query := `select id, product_id, price, original_price, currency, status::text, starts_at, created_by, created_at
+			from product_prices where id = $1`
+
+	if err := p.db.QueryRow(ctx, query, key.ID).Scan(
+		&price.ID,
+		&price.ProductID,
+		&price.Price,
+		&price.OriginalPrice,
+		&price.Currency,
+		&price.Status,
+		&startsAt,
+		&createdBy,
+		&createdAt,
+	); err != nil {
+		p.log.Error("error is while selecting product price by id", logger.Error(err))
+
+		return models.ProductPrice{}, err
+	}
+
+	if startsAt.Valid {
+		price.StartsAt = startsAt.String
+	}
+
+	if createdBy.Valid {
+		price.CreatedBy = createdBy.String
+	}
+
+	if createdAt.Valid {
+		price.CreatedAt = createdAt.String
+	}
+
+	return price, nil
+}
+
+func (p *productPriceRepo) GetList(ctx context.Context, request models.GetListRequest) (models.ProductPricesResponse, error) {
+	var (
+		prices                         = []models.ProductPrice{}
+		count                          = 0
+		startsAt, createdBy, createdAt = sql.NullString{}, sql.NullString{}, sql.NullString{}
+	)
+
+	page, err := newListPage(request, keyset{column: "starts_at", columnType: "timestamp", tie: "id", tieType: "uuid", desc: true})
+	if err != nil {
+		return models.ProductPricesResponse{}, err
+	}
+
+	if !request.SkipCount {
+		countQuery := `select count(1) from product_prices where product_id = $1`
+		if err = p.db.QueryRow(ctx, countQuery, request.ProductID).Scan(&count); err != nil {
+			p.log.Error("error is while scanning count of product prices", logger.Error(err))
+
+			return models.ProductPricesResponse{}, err
+		}
+	}
+
+	where, args := page.where([]interface{}{request.ProductID})
+	orderLimit, args := page.orderLimit(args)
+
+	query := `select id, product_id, price, original_price, currency, status::text, starts_at, created_by, created_at
+			from product_prices where product_id = $1` + where + orderLimit
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		p.log.Error("error is while selecting product prices", logger.Error(err))
+
+		return models.ProductPricesResponse{}, err
+	}
+
+	for rows.Next() {
+		price := models.ProductPrice{}
+		if err = rows.Scan(
+			&price.ID,
+			&price.ProductID,
+			&price.Price,
+			&price.OriginalPrice,
+			&price.Currency,
+			&price.Status,
+			&startsAt,
+			&createdBy,
+			&createdAt,
+		); err != nil {
+			p.log.Error("error is while scanning product price", logger.Error(err))
+
+			return models.ProductPricesResponse{}, err
+		}
+
+		if startsAt.Valid {
+			price.StartsAt = startsAt.String
+		}
+
+		if createdBy.Valid {
+			price.CreatedBy = createdBy.String
+		}
+
+		if createdAt.Valid {
+			price.CreatedAt = createdAt.String
+		}
+
+		prices = append(prices, price)
+	}
+
+	prices, cursors := paginate(page, prices, func(price models.ProductPrice) (string, string) {
+		return price.StartsAt, price.ID
+	})
+
+	return models.ProductPricesResponse{
+		ProductPrices: prices,
+		Count:         count,
+		Cursors:       cursors,
+	}, nil
+}
+
+// ActivateScheduled marks every scheduled price of a product that is not deleted active once its start time
+// has come and returns the latest one per product, the products are left to the caller to update.
+func (p *productPriceRepo) ActivateScheduled(ctx context.Context) ([]models.ProductPrice, error) {
+	query := `
+		with due as (
+			update product_prices set status = 'active'
+				where status = 'scheduled' and starts_at <= now()
+					and product_id in (select id from products where deleted_at = 0)
+			returning id, product_id, price, original_price, status, starts_at, created_by
+		)
+		select distinct on (product_id) id, product_id, price, original_price, status::text, created_by
+			from due order by product_id, starts_at desc`
+
+	rows, err := p.db.Query(ctx, query)
+	if err != nil {
+		p.log.Error("error is while activating scheduled product prices", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := []models.ProductPrice{}
+	for rows.Next() {
+		var (
+			price     = models.ProductPrice{}
+			createdBy sql.NullString
+		)
+
+		if err = rows.Scan(&price.ID, &price.ProductID, &price.Price, &price.OriginalPrice, &price.Status, &createdBy); err != nil {
+			p.log.Error("error is while scanning activated product price", logger.Error(err))
+
+			return nil, err
+		}
+
+		if createdBy.Valid {
+			price.CreatedBy = createdBy.String
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices, rows.Err()
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestProductPriceRepo_CreateAndGetList(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "apple",
+		Price:         100,
+		OriginalPrice: 80,
+		Quantity:      measure.Whole(34),
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	activeID, err := pgStore.ProductPrice().Create(context.Background(), models.CreateProductPrice{
+		ProductID:     productID,
+		Price:         100,
+		OriginalPrice: 80,
+	})
+	if err != nil {
+		t.Fatalf("error while creating product price: %v", err)
+	}
+
+	scheduledID, err := pgStore.ProductPrice().Create(context.Background(), models.CreateProductPrice{
+		ProductID:     productID,
+		Price:         120,
+		OriginalPrice: 80,
+		StartsAt:      time.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("error while scheduling product price: %v", err)
+	}
+
+	activePrice, err := pgStore.ProductPrice().GetByID(context.Background(), models.PrimaryKey{ID: activeID})
+	if err != nil {
+		t.Errorf("error while getting product price: %v", err)
+	}
+
+	scheduledPrice, err := pgStore.ProductPrice().GetByID(context.Background(), models.PrimaryKey{ID: scheduledID})
+	if err != nil {
+		t.Errorf("error while getting product price: %v", err)
+	}
+
+	assert.Equal(t, activePrice.Status, "active")
+	assert.Equal(t, scheduledPrice.Status, "scheduled")
+
+	prices, err := pgStore.ProductPrice().GetList(context.Background(), models.GetListRequest{
+		Page:      1,
+		Limit:     10,
+		ProductID: productID,
+	})
+	if err != nil {
+		t.Errorf("error while getting product prices: %v", err)
+	}
+
+	assert.Equal(t, prices.Count, 2)
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"test/api/models"
 	"test/config"
+	"test/pkg/helper"
 	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/money"
 	"testing"
 
 	"github.com/go-playground/assert/v2"
@@ -22,7 +25,7 @@ func TestProductRepo_Create(t *testing.T) {
 		Name:          "apple",
 		Price:         100,
 		OriginalPrice: 2000,
-		Quantity:      34,
+		Quantity:      measure.Whole(34),
 		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
 		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
 	}
@@ -57,7 +60,7 @@ func TestProductRepo_GetById(t *testing.T) {
 		Name:          "apple",
 		Price:         100,
 		OriginalPrice: 2000,
-		Quantity:      34,
+		Quantity:      measure.Whole(34),
 		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
 		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
 	}
@@ -136,7 +139,7 @@ func TestProductRepo_Update(t *testing.T) {
 		Name:          "apple",
 		Price:         100,
 		OriginalPrice: 2000,
-		Quantity:      34,
+		Quantity:      measure.Whole(34),
 		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
 		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
 	}
@@ -144,10 +147,11 @@ func TestProductRepo_Update(t *testing.T) {
 	productid, err := pgStore.Product().Create(context.Background(), createProduct)
 	updateProduct := models.UpdateProduct{
 		ID:            productid,
+		Version:       1,
 		Name:          "apple",
 		Price:         100,
 		OriginalPrice: 2000,
-		Quantity:      34,
+		Quantity:      measure.Whole(34),
 		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
 	}
 
@@ -169,6 +173,48 @@ func TestProductRepo_Update(t *testing.T) {
 	assert.Equal(t, product.OriginalPrice, updateProduct.OriginalPrice)
 }
 
+func TestProductRepo_Patch(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "pear",
+		Price:         100,
+		OriginalPrice: 80,
+		Quantity:      measure.Whole(34),
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	// fields left out of the patch keep their values
+	_, err = pgStore.Product().Patch(context.Background(), models.UpdateProduct{
+		ID:      productID,
+		Version: 1,
+		Price:   120,
+	}, []string{"price"})
+	if err != nil {
+		t.Fatalf("error while patching product: %v", err)
+	}
+
+	product, err := pgStore.Product().GetByID(context.Background(), models.PrimaryKey{ID: productID})
+	if err != nil {
+		t.Fatalf("error while getting product: %v", err)
+	}
+
+	assert.Equal(t, product.Name, "pear")
+	assert.Equal(t, product.Price, money.Amount(120))
+	assert.Equal(t, product.OriginalPrice, money.Amount(80))
+	assert.Equal(t, product.Quantity, measure.Whole(34))
+	assert.Equal(t, product.Version, 2)
+}
+
 func TestProductRepo_Delete(t *testing.T) {
 	cfg := config.Load()
 
@@ -181,7 +227,7 @@ func TestProductRepo_Delete(t *testing.T) {
 		Name:          "apple",
 		Price:         100,
 		OriginalPrice: 2000,
-		Quantity:      34,
+		Quantity:      measure.Whole(34),
 		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
 		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
 	}
@@ -196,3 +242,286 @@ func TestProductRepo_Delete(t *testing.T) {
 	}
 
 }
+
+func TestProductRepo_GetByBarcode(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	barcodes := []string{helper.GenerateBarcode(), helper.GenerateBarcode()}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "apple",
+		Barcodes:      barcodes,
+		Price:         100,
+		OriginalPrice: 80,
+		Quantity:      measure.Whole(34),
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	product, err := pgStore.Product().GetByBarcode(context.Background(), barcodes[1])
+	if err != nil {
+		t.Fatalf("error while getting product by barcode: %v", err)
+	}
+
+	assert.Equal(t, product.ID, productID)
+	assert.Equal(t, len(product.Barcodes), len(barcodes))
+
+	ids, err := pgStore.Product().GetIDsByBarcodes(context.Background(), append(barcodes, "0000000000000"))
+	if err != nil {
+		t.Fatalf("error while getting products by barcodes: %v", err)
+	}
+
+	assert.Equal(t, len(ids), 2)
+	assert.Equal(t, ids[barcodes[0]], productID)
+}
+
+func TestProductRepo_ReuseDeletedBarcode(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	barcode := helper.GenerateBarcode()
+	product := models.CreateProduct{
+		Name:       "melon",
+		Barcodes:   []string{barcode},
+		Price:      100,
+		CategoryID: "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:   "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	}
+
+	deletedID, err := pgStore.Product().Create(context.Background(), product)
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	if err = pgStore.Product().Delete(context.Background(), models.PrimaryKey{ID: deletedID}); err != nil {
+		t.Fatalf("error while deleting product: %v", err)
+	}
+
+	// the barcode of a deleted product is free for another one
+	productID, err := pgStore.Product().Create(context.Background(), product)
+	if err != nil {
+		t.Fatalf("error while creating product with the barcode of a deleted one: %v", err)
+	}
+
+	found, err := pgStore.Product().GetByBarcode(context.Background(), barcode)
+	if err != nil {
+		t.Fatalf("error while getting product by barcode: %v", err)
+	}
+
+	assert.Equal(t, found.ID, productID)
+
+	if err = pgStore.Trash().Restore(context.Background(), models.EntityProduct, deletedID); err == nil {
+		t.Error("expected restoring a product whose barcode is taken to fail")
+	}
+}
+
+func TestProductRepo_GetListGroupVariants(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	parentID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "t-shirt",
+		Price:         100,
+		OriginalPrice: 80,
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating parent product: %v", err)
+	}
+
+	variantID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "t-shirt XL",
+		ParentID:      parentID,
+		Attributes:    map[string]string{"size": "XL", "color": "black"},
+		Price:         120,
+		OriginalPrice: 90,
+		Quantity:      measure.Whole(5),
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating variant: %v", err)
+	}
+
+	variant, err := pgStore.Product().GetByID(context.Background(), models.PrimaryKey{ID: variantID})
+	if err != nil {
+		t.Fatalf("error while getting variant: %v", err)
+	}
+
+	assert.Equal(t, variant.ParentID, parentID)
+	assert.Equal(t, variant.Attributes["size"], "XL")
+
+	products, err := pgStore.Product().GetList(context.Background(), models.GetListRequest{
+		Page:          1,
+		Limit:         10,
+		GroupVariants: true,
+	})
+	if err != nil {
+		t.Fatalf("error while getting grouped product list: %v", err)
+	}
+
+	for _, product := range products.Products {
+		if product.ID == variantID {
+			t.Errorf("variant should be listed under its parent only")
+		}
+
+		if product.ID == parentID {
+			assert.Equal(t, len(product.Variants), 1)
+			assert.Equal(t, product.Variants[0].ID, variantID)
+		}
+	}
+}
+
+func TestProductRepo_GetListFilters(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connection to db: %v", err)
+	}
+
+	categoryID, err := pgStore.Category().Create(context.Background(), models.CreateCategory{Name: "filters"})
+	if err != nil {
+		t.Fatalf("error while creating category: %v", err)
+	}
+
+	for _, product := range []models.CreateProduct{
+		{Name: "cheap", Price: 100, Quantity: measure.Whole(2)},
+		{Name: "middle", Price: 500, Quantity: measure.Whole(50)},
+		{Name: "expensive", Price: 900, Quantity: 0},
+	} {
+		product.CategoryID = categoryID
+		product.BranchID = "aa541fcc-bf74-11ee-ae0b-166244b65504"
+
+		if _, err = pgStore.Product().Create(context.Background(), product); err != nil {
+			t.Fatalf("error while creating product: %v", err)
+		}
+	}
+
+	products, err := pgStore.Product().GetList(context.Background(), models.GetListRequest{
+		Page:        1,
+		Limit:       10,
+		CategoryIDs: []string{categoryID},
+		MinPrice:    100,
+		MaxPrice:    800,
+		Stock:       models.StockIn,
+		SortBy:      "price",
+		SortOrder:   "desc",
+	})
+	if err != nil {
+		t.Fatalf("error while getting filtered product list: %v", err)
+	}
+
+	assert.Equal(t, products.Count, 2)
+	assert.Equal(t, products.Products[0].Name, "middle")
+
+	products, err = pgStore.Product().GetList(context.Background(), models.GetListRequest{
+		Page:              1,
+		Limit:             10,
+		CategoryIDs:       []string{categoryID},
+		Stock:             models.StockLow,
+		LowStockThreshold: measure.Whole(5),
+	})
+	if err != nil {
+		t.Fatalf("error while getting low stock product list: %v", err)
+	}
+
+	assert.Equal(t, products.Count, 1)
+	assert.Equal(t, products.Products[0].Name, "cheap")
+}
+
+func TestPrefixTsQuery(t *testing.T) {
+	assert.Equal(t, prefixTsQuery("Ched  mil"), "ched:* & mil:*")
+	assert.Equal(t, prefixTsQuery("a&b | !c:*"), "a:* & b:* & c:*")
+	assert.Equal(t, prefixTsQuery(" '' "), "")
+}
+
+func TestProductRepo_FullTextSearch(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connection to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:       "Cheddar cheese",
+		Price:      9000,
+		CategoryID: "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:   "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	for _, search := range []string{"ched", "chedar cheese"} {
+		products, err := pgStore.Product().FullTextSearch(context.Background(), models.GetListRequest{
+			Limit:  50,
+			Search: search,
+		})
+		if err != nil {
+			t.Fatalf("error while searching products: %v", err)
+		}
+
+		found := false
+		for _, product := range products.Products {
+			if product.ID == productID {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("expected product to be found by %q", search)
+		}
+	}
+}
+
+func TestProductRepo_UpdateAverageCost(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connection to db error: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "buckwheat",
+		Price:         3000,
+		OriginalPrice: 1000,
+		Quantity:      measure.Whole(10),
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product error: %v", err)
+	}
+
+	// 10 at the original price of 1000 and 30 received at 1400 average 1300
+	if err = pgStore.Product().UpdateAverageCost(context.Background(), productID, measure.Whole(30), 1400); err != nil {
+		t.Fatalf("error while updating average cost error: %v", err)
+	}
+
+	productSell, err := pgStore.Product().Search(context.Background(), map[string]measure.Quantity{productID: measure.Whole(1)}, "")
+	if err != nil {
+		t.Fatalf("error while searching product error: %v", err)
+	}
+
+	assert.Equal(t, productSell.ProductCosts[productID], money.Amount(1300))
+}
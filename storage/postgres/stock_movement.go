@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type stockMovementRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewStockMovementRepo(db *pgxpool.Pool, log logger.ILogger) storage.IStockMovementStorage {
+	return &stockMovementRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+func (s *stockMovementRepo) Create(ctx context.Context, movement models.CreateStockMovement) (string, error) {
+	id := uuid.New()
+	query := `insert into stock_movements (id, product_id, kind, quantity, balance, reason, created_by)
+			values ($1, $2, $3, $4, $5, nullif($6, ''), nullif($7, ''))`
+
+	if _, err := s.db.Exec(ctx, query,
+		id,
+		movement.ProductID,
+		movement.Kind,
+		movement.Quantity,
+		movement.Balance,
+		movement.Reason,
+		movement.CreatedBy,
+	); err != nil {
+		s.log.Error("error is while inserting stock movement", logger.Error(err))
+
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// GetList returns the stock movements of the product, newest first.
+func (s *stockMovementRepo) GetList(ctx context.Context, request models.GetListRequest) (models.StockMovementsResponse, error) {
+	var (
+		movements                    = []models.StockMovement{}
+		count                        = 0
+		reason, createdBy, createdAt = sql.NullString{}, sql.NullString{}, sql.NullString{}
+	)
+
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.StockMovementsResponse{}, err
+	}
+
+	if !request.SkipCount {
+		countQuery := `select count(1) from stock_movements where product_id = $1`
+		if err = s.db.QueryRow(ctx, countQuery, request.ProductID).Scan(&count); err != nil {
+			s.log.Error("error is while scanning count of stock movements", logger.Error(err))
+
+			return models.StockMovementsResponse{}, err
+		}
+	}
+
+	where, args := page.where([]interface{}{request.ProductID})
+	orderLimit, args := page.orderLimit(args)
+
+	query := `select id, product_id, kind, quantity, balance, reason, created_by, created_at
+			from stock_movements where product_id = $1` + where + orderLimit
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		s.log.Error("error is while selecting stock movements", logger.Error(err))
+
+		return models.StockMovementsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		movement := models.StockMovement{}
+		if err = rows.Scan(
+			&movement.ID,
+			&movement.ProductID,
+			&movement.Kind,
+			&movement.Quantity,
+			&movement.Balance,
+			&reason,
+			&createdBy,
+			&createdAt,
+		); err != nil {
+			s.log.Error("error is while scanning stock movement", logger.Error(err))
+
+			return models.StockMovementsResponse{}, err
+		}
+
+		movement.Reason, movement.CreatedBy, movement.CreatedAt = reason.String, createdBy.String, createdAt.String
+
+		movements = append(movements, movement)
+	}
+
+	movements, cursors := paginate(page, movements, func(movement models.StockMovement) (string, string) {
+		return movement.CreatedAt, movement.ID
+	})
+
+	return models.StockMovementsResponse{
+		StockMovements: movements,
+		Count:          count,
+		Cursors:        cursors,
+	}, nil
+}
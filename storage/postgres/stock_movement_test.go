@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestStockMovementRepo_GetList(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:       "yogurt",
+		Price:      7000,
+		Quantity:   measure.Whole(10),
+		CategoryID: "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:   "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	balance, err := pgStore.Product().AddQuantity(context.Background(), productID, measure.Whole(-3))
+	if err != nil {
+		t.Fatalf("error while adding quantity: %v", err)
+	}
+
+	assert.Equal(t, balance, measure.Whole(7))
+
+	if _, err = pgStore.StockMovement().Create(context.Background(), models.CreateStockMovement{
+		ProductID: productID,
+		Kind:      models.StockMovementAdjustment,
+		Quantity:  measure.Whole(-3),
+		Balance:   balance,
+		Reason:    "damaged in storage",
+	}); err != nil {
+		t.Fatalf("error while creating stock movement: %v", err)
+	}
+
+	movements, err := pgStore.StockMovement().GetList(context.Background(), models.GetListRequest{
+		Page:      1,
+		Limit:     10,
+		ProductID: productID,
+	})
+	if err != nil {
+		t.Fatalf("error while getting stock movements: %v", err)
+	}
+
+	assert.Equal(t, movements.Count, 1)
+	assert.Equal(t, movements.StockMovements[0].Reason, "damaged in storage")
+}
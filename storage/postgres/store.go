@@ -3,37 +3,54 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"test/api/models"
+	"test/pkg/money"
 	"test/storage"
 )
 
 type storeRepo struct {
-	db *pgxpool.Pool
+	db txPool
 }
 
 func NewStoreRepo(db *pgxpool.Pool) storage.IStoreStorage {
 	return &storeRepo{
-		db: db,
+		db: txPool{db},
 	}
 }
 
-func (s *storeRepo) AddProfit(ctx context.Context, profit float32, branchID string) error {
+func (s *storeRepo) AddProfit(ctx context.Context, profit money.Amount, branchID string) error {
 	rowsAffected, err := s.db.Exec(ctx, `update store set profit = profit + $1, updated_at = now() where branch_id = $2`, profit, branchID)
 	if err != nil {
 		fmt.Println("Error while adding profit to store", err.Error())
 		return err
 	}
 
-	if n := rowsAffected.RowsAffected(); n == 0 {
-		fmt.Println("Error in rows affected", err.Error())
+	if rowsAffected.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// AddLoss adds the cost of goods written off to the losses of the branch.
+func (s *storeRepo) AddLoss(ctx context.Context, loss money.Amount, branchID string) error {
+	result, err := s.db.Exec(ctx, `update store set loss = loss + $1, updated_at = now() where branch_id = $2`, loss, branchID)
+	if err != nil {
+		fmt.Println("error while adding loss to store", err.Error())
 		return err
 	}
 
-	return err
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
 }
 
-func (s *storeRepo) GetStoreBudget(ctx context.Context, branchID string) (float32, error) {
-	var budget float32
+func (s *storeRepo) GetStoreBudget(ctx context.Context, branchID string) (money.Amount, error) {
+	var budget money.Amount
 	query := `select budget from store where branch_id = $1`
 	if err := s.db.QueryRow(ctx, query, branchID).Scan(&budget); err != nil {
 		fmt.Println("error is while getting store budget", err.Error())
@@ -43,7 +60,32 @@ func (s *storeRepo) GetStoreBudget(ctx context.Context, branchID string) (float3
 	return budget, nil
 }
 
-func (s *storeRepo) WithdrawalDeliveredSum(ctx context.Context, totalSum float32, branchID string) error {
+// GetProfits returns profit of every branch in its own currency.
+func (s *storeRepo) GetProfits(ctx context.Context) ([]models.BranchProfit, error) {
+	profits := []models.BranchProfit{}
+
+	query := `select s.branch_id, b.name, b.currency, coalesce(s.profit, 0), s.loss from store s
+					join branches b on b.id = s.branch_id where b.deleted_at = 0 order by b.name`
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		fmt.Println("error is while selecting store profits", err.Error())
+		return nil, err
+	}
+
+	for rows.Next() {
+		profit := models.BranchProfit{}
+		if err = rows.Scan(&profit.BranchID, &profit.BranchName, &profit.Currency, &profit.Profit, &profit.Loss); err != nil {
+			fmt.Println("error is while scanning store profit", err.Error())
+			return nil, err
+		}
+
+		profits = append(profits, profit)
+	}
+
+	return profits, nil
+}
+
+func (s *storeRepo) WithdrawalDeliveredSum(ctx context.Context, totalSum money.Amount, branchID string) error {
 	query := `update store set budget = budget - $1 where branch_id = $2 `
 	if rowsAffected, err := s.db.Exec(ctx, query, &totalSum, &branchID); err != nil {
 		if r := rowsAffected.RowsAffected(); r == 0 {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"test/config"
 	"test/pkg/logger"
+	"test/pkg/money"
 	"testing"
 	"time"
 )
@@ -16,7 +17,7 @@ func TestStoreAddProfitAndWithdrawal(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	profit := float32(400.0)
+	profit := money.Amount(400)
 	branchID := "28288bf9-3ed1-4f4f-92f4-7ab3d5f2959a"
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
@@ -51,9 +52,10 @@ func TestStoreGetStoreBudget(t *testing.T) {
 		t.Fatalf("GetStoreBudget returned an unexpected error: %v", err)
 	}
 
-	expectedBudget := float32(3000.0)
+	// the budget of the fixture is 3000 in whole units, kept in minor units since the money migration
+	expectedBudget := money.Amount(300000)
 	if budget != expectedBudget {
-		t.Errorf("GetStoreBudget returned unexpected budget. Expected: %f, Got: %f", expectedBudget, budget)
+		t.Errorf("GetStoreBudget returned unexpected budget. Expected: %d, Got: %d", expectedBudget, budget)
 	}
 }
 
@@ -67,7 +69,7 @@ func TestWithdrawalDeliveredSum(t *testing.T) {
 
 	ctx := context.Background()
 	branchID := "3f396f70-60ea-4cb1-8eb7-30fe0fc6664a"
-	totalSum := float32(500)
+	totalSum := money.Amount(500)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 	err = pgStore.Store().WithdrawalDeliveredSum(ctx, totalSum, branchID)
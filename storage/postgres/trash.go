@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// trashTables are the soft-deletable tables keyed by entity name, purged in the given order
+// so that children go before their parents.
+var trashTables = []struct {
+	entity, table string
+	owned         []string
+}{
+	{entity: models.EntityBasketProduct, table: "basket_products"},
+	{entity: models.EntityIncomeProduct, table: "income_products"},
+	{entity: models.EntityBasket, table: "baskets"},
+	{entity: models.EntityIncome, table: "incomes"},
+	{entity: models.EntityProduct, table: "products", owned: []string{"product_barcodes", "product_units", "branch_product_prices", "product_prices"}},
+	{entity: models.EntityCategory, table: "categories"},
+	{entity: models.EntityUser, table: "users"},
+	{entity: models.EntityBranch, table: "branches"},
+}
+
+type trashRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewTrashRepo(db *pgxpool.Pool, log logger.ILogger) storage.ITrashStorage {
+	return &trashRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+// trashTable returns the table of the entity, empty for unknown entities.
+func trashTable(entity string) string {
+	for _, trashTable := range trashTables {
+		if trashTable.entity == entity {
+			return trashTable.table
+		}
+	}
+
+	return ""
+}
+
+// deletedCondition selects live rows, or soft-deleted ones when the request lists the trash.
+func deletedCondition(request models.GetListRequest, column string) string {
+	if request.Deleted {
+		return column + " <> 0"
+	}
+
+	return column + " = 0"
+}
+
+// Restore brings a soft-deleted row back, pgx.ErrNoRows is returned when there is no such row in the trash.
+func (t *trashRepo) Restore(ctx context.Context, entity, id string) error {
+	table := trashTable(entity)
+	if table == "" {
+		return errors.New("unknown entity " + entity)
+	}
+
+	result, err := t.db.Exec(ctx, `update `+table+` set deleted_at = 0 where id = $1 and deleted_at <> 0`, id)
+	if err != nil {
+		t.log.Error("error is while restoring deleted row", logger.Error(err))
+
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// Purge removes rows deleted before the given unix time for good.
+// Rows still referenced by live data are kept until their dependents go.
+func (t *trashRepo) Purge(ctx context.Context, deletedBefore int64) (int64, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	purged := int64(0)
+	for _, trashTable := range trashTables {
+		rows, err := tx.Query(ctx, `select id::text from `+trashTable.table+` where deleted_at <> 0 and deleted_at < $1`, deletedBefore)
+		if err != nil {
+			t.log.Error("error is while selecting rows to purge", logger.Error(err))
+
+			return 0, err
+		}
+
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			t.log.Error("error is while scanning rows to purge", logger.Error(err))
+
+			return 0, err
+		}
+
+		for _, id := range ids {
+			ok, err := purgeRow(ctx, tx, trashTable.table, trashTable.owned, id)
+			if err != nil {
+				t.log.Error("error is while purging deleted row", logger.Error(err))
+
+				return 0, err
+			}
+
+			if ok {
+				purged++
+			}
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// purgeRow deletes one row with the rows it owns inside a savepoint,
+// it reports false when the row is still referenced and was left in place.
+func purgeRow(ctx context.Context, tx pgx.Tx, table string, owned []string, id string) (bool, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer savepoint.Rollback(ctx)
+
+	for _, ownedTable := range owned {
+		if _, err = savepoint.Exec(ctx, `delete from `+ownedTable+` where product_id = $1`, id); err != nil {
+			return false, skipForeignKeyViolation(err)
+		}
+	}
+
+	if _, err = savepoint.Exec(ctx, `delete from `+table+` where id = $1`, id); err != nil {
+		return false, skipForeignKeyViolation(err)
+	}
+
+	return true, savepoint.Commit(ctx)
+}
+
+// skipForeignKeyViolation swallows the error of deleting a row other rows still reference.
+func skipForeignKeyViolation(err error) error {
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestTrashRepo_Restore(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Errorf("error while connection to db error: %v", err)
+	}
+
+	categoryID, err := pgStore.Category().Create(context.Background(), models.CreateCategory{
+		Name: "trashed category",
+	})
+	if err != nil {
+		t.Errorf("error while creating category error: %v", err)
+	}
+
+	if err = pgStore.Category().Delete(context.Background(), models.PrimaryKey{ID: categoryID}); err != nil {
+		t.Errorf("error while deleting category error: %v", err)
+	}
+
+	trash, err := pgStore.Category().GetList(context.Background(), models.GetListRequest{
+		Page:    1,
+		Limit:   1000,
+		Deleted: true,
+	})
+	if err != nil {
+		t.Errorf("error while getting trash error: %v", err)
+	}
+
+	trashed := false
+	for _, category := range trash.Category {
+		if category.ID == categoryID {
+			trashed = true
+		}
+	}
+
+	assert.Equal(t, trashed, true)
+
+	if err = pgStore.Trash().Restore(context.Background(), models.EntityCategory, categoryID); err != nil {
+		t.Errorf("error while restoring category error: %v", err)
+	}
+
+	category, err := pgStore.Category().GetByID(context.Background(), models.PrimaryKey{ID: categoryID})
+	if err != nil {
+		t.Errorf("error while getting restored category error: %v", err)
+	}
+
+	assert.Equal(t, category.ID, categoryID)
+
+	err = pgStore.Trash().Restore(context.Background(), models.EntityCategory, categoryID)
+	assert.NotEqual(t, err, nil)
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// txPool runs queries in the transaction carried by the context and on the pool otherwise,
+// so that changes made through several repos can be committed together.
+type txPool struct {
+	*pgxpool.Pool
+}
+
+func (p txPool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Exec(ctx, sql, args...)
+	}
+
+	return p.Pool.Exec(ctx, sql, args...)
+}
+
+func (p txPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Query(ctx, sql, args...)
+	}
+
+	return p.Pool.Query(ctx, sql, args...)
+}
+
+func (p txPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+
+	return p.Pool.QueryRow(ctx, sql, args...)
+}
+
+// CopyFrom copies rows in the transaction carried by the context and on the pool otherwise.
+func (p txPool) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	}
+
+	return p.Pool.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+// Begin starts a savepoint inside the transaction carried by the context.
+func (p txPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+
+	return p.Pool.Begin(ctx)
+}
+
+// WithTx runs fn in one transaction, repos called with the context given to fn take part in it.
+// Inside an outer transaction fn simply joins it.
+func (s Store) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
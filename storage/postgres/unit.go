@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type unitRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewUnitRepo(db *pgxpool.Pool, log logger.ILogger) storage.IUnitStorage {
+	return &unitRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+func (u *unitRepo) GetList(ctx context.Context) (models.UnitsResponse, error) {
+	units := []models.Unit{}
+
+	rows, err := u.db.Query(ctx, `select code, name, fractional from units order by code`)
+	if err != nil {
+		u.log.Error("error is while selecting units", logger.Error(err))
+
+		return models.UnitsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		unit := models.Unit{}
+		if err = rows.Scan(&unit.Code, &unit.Name, &unit.Fractional); err != nil {
+			u.log.Error("error is while scanning unit", logger.Error(err))
+
+			return models.UnitsResponse{}, err
+		}
+
+		units = append(units, unit)
+	}
+
+	return models.UnitsResponse{
+		Units: units,
+		Count: len(units),
+	}, nil
+}
+
+func (u *unitRepo) GetByCode(ctx context.Context, code string) (models.Unit, error) {
+	unit := models.Unit{}
+
+	if err := u.db.QueryRow(ctx, `select code, name, fractional from units where code = $1`, code).Scan(
+		&unit.Code,
+		&unit.Name,
+		&unit.Fractional,
+	); err != nil {
+		u.log.Error("error is while selecting unit by code", logger.Error(err))
+
+		return models.Unit{}, err
+	}
+
+	return unit, nil
+}
+
+// SetProductUnits replaces packagings of the product with the given ones.
+func (u *unitRepo) SetProductUnits(ctx context.Context, request models.SetProductUnits) error {
+	var (
+		ids     = make([]string, 0, len(request.Units))
+		units   = make([]string, 0, len(request.Units))
+		factors = make([]string, 0, len(request.Units))
+	)
+
+	for _, unit := range request.Units {
+		ids = append(ids, uuid.New().String())
+		units = append(units, unit.Unit)
+		factors = append(factors, unit.Factor.String())
+	}
+
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		u.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `delete from product_units where product_id = $1 and unit <> all($2::varchar[])`, request.ProductID, units); err != nil {
+		u.log.Error("error is while deleting product units", logger.Error(err))
+
+		return err
+	}
+
+	query := `insert into product_units (id, product_id, unit, factor)
+			select id, $1::uuid, unit, factor from unnest($2::uuid[], $3::varchar[], $4::numeric[]) as t(id, unit, factor)
+		on conflict (product_id, unit) do update set factor = excluded.factor, updated_at = now()`
+
+	if _, err = tx.Exec(ctx, query, request.ProductID, ids, units, factors); err != nil {
+		u.log.Error("error is while upserting product units", logger.Error(err))
+
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (u *unitRepo) GetProductUnits(ctx context.Context, productID string) (models.ProductUnitsResponse, error) {
+	var (
+		productUnits         = []models.ProductUnit{}
+		createdAt, updatedAt = sql.NullString{}, sql.NullString{}
+	)
+
+	query := `select id, product_id, unit, factor, created_at, updated_at from product_units where product_id = $1 order by unit`
+
+	rows, err := u.db.Query(ctx, query, productID)
+	if err != nil {
+		u.log.Error("error is while selecting product units", logger.Error(err))
+
+		return models.ProductUnitsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		productUnit := models.ProductUnit{}
+		if err = rows.Scan(
+			&productUnit.ID,
+			&productUnit.ProductID,
+			&productUnit.Unit,
+			&productUnit.Factor,
+			&createdAt,
+			&updatedAt,
+		); err != nil {
+			u.log.Error("error is while scanning product unit", logger.Error(err))
+
+			return models.ProductUnitsResponse{}, err
+		}
+
+		if createdAt.Valid {
+			productUnit.CreatedAt = createdAt.String
+		}
+
+		if updatedAt.Valid {
+			productUnit.UpdatedAt = updatedAt.String
+		}
+
+		productUnits = append(productUnits, productUnit)
+	}
+
+	return models.ProductUnitsResponse{
+		ProductUnits: productUnits,
+		Count:        len(productUnits),
+	}, nil
+}
+
+// GetFactors returns how many product units one packaging holds, keyed by product id and packaging unit.
+func (u *unitRepo) GetFactors(ctx context.Context, productIDs []string) (map[string]map[string]measure.Quantity, error) {
+	factors := map[string]map[string]measure.Quantity{}
+
+	rows, err := u.db.Query(ctx, `select product_id, unit, factor from product_units where product_id = any($1::uuid[])`, productIDs)
+	if err != nil {
+		u.log.Error("error is while selecting product unit factors", logger.Error(err))
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			productID, unit string
+			factor          measure.Quantity
+		)
+
+		if err = rows.Scan(&productID, &unit, &factor); err != nil {
+			u.log.Error("error is while scanning product unit factor", logger.Error(err))
+
+			return nil, err
+		}
+
+		if factors[productID] == nil {
+			factors[productID] = map[string]measure.Quantity{}
+		}
+
+		factors[productID][unit] = factor
+	}
+
+	return factors, nil
+}
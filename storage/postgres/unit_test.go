@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestUnitRepo_SetProductUnits(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "cheese",
+		Price:         9000,
+		OriginalPrice: 7000,
+		Quantity:      measure.Quantity(1250),
+		Unit:          "kg",
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	product, err := pgStore.Product().GetByID(context.Background(), models.PrimaryKey{ID: productID})
+	if err != nil {
+		t.Fatalf("error while getting product: %v", err)
+	}
+
+	assert.Equal(t, product.Unit, "kg")
+	assert.Equal(t, product.Quantity, measure.Quantity(1250))
+
+	for _, units := range [][]models.SetProductUnit{
+		{{Unit: "box", Factor: measure.Whole(12)}, {Unit: "head", Factor: measure.Quantity(2500)}},
+		{{Unit: "box", Factor: measure.Whole(10)}},
+	} {
+		if err = pgStore.Unit().SetProductUnits(context.Background(), models.SetProductUnits{
+			ProductID: productID,
+			Units:     units,
+		}); err != nil {
+			t.Fatalf("error while setting product units: %v", err)
+		}
+	}
+
+	productUnits, err := pgStore.Unit().GetProductUnits(context.Background(), productID)
+	if err != nil {
+		t.Fatalf("error while getting product units: %v", err)
+	}
+
+	assert.Equal(t, productUnits.Count, 1)
+	assert.Equal(t, productUnits.ProductUnits[0].Factor, measure.Whole(10))
+
+	factors, err := pgStore.Unit().GetFactors(context.Background(), []string{productID})
+	if err != nil {
+		t.Fatalf("error while getting product unit factors: %v", err)
+	}
+
+	assert.Equal(t, factors[productID]["box"], measure.Whole(10))
+}
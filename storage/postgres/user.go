@@ -5,20 +5,23 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"test/api/models"
 	"test/pkg/logger"
+	"test/pkg/money"
 	"test/storage"
+	"time"
 )
 
 type userRepo struct {
-	db  *pgxpool.Pool
+	db  txPool
 	log logger.ILogger
 }
 
 func NewUserRepo(db *pgxpool.Pool, log logger.ILogger) storage.IUserStorage {
 	return &userRepo{
-		db:  db,
+		db:  txPool{db},
 		log: log,
 	}
 }
@@ -50,7 +53,7 @@ func (u *userRepo) GetByID(ctx context.Context, pKey models.PrimaryKey) (models.
 	user := models.User{}
 
 	query := `
-		select id, full_name, phone, cash, branch_id, created_at, updated_at 
+		select id, full_name, phone, cash, branch_id, version, created_at, updated_at, ` + deletedReferences("users", reference{"branch_id", "branches"}) + `
 						from users where id = $1 and deleted_at = 0 and user_role = 'customer'
 `
 	if err := u.db.QueryRow(ctx, query, pKey.ID).Scan(
@@ -59,8 +62,10 @@ func (u *userRepo) GetByID(ctx context.Context, pKey models.PrimaryKey) (models.
 		&user.Phone,    //2
 		&user.Cash,     //3
 		&user.BranchID,
+		&user.Version,
 		&createdAt, //4
 		&updatedAt, //5
+		&user.DeletedReferences,
 	); err != nil {
 		u.log.Error("error while scanning user", logger.Error(err))
 		return models.User{}, err
@@ -82,37 +87,44 @@ func (u *userRepo) GetList(ctx context.Context, request models.GetListRequest) (
 		users                = []models.User{}
 		count                = 0
 		countQuery, query    string
-		page                 = request.Page
-		offset               = (page - 1) * request.Limit
 		search               = request.Search
 		createdAt, updatedAt = sql.NullTime{}, sql.NullString{}
 	)
 
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.UsersResponse{}, err
+	}
+
 	countQuery = `
-		SELECT count(1) from users where user_role = 'customer' and deleted_at = 0 `
+		SELECT count(1) from users where user_role = 'customer' and ` + deletedCondition(request, "deleted_at") + ` `
 
 	if search != "" {
 		countQuery += fmt.Sprintf(` and (phone ilike '%s' or full_name ilike '%s')`, search, search)
 	}
 
-	if err := u.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		fmt.Println("error while scanning count of users", err.Error())
-		return models.UsersResponse{}, err
+	if !request.SkipCount {
+		if err = u.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
+			fmt.Println("error while scanning count of users", err.Error())
+			return models.UsersResponse{}, err
+		}
 	}
 
 	query = `
-		SELECT id, full_name, phone, cash, branch_id, created_at, updated_at
+		SELECT id, full_name, phone, cash, branch_id, version, created_at, updated_at
 			FROM users
-			    WHERE user_role = 'customer' and deleted_at = 0
+			    WHERE user_role = 'customer' and ` + deletedCondition(request, "deleted_at") + `
 			    `
 
 	if search != "" {
 		query += fmt.Sprintf(` and (phone ilike '%s' or full_name ilike '%s') `, search, search)
 	}
 
-	query += ` order by created_at desc LIMIT $1 OFFSET $2`
+	where, args := page.where(nil)
+	orderLimit, args := page.orderLimit(args)
+	query += where + orderLimit
 
-	rows, err := u.db.Query(ctx, query, request.Limit, offset)
+	rows, err := u.db.Query(ctx, query, args...)
 	if err != nil {
 		fmt.Println("error while query rows", err.Error())
 		return models.UsersResponse{}, err
@@ -127,6 +139,7 @@ func (u *userRepo) GetList(ctx context.Context, request models.GetListRequest) (
 			&user.Phone,
 			&user.Cash,
 			&user.BranchID,
+			&user.Version,
 			&createdAt,
 			&updatedAt,
 		); err != nil {
@@ -143,9 +156,14 @@ func (u *userRepo) GetList(ctx context.Context, request models.GetListRequest) (
 		users = append(users, user)
 	}
 
+	users, cursors := paginate(page, users, func(user models.User) (string, string) {
+		return user.CreatedAt.Format(time.RFC3339Nano), user.ID
+	})
+
 	return models.UsersResponse{
-		Users: users,
-		Count: count,
+		Users:   users,
+		Count:   count,
+		Cursors: cursors,
 	}, nil
 }
 
@@ -153,13 +171,41 @@ func (u *userRepo) Update(ctx context.Context, request models.UpdateUser) (strin
 	query := `
 		update users 
 			set full_name = $1, phone = $2, cash = $3, updated_at = now()
-				where user_role = 'customer' and id = $4`
+				where user_role = 'customer' and id = $4 and version = $5`
 
-	if _, err := u.db.Exec(ctx, query, request.FullName, request.Phone, request.Cash, request.ID); err != nil {
+	result, err := u.db.Exec(ctx, query, request.FullName, request.Phone, request.Cash, request.ID, request.Version)
+	if err != nil {
 		fmt.Println("error while updating user data", err.Error())
 		return "", err
 	}
 
+	// the user is gone or was changed since the version was read
+	if result.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
+	return request.ID, nil
+}
+
+// Patch updates only the fields of the customer present in a merge patch.
+func (u *userRepo) Patch(ctx context.Context, request models.UpdateUser, fields []string) (string, error) {
+	set, args := patchSet(fields, map[string]patchColumn{
+		"full_name": {column: "full_name", value: request.FullName},
+		"phone":     {column: "phone", value: request.Phone},
+		"cash":      {column: "cash", value: request.Cash},
+	}, request.ID, request.Version)
+
+	result, err := u.db.Exec(ctx, patchQuery("users", set, "and user_role = 'customer'"), args...)
+	if err != nil {
+		u.log.Error("error while patching user", logger.Error(err))
+		return "", err
+	}
+
+	// the user is gone or was changed since the version was read
+	if result.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
 	return request.ID, nil
 }
 
@@ -203,7 +249,7 @@ func (u *userRepo) UpdatePassword(ctx context.Context, request models.UpdateUser
 	return nil
 }
 
-func (u *userRepo) UpdateCustomerCash(ctx context.Context, id string, sum int) error {
+func (u *userRepo) UpdateCustomerCash(ctx context.Context, id string, sum money.Amount) error {
 	query := `update users set cash = cash - $1 where id = $2`
 
 	if _, err := u.db.Exec(ctx, query, sum, id); err != nil {
@@ -212,4 +258,17 @@ func (u *userRepo) UpdateCustomerCash(ctx context.Context, id string, sum int) e
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// IsAdmin tells whether the user is an admin, admins manage the stores and approve their documents.
+func (u *userRepo) IsAdmin(ctx context.Context, id string) (bool, error) {
+	isAdmin := false
+
+	query := `select exists (select 1 from users where id::text = $1 and user_role = 'admin' and deleted_at = 0)`
+	if err := u.db.QueryRow(ctx, query, id).Scan(&isAdmin); err != nil {
+		u.log.Error("error while checking user role", logger.Error(err))
+		return false, err
+	}
+
+	return isAdmin, nil
+}
@@ -181,6 +181,7 @@ func TestUserRepo_Update(t *testing.T) {
 
 	UpdateUser := models.UpdateUser{
 		ID:       userID,
+		Version:  1,
 		FullName: helper.GenerateFullName(),
 		Phone:    helper.GeneratePhoneNumber(),
 		Cash:     10,
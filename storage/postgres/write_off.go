@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"test/api/models"
+	"test/pkg/logger"
+	"test/pkg/money"
+	"test/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type writeOffRepo struct {
+	db  txPool
+	log logger.ILogger
+}
+
+func NewWriteOffRepo(db *pgxpool.Pool, log logger.ILogger) storage.IWriteOffStorage {
+	return &writeOffRepo{
+		db:  txPool{db},
+		log: log,
+	}
+}
+
+func (w *writeOffRepo) Create(ctx context.Context, writeOff models.CreateWriteOff) (string, error) {
+	id := uuid.New()
+
+	tx, err := w.db.Begin(ctx)
+	if err != nil {
+		w.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `insert into write_offs (id, branch_id, reason, note, created_by)
+			values ($1, $2, $3::write_off_reason_enum, nullif($4, ''), nullif($5, ''))`
+	if _, err = tx.Exec(ctx, query, id, writeOff.BranchID, writeOff.Reason, writeOff.Note, writeOff.CreatedBy); err != nil {
+		w.log.Error("error is while inserting write-off", logger.Error(err))
+
+		return "", err
+	}
+
+	for _, line := range writeOff.Lines {
+		if _, err = tx.Exec(ctx, `insert into write_off_lines (write_off_id, product_id, quantity) values ($1, $2, $3)`,
+			id, line.ProductID, line.Quantity); err != nil {
+			w.log.Error("error is while inserting write-off line", logger.Error(err))
+
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		w.log.Error("error is while committing transaction", logger.Error(err))
+
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// GetByID returns the write-off with its lines, lines of a write-off that is not posted yet have the current cost of the product.
+func (w *writeOffRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.WriteOff, error) {
+	writeOff, err := scanWriteOff(w.db.QueryRow(ctx, `select `+writeOffColumns+` from write_offs w where w.id = $1`, key.ID))
+	if err != nil {
+		w.log.Error("error is while selecting write-off by id", logger.Error(err))
+
+		return models.WriteOff{}, err
+	}
+
+	query := `select l.product_id, p.name, l.quantity, coalesce(l.cost, p.average_cost, p.original_price)
+			from write_off_lines l join products p on p.id = l.product_id
+				where l.write_off_id = $1 order by p.name, l.product_id`
+
+	rows, err := w.db.Query(ctx, query, key.ID)
+	if err != nil {
+		w.log.Error("error is while selecting write-off lines", logger.Error(err))
+
+		return models.WriteOff{}, err
+	}
+	defer rows.Close()
+
+	writeOff.Lines = []models.WriteOffLine{}
+	for rows.Next() {
+		line := models.WriteOffLine{}
+		if err = rows.Scan(&line.ProductID, &line.ProductName, &line.Quantity, &line.Cost); err != nil {
+			w.log.Error("error is while scanning write-off line", logger.Error(err))
+
+			return models.WriteOff{}, err
+		}
+
+		writeOff.Lines = append(writeOff.Lines, line)
+	}
+
+	return writeOff, rows.Err()
+}
+
+// GetList returns write-offs without their lines, newest first.
+func (w *writeOffRepo) GetList(ctx context.Context, request models.GetListRequest) (models.WriteOffsResponse, error) {
+	var (
+		writeOffs = []models.WriteOff{}
+		count     = 0
+	)
+
+	page, err := newListPage(request, createdAtKeyset)
+	if err != nil {
+		return models.WriteOffsResponse{}, err
+	}
+
+	filter := ` where (w.branch_id = nullif($1, '')::uuid or $1 = '') and (w.status::text = $2 or $2 = '')`
+	args := []interface{}{request.BranchID, request.Status}
+
+	if !request.SkipCount {
+		if err = w.db.QueryRow(ctx, `select count(1) from write_offs w`+filter, args...).Scan(&count); err != nil {
+			w.log.Error("error is while scanning count of write-offs", logger.Error(err))
+
+			return models.WriteOffsResponse{}, err
+		}
+	}
+
+	where, args := page.where(args)
+	orderLimit, args := page.orderLimit(args)
+
+	rows, err := w.db.Query(ctx, `select `+writeOffColumns+` from write_offs w`+filter+where+orderLimit, args...)
+	if err != nil {
+		w.log.Error("error is while selecting write-offs", logger.Error(err))
+
+		return models.WriteOffsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		writeOff, err := scanWriteOff(rows)
+		if err != nil {
+			w.log.Error("error is while scanning write-off", logger.Error(err))
+
+			return models.WriteOffsResponse{}, err
+		}
+
+		writeOffs = append(writeOffs, writeOff)
+	}
+
+	writeOffs, cursors := paginate(page, writeOffs, func(writeOff models.WriteOff) (string, string) {
+		return writeOff.CreatedAt, writeOff.ID
+	})
+
+	return models.WriteOffsResponse{
+		WriteOffs: writeOffs,
+		Count:     count,
+		Cursors:   cursors,
+	}, nil
+}
+
+// Close posts or rejects a pending write-off, it returns no rows when the write-off is not pending.
+// The costs of the lines of a posted write-off are fixed at the current costs of the products.
+func (w *writeOffRepo) Close(ctx context.Context, id, status, closedBy string) error {
+	tx, err := w.db.Begin(ctx)
+	if err != nil {
+		w.log.Error("error is while beginning transaction", logger.Error(err))
+
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `update write_offs set status = $2::write_off_status_enum, closed_by = nullif($3, ''), closed_at = now(), updated_at = now()
+			where id = $1 and status = 'pending'`
+
+	result, err := tx.Exec(ctx, query, id, status, closedBy)
+	if err != nil {
+		w.log.Error("error is while closing write-off", logger.Error(err))
+
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	if status == models.WriteOffPosted {
+		if _, err = tx.Exec(ctx, `update write_off_lines l set cost = coalesce(p.average_cost, p.original_price) from products p
+				where p.id = l.product_id and l.write_off_id = $1`, id); err != nil {
+			w.log.Error("error is while fixing write-off costs", logger.Error(err))
+
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		w.log.Error("error is while committing transaction", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// SetLineCost fixes the cost of one unit of the product written off.
+func (w *writeOffRepo) SetLineCost(ctx context.Context, writeOffID, productID string, cost money.Amount) error {
+	if _, err := w.db.Exec(ctx, `update write_off_lines set cost = $3 where write_off_id = $1 and product_id = $2`,
+		writeOffID, productID, cost); err != nil {
+		w.log.Error("error is while setting write-off line cost", logger.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// writeOffColumns are read from write_offs w, the total cost is the rounded sum of the costs of the lines.
+const writeOffColumns = `w.id, w.branch_id, w.reason::text, w.status::text, w.note,
+		(select coalesce(sum(round(l.quantity * coalesce(l.cost, p.average_cost, p.original_price))), 0)
+			from write_off_lines l join products p on p.id = l.product_id where l.write_off_id = w.id),
+		w.created_by, w.closed_by, w.closed_at, w.created_at, w.updated_at`
+
+func scanWriteOff(row pgx.Row) (models.WriteOff, error) {
+	var (
+		writeOff                                                  = models.WriteOff{}
+		note, createdBy, closedBy, closedAt, createdAt, updatedAt = sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}
+	)
+
+	if err := row.Scan(&writeOff.ID, &writeOff.BranchID, &writeOff.Reason, &writeOff.Status, &note, &writeOff.TotalCost, &createdBy, &closedBy, &closedAt, &createdAt, &updatedAt); err != nil {
+		return models.WriteOff{}, err
+	}
+
+	writeOff.Note, writeOff.CreatedBy, writeOff.ClosedBy = note.String, createdBy.String, closedBy.String
+	writeOff.ClosedAt, writeOff.CreatedAt, writeOff.UpdatedAt = closedAt.String, createdAt.String, updatedAt.String
+
+	return writeOff, nil
+}
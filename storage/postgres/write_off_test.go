@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/pkg/measure"
+	"test/pkg/money"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestWriteOffRepo_Close(t *testing.T) {
+	cfg := config.Load()
+
+	pgStore, err := New(context.Background(), cfg, logger.New(""))
+	if err != nil {
+		t.Fatalf("error while connecting to db: %v", err)
+	}
+
+	productID, err := pgStore.Product().Create(context.Background(), models.CreateProduct{
+		Name:          "sour cream",
+		Price:         5000,
+		OriginalPrice: 3000,
+		Quantity:      measure.Whole(10),
+		CategoryID:    "0b59dd69-b7b3-43c7-95c1-19a1fd9e0677",
+		BranchID:      "aa541fcc-bf74-11ee-ae0b-166244b65504",
+	})
+	if err != nil {
+		t.Fatalf("error while creating product: %v", err)
+	}
+
+	writeOffID, err := pgStore.WriteOff().Create(context.Background(), models.CreateWriteOff{
+		BranchID: "aa541fcc-bf74-11ee-ae0b-166244b65504",
+		Reason:   models.WriteOffExpiry,
+		Lines:    []models.CreateWriteOffLine{{ProductID: productID, Quantity: measure.Whole(2)}},
+	})
+	if err != nil {
+		t.Fatalf("error while creating write-off: %v", err)
+	}
+
+	if err = pgStore.WriteOff().Close(context.Background(), writeOffID, models.WriteOffPosted, ""); err != nil {
+		t.Fatalf("error while posting write-off: %v", err)
+	}
+
+	writeOff, err := pgStore.WriteOff().GetByID(context.Background(), models.PrimaryKey{ID: writeOffID})
+	if err != nil {
+		t.Fatalf("error while getting write-off: %v", err)
+	}
+
+	assert.Equal(t, writeOff.Status, models.WriteOffPosted)
+	assert.Equal(t, writeOff.Lines[0].Cost, money.Amount(3000))
+	assert.Equal(t, writeOff.TotalCost, money.Amount(6000))
+
+	// the cost of the lots the write-off took replaces the cost of the product
+	if err = pgStore.WriteOff().SetLineCost(context.Background(), writeOffID, productID, 2500); err != nil {
+		t.Fatalf("error while setting write-off line cost: %v", err)
+	}
+
+	writeOff, err = pgStore.WriteOff().GetByID(context.Background(), models.PrimaryKey{ID: writeOffID})
+	if err != nil {
+		t.Fatalf("error while getting write-off: %v", err)
+	}
+
+	assert.Equal(t, writeOff.Lines[0].Cost, money.Amount(2500))
+	assert.Equal(t, writeOff.TotalCost, money.Amount(5000))
+
+	err = pgStore.WriteOff().Close(context.Background(), writeOffID, models.WriteOffRejected, "")
+	if !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expected closing a posted write-off to find no rows, but got: %v", err)
+	}
+}
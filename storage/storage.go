@@ -3,10 +3,13 @@ package storage
 import (
 	"context"
 	"test/api/models"
+	"test/pkg/measure"
+	"test/pkg/money"
 )
 
 type IStorage interface {
 	Close()
+	WithTx(context.Context, func(context.Context) error) error
 	User() IUserStorage
 	Category() ICategoryStorage
 	Product() IProductStorage
@@ -17,6 +20,19 @@ type IStorage interface {
 	Dealer() IDealerStorage
 	Income() IIncomeStorage
 	IncomeProduct() IIncomeProductStorage
+	Loyalty() ILoyaltyStorage
+	ProductPrice() IProductPriceStorage
+	BranchProductPrice() IBranchProductPriceStorage
+	CurrencyRate() ICurrencyRateStorage
+	Unit() IUnitStorage
+	Trash() ITrashStorage
+	Dependency() IDependencyStorage
+	Audit() IAuditStorage
+	StockMovement() IStockMovementStorage
+	InventoryCount() IInventoryCountStorage
+	WriteOff() IWriteOffStorage
+	Lot() ILotStorage
+	Backorder() IBackorderStorage
 }
 
 type IUserStorage interface {
@@ -24,10 +40,12 @@ type IUserStorage interface {
 	GetByID(context.Context, models.PrimaryKey) (models.User, error)
 	GetList(context.Context, models.GetListRequest) (models.UsersResponse, error)
 	Update(context.Context, models.UpdateUser) (string, error)
+	Patch(context.Context, models.UpdateUser, []string) (string, error)
 	Delete(context.Context, models.PrimaryKey) error
 	GetPassword(context.Context, string) (string, error)
 	UpdatePassword(context.Context, models.UpdateUserPassword) error
-	UpdateCustomerCash(context.Context, string, int) error
+	UpdateCustomerCash(context.Context, string, money.Amount) error
+	IsAdmin(context.Context, string) (bool, error)
 }
 
 type ICategoryStorage interface {
@@ -35,7 +53,10 @@ type ICategoryStorage interface {
 	GetByID(context.Context, models.PrimaryKey) (models.Category, error)
 	GetList(context.Context, models.GetListRequest) (models.CategoryResponse, error)
 	Update(context.Context, models.UpdateCategory) (string, error)
+	Patch(context.Context, models.UpdateCategory, []string) (string, error)
 	Delete(context.Context, models.PrimaryKey) error
+	GetAll(context.Context) ([]models.Category, error)
+	GetDescendantIDs(context.Context, string) ([]string, error)
 }
 
 type IProductStorage interface {
@@ -43,18 +64,30 @@ type IProductStorage interface {
 	GetByID(context.Context, models.PrimaryKey) (models.Product, error)
 	GetList(context.Context, models.GetListRequest) (models.ProductResponse, error)
 	Update(context.Context, models.UpdateProduct) (string, error)
+	Patch(context.Context, models.UpdateProduct, []string) (string, error)
+	StageImport(context.Context, []models.ProductImportRow) ([]models.ProductImportError, error)
+	InsertImported(context.Context) ([]string, error)
+	LockPrices(context.Context, models.ProductFilter) ([]models.ProductPrice, error)
+	SetPrices(context.Context, []models.ProductPrice) error
+	AddQuantity(context.Context, string, measure.Quantity) (measure.Quantity, error)
+	UpdateAverageCost(ctx context.Context, productID string, quantity measure.Quantity, cost money.Amount) error
 	Delete(context.Context, models.PrimaryKey) error
-	Search(context.Context, map[string]int) (models.ProductSell, error)
-	TakeProducts(context.Context, map[string]int) error
+	Search(context.Context, map[string]measure.Quantity, string) (models.ProductSell, error)
+	TakeProducts(context.Context, map[string]measure.Quantity) error
 	AddDeliveredProducts(context.Context, models.DeliverProducts, string) error
-	GetListByIDs(context.Context, []string) (models.ProductResponse, error)
+	GetListByIDs(context.Context, []string, string) (models.ProductResponse, error)
+	GetByBarcode(context.Context, string) (models.Product, error)
+	GetIDsByBarcodes(context.Context, []string) (map[string]string, error)
+	FullTextSearch(context.Context, models.GetListRequest) (models.ProductResponse, error)
 }
 type IBasketStorage interface {
 	Create(context.Context, models.CreateBasket) (string, error)
 	GetByID(context.Context, models.PrimaryKey) (models.Basket, error)
 	GetList(context.Context, models.GetListRequest) (models.BasketResponse, error)
 	Update(context.Context, models.UpdateBasket) (string, error)
+	Patch(context.Context, models.UpdateBasket, []string) (string, error)
 	Delete(context.Context, models.PrimaryKey) error
+	UpdateSums(context.Context, models.UpdateBasketSums) error
 }
 
 type IBasketProductStorage interface {
@@ -62,18 +95,21 @@ type IBasketProductStorage interface {
 	GetByID(context.Context, models.PrimaryKey) (models.BasketProduct, error)
 	GetList(context.Context, models.GetListRequest) (models.BasketProductResponse, error)
 	Update(context.Context, models.UpdateBasketProduct) (string, error)
+	Patch(context.Context, models.UpdateBasketProduct, []string) (string, error)
 	Delete(context.Context, models.PrimaryKey) error
-	AddProducts(context.Context, string, map[string]int) error
+	AddProducts(context.Context, string, []models.CheckProduct) error
 }
 
 type IStoreStorage interface {
-	AddProfit(ctx context.Context, profit float32, branchID string) error
-	GetStoreBudget(context.Context, string) (float32, error)
-	WithdrawalDeliveredSum(context.Context, float32, string) error
+	AddProfit(ctx context.Context, profit money.Amount, branchID string) error
+	AddLoss(ctx context.Context, loss money.Amount, branchID string) error
+	GetStoreBudget(context.Context, string) (money.Amount, error)
+	WithdrawalDeliveredSum(context.Context, money.Amount, string) error
+	GetProfits(context.Context) ([]models.BranchProfit, error)
 }
 
 type IDealerStorage interface {
-	AddSum(context.Context, int) error
+	AddSum(context.Context, money.Amount) error
 	
 }
 
@@ -82,6 +118,7 @@ type IBranchStorage interface {
 	GetByID(context.Context, models.PrimaryKey) (models.Branch, error)
 	GetList(context.Context, models.GetListRequest) (models.BranchResponse, error)
 	Update(context.Context, models.UpdateBranch) (string, error)
+	Patch(context.Context, models.UpdateBranch, []string) (string, error)
 	Delete(context.Context, models.PrimaryKey) error
 }
 
@@ -90,11 +127,109 @@ type IIncomeStorage interface {
 	GetByID(context.Context, models.PrimaryKey) (models.Income, error)
 	GetList(context.Context, models.GetListRequest) (models.IncomesResponse, error)
 	Delete(context.Context, models.PrimaryKey) error
+	Post(context.Context, models.PrimaryKey) error
 }
 
 type IIncomeProductStorage interface {
 	CreateMultiple(context.Context, models.CreateIncomeProducts) error
 	GetList(context.Context, models.GetListRequest) (models.IncomeProductsResponse, error)
+	GetByIncome(ctx context.Context, incomeID string) ([]models.IncomeProduct, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.IncomeProduct, error)
 	UpdateMultiple(context.Context, models.UpdateIncomeProducts) error
-	DeleteMultiple(context.Context, models.DeleteIncomeProducts) error
+	DeleteMultiple(context.Context, models.DeleteIncomeProducts) ([]string, error)
+}
+
+type ILoyaltyStorage interface {
+	Earn(context.Context, models.EarnLoyaltyPoints) error
+	Redeem(context.Context, models.RedeemLoyaltyPoints) error
+	Expire(context.Context, string) error
+	GetBalance(context.Context, string) (int, error)
+	GetHistory(context.Context, models.GetListRequest) (models.LoyaltyResponse, error)
+}
+
+type IProductPriceStorage interface {
+	Create(context.Context, models.CreateProductPrice) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.ProductPrice, error)
+	GetList(context.Context, models.GetListRequest) (models.ProductPricesResponse, error)
+	ActivateScheduled(context.Context) ([]models.ProductPrice, error)
+}
+
+type IBranchProductPriceStorage interface {
+	UpsertMultiple(context.Context, models.SetBranchProductPrices) error
+	GetList(context.Context, models.GetListRequest) (models.BranchProductPricesResponse, error)
+	DeleteMultiple(context.Context, models.DeleteBranchProductPrices) error
+}
+
+type ICurrencyRateStorage interface {
+	Upsert(context.Context, models.CreateCurrencyRate) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.CurrencyRate, error)
+	GetList(context.Context, models.GetListRequest) (models.CurrencyRatesResponse, error)
+	Delete(context.Context, models.PrimaryKey) error
+	GetRate(ctx context.Context, currency, date string) (models.CurrencyRate, error)
+}
+
+type IUnitStorage interface {
+	GetList(context.Context) (models.UnitsResponse, error)
+	GetByCode(context.Context, string) (models.Unit, error)
+	SetProductUnits(context.Context, models.SetProductUnits) error
+	GetProductUnits(context.Context, string) (models.ProductUnitsResponse, error)
+	GetFactors(context.Context, []string) (map[string]map[string]measure.Quantity, error)
+}
+
+type ITrashStorage interface {
+	Restore(ctx context.Context, entity, id string) error
+	Purge(context.Context, int64) (int64, error)
+}
+
+type IDependencyStorage interface {
+	Dependents(ctx context.Context, entity, id string) (models.Dependents, error)
+	DeleteCascade(ctx context.Context, entity, id string) ([]models.DeletedRow, error)
+}
+
+type IAuditStorage interface {
+	Create(context.Context, models.CreateAuditLog) error
+	GetList(context.Context, models.GetListRequest) (models.AuditLogsResponse, error)
+}
+
+type IStockMovementStorage interface {
+	Create(context.Context, models.CreateStockMovement) (string, error)
+	GetList(context.Context, models.GetListRequest) (models.StockMovementsResponse, error)
+}
+
+type IInventoryCountStorage interface {
+	Create(context.Context, models.CreateInventoryCount) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.InventoryCount, error)
+	GetList(context.Context, models.GetListRequest) (models.InventoryCountsResponse, error)
+	AddEntries(context.Context, models.CountInventory) error
+	Close(ctx context.Context, id, status, closedBy string) error
+}
+
+type IWriteOffStorage interface {
+	Create(context.Context, models.CreateWriteOff) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.WriteOff, error)
+	GetList(context.Context, models.GetListRequest) (models.WriteOffsResponse, error)
+	Close(ctx context.Context, id, status, closedBy string) error
+	SetLineCost(ctx context.Context, writeOffID, productID string, cost money.Amount) error
+}
+
+type ILotStorage interface {
+	Create(context.Context, models.CreateLot) (string, error)
+	GetList(context.Context, models.LotFilter) (models.LotsResponse, error)
+	LockAvailable(ctx context.Context, productID string, withExpired bool) ([]models.Lot, error)
+	Take(context.Context, models.TakeLot) error
+	GetTakes(ctx context.Context, kind, referenceID string) ([]models.TakeLot, error)
+	ExpiredRemaining(ctx context.Context, productID string) (measure.Quantity, error)
+}
+
+type IBackorderStorage interface {
+	Create(context.Context, models.CreateBackorder) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.Backorder, error)
+	GetList(context.Context, models.GetListRequest) (models.BackordersResponse, error)
+	LockOpen(ctx context.Context, productID string) ([]models.Backorder, error)
+	Fulfill(ctx context.Context, id string) error
+	Cancel(ctx context.Context, id string) error
+	Collect(ctx context.Context, id, saleBasketID string) error
+	Release(ctx context.Context, id string) error
+	GetUnnotified(ctx context.Context, limit, maxAttempts int) ([]models.Backorder, error)
+	MarkNotified(ctx context.Context, id string, delivered bool) error
 }